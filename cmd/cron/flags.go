@@ -5,6 +5,36 @@ import (
 	"github.com/AmazingTalker/go-rpc-kit/logkit"
 )
 
+type RedisConfig struct {
+	// Addrs indicates the map of name => host:port addresses of ring shards.
+	Addrs map[string]string `long:"addrs" description:"a map from name to the pair of host and port" env:"ADDRS" env-delim:","`
+}
+
+// LocalCacheConfig means the config for local cache
+type LocalCacheConfig struct {
+	// default size is 67108864 = 64*1024*1024
+	Size int `long:"size" description:"the size of the cache" default:"67108864" env:"SIZE"`
+}
+
+type MysqlConnConfig struct {
+	Protocol string `long:"protocol" description:"protocol" default:"tcp" env:"PROTOCOL"`
+	Host     string `long:"host" description:"host" default:"127.0.0.1" env:"HOST"`
+	Port     string `long:"port" description:"port" default:"3306" env:"PORT"`
+	User     string `long:"user" description:"user account" default:"root" env:"USER"`
+	Password string `long:"password" description:"password" default:"root" env:"PASSWORD"`
+	DBName   string `long:"database" description:"db name" default:"" env:"DATABASE_NAME"`
+	// QueryTimeoutMs bounds every query, 0 leaves them bounded by the request only.
+	QueryTimeoutMs int `long:"query-timeout-ms" description:"timeout of a query in milliseconds" default:"30000" env:"QUERY_TIMEOUT_MS"`
+
+	DSN string `long:"dsn" description:"connect url, set it will ignore all config setting" default:"" env:"DSN"`
+}
+
+// PurgeConfig configures the purge of the soft deleted records.
+type PurgeConfig struct {
+	RetentionDays int `long:"retention-days" description:"days the deleted records are kept restorable before purged" default:"30" env:"RETENTION_DAYS"`
+	BatchSize     int `long:"batch-size" description:"the number of records purged in a transaction" default:"100" env:"BATCH_SIZE"`
+}
+
 type AirbrakeConfig struct {
 	ProjectID  int64  `long:"projectId" default:"0" env:"PROJECT_ID"`
 	ProjectKey string `long:"projectKey" default:"" env:"PROJECT_KEY"`
@@ -33,10 +63,14 @@ type MetricConfig struct {
 }
 
 var env struct {
-	LoggerConfig  `group:"logger" namespace:"logger" env-namespace:"LOGGER"`
-	EnvConfig     `group:"env" namespace:"env" env-namespace:"ENV"`
-	MetricConfig  `group:"metric" namespace:"metric" env-namespace:"METRIC"`
-	MonitorConfig `group:"monitor" namespace:"monitor" env-namespace:"MONITOR"`
+	LoggerConfig     `group:"logger" namespace:"logger" env-namespace:"LOGGER"`
+	EnvConfig        `group:"env" namespace:"env" env-namespace:"ENV"`
+	MetricConfig     `group:"metric" namespace:"metric" env-namespace:"METRIC"`
+	MonitorConfig    `group:"monitor" namespace:"monitor" env-namespace:"MONITOR"`
+	MysqlConnConfig  `group:"mysql" namespace:"mysql" env-namespace:"MYSQL"`
+	RedisConfig      `group:"redis" namespace:"redis" env-namespace:"REDIS"`
+	LocalCacheConfig `group:"lc" namespace:"lc" env-namespace:"LOCAL_CACHE"`
+	PurgeConfig      `group:"purge" namespace:"purge" env-namespace:"PURGE"`
 }
 
 func init() {
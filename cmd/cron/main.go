@@ -5,12 +5,18 @@ import (
 	"os"
 	"time"
 
+	"github.com/rafaelhl/gorm-newrelic-telemetry-plugin/telemetry"
+
 	"github.com/AmazingTalker/go-amazing/pkg/cronjob"
+	"github.com/AmazingTalker/go-amazing/pkg/dao"
+	"github.com/AmazingTalker/go-rpc-kit/cachekit"
 	"github.com/AmazingTalker/go-rpc-kit/envkit"
 	"github.com/AmazingTalker/go-rpc-kit/flagkit"
 	"github.com/AmazingTalker/go-rpc-kit/logkit"
 	"github.com/AmazingTalker/go-rpc-kit/metrickit"
 	"github.com/AmazingTalker/go-rpc-kit/monitorkit"
+	"github.com/AmazingTalker/go-rpc-kit/mysqlkit"
+	"github.com/AmazingTalker/go-rpc-kit/rediskit"
 )
 
 func main() {
@@ -67,8 +73,74 @@ func main() {
 	monitorkit.Run()
 	defer monitorkit.GracefulStop()
 
+	// init redis, the purged records are evicted from the cache
+	logkit.Info(ctx, "init redis", logkit.Payload{"addrs": env.RedisConfig.Addrs})
+	ring, err := rediskit.NewRedisRing(env.RedisConfig.Addrs)
+	if err != nil {
+		logkit.FatalV2(ctx, "init redis failed", err, nil)
+	}
+	defer ring.Close()
+
+	// init cache
+	logkit.Info(ctx, "init cache", logkit.Payload{"size": env.LocalCacheConfig.Size})
+	cacheSrv := cachekit.NewCache(
+		cachekit.NewSharedCache(ring),
+		cachekit.NewLocalCache(env.LocalCacheConfig.Size),
+	)
+
+	mysqlCfg, err := mysqlkit.NewMySQLConfig(mysqlkit.MysqlConnConf{
+		Protocol: env.MysqlConnConfig.Protocol,
+		Host:     env.MysqlConnConfig.Host,
+		Port:     env.MysqlConnConfig.Port,
+		User:     env.MysqlConnConfig.User,
+		Password: env.MysqlConnConfig.Password,
+		DBName:   env.MysqlConnConfig.DBName,
+		DSN:      env.MysqlConnConfig.DSN,
+	})
+	if err != nil {
+		logkit.FatalV2(ctx, "init mysql config failed", err, nil)
+	}
+
+	// the migrations are run by the rpc server
+	db, err := mysqlkit.NewGORM(
+		mysqlCfg,
+		telemetry.NewNrTracer(
+			mysqlCfg.DBName, // db name
+			mysqlCfg.Addr,   // Addr is the name of the server hosting the datastore.
+			"MySQL",         // product name: fixed string defined by new relic
+		),
+		metrickit.NewGORMTracer(metrickit.Setting{
+			DBName: mysqlCfg.DBName,
+			Metric: metrickit.New("gorm"),
+		}),
+	)
+	if err != nil {
+		logkit.FatalV2(ctx, "init gorm failed", err, nil)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		logkit.FatalV2(ctx, "invalid db", err, nil)
+	}
+	defer sqlDB.Close()
+
+	// the job doesn't read the records through the cache
+	recordDao := dao.NewRecordDAO(db, cacheSrv, ring, dao.RecordDAOOpt{
+		MySql: dao.MySqlOpt{
+			QueryTimeout: time.Duration(env.MysqlConnConfig.QueryTimeoutMs) * time.Millisecond,
+		},
+	})
+
 	// Here is the job get started
-	if err := cronjob.Execute(ctx); err != nil {
+	logkit.Info(ctx, "start cronjob", logkit.Payload{
+		"retentionDays":  env.PurgeConfig.RetentionDays,
+		"purgeBatchSize": env.PurgeConfig.BatchSize,
+	})
+	if err := cronjob.Execute(ctx, cronjob.Opt{
+		RecordDao:      recordDao,
+		Retention:      time.Duration(env.PurgeConfig.RetentionDays) * 24 * time.Hour,
+		PurgeBatchSize: env.PurgeConfig.BatchSize,
+	}); err != nil {
 		logkit.ErrorV2(ctx, "Cronjob executed failed", err, nil)
 	}
 
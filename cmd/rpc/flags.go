@@ -16,6 +16,15 @@ type LocalCacheConfig struct {
 	Size int `long:"size" description:"the size of the cache" default:"67108864" env:"SIZE"`
 }
 
+// CacheConfig protects the cached records against the stampedes.
+type CacheConfig struct {
+	RebuildLock   bool `long:"rebuild-lock" description:"let only one pod load a missed record" env:"REBUILD_LOCK"`
+	RebuildWaitMs int  `long:"rebuild-wait-ms" description:"milliseconds to wait for the record loaded by another pod" default:"500" env:"REBUILD_WAIT_MS"`
+	// EarlyRefreshBeta is 1 usually, 0 disables the early refreshes and it's off by default.
+	EarlyRefreshBeta float64 `long:"early-refresh-beta" description:"how early the hot records are refreshed before they expire, 0 disables it" default:"0" env:"EARLY_REFRESH_BETA"`
+	NegativeTTLMs    int     `long:"negative-ttl-ms" description:"milliseconds to cache the ids not found, 0 disables it" default:"5000" env:"NEGATIVE_TTL_MS"`
+}
+
 type AirbrakeConfig struct {
 	ProjectID  int64  `long:"projectId" default:"0" env:"PROJECT_ID"`
 	ProjectKey string `long:"projectKey" default:"" env:"PROJECT_KEY"`
@@ -57,21 +66,56 @@ type MysqlConnConfig struct {
 	User     string `long:"user" description:"user account" default:"root" env:"USER"`
 	Password string `long:"password" description:"password" default:"root" env:"PASSWORD"`
 	DBName   string `long:"database" description:"db name" default:"" env:"DATABASE_NAME"`
+	// QueryTimeoutMs bounds every query, 0 leaves them bounded by the request only.
+	QueryTimeoutMs int `long:"query-timeout-ms" description:"timeout of a query in milliseconds" default:"3000" env:"QUERY_TIMEOUT_MS"`
 
 	DSN string `long:"dsn" description:"connect url, set it will ignore all config setting" default:"" env:"DSN"`
 }
 
+type PageTokenConfig struct {
+	Secret string `long:"secret" description:"the secret signing the page tokens of ListRecord, required" env:"SECRET"`
+}
+
+type ShutdownConfig struct {
+	// DrainSeconds gives the load balancers time to notice the failing readiness before the servers stop.
+	DrainSeconds   int `long:"drain-seconds" description:"seconds between failing the readiness and stopping the servers" default:"5" env:"DRAIN_SECONDS"`
+	TimeoutSeconds int `long:"timeout-seconds" description:"deadline in seconds for the servers to finish the in-flight requests" default:"20" env:"TIMEOUT_SECONDS"`
+}
+
+type HealthConfig struct {
+	TimeoutMs int `long:"timeout-ms" description:"timeout in milliseconds of pinging a dependency" default:"1000" env:"TIMEOUT_MS"`
+	// TTLMs caches the report, so the probes don't hammer the dependencies.
+	TTLMs int `long:"ttl-ms" description:"milliseconds to cache the report of the dependencies" default:"2000" env:"TTL_MS"`
+}
+
+// AuthConfig configures the authenticators, at least one of them is required.
+type AuthConfig struct {
+	JWTHMACSecret       string            `long:"jwt-hmac-secret" description:"the secret verifying the HS256 tokens" env:"JWT_HMAC_SECRET"`
+	JWTRSAPublicKeyFile string            `long:"jwt-rsa-public-key-file" description:"the PEM file of the public key verifying the RS256 tokens" env:"JWT_RSA_PUBLIC_KEY_FILE"`
+	JWTIssuer           string            `long:"jwt-issuer" description:"the expected iss of the tokens, not checked when empty" env:"JWT_ISSUER"`
+	JWTAudience         string            `long:"jwt-audience" description:"the expected aud of the tokens, not checked when empty" env:"JWT_AUDIENCE"`
+	APIKeys             map[string]string `long:"api-keys" description:"a map from the service name to its api key" env:"API_KEYS" env-delim:","`
+	// APIKeyRoles binds the roles to the api keys, the callers with the api keys can't claim any other.
+	APIKeyRoles map[string]string `long:"api-key-roles" description:"a map from the service name to the space separated roles of its api key" env:"API_KEY_ROLES" env-delim:","`
+}
+
 var env struct {
 	HTTPAddr         string `short:"h" long:"http.addr" env:"HTTP_ADDR" default:":8080"`
 	GRPCAddr         string `short:"g" long:"grpc.addr" env:"GRPC_ADDR" default:":8081"`
+	GRPCReflection   bool   `long:"grpc.reflection" description:"enable the grpc server reflection, ignored in production" env:"GRPC_REFLECTION"`
 	LoggerConfig     `group:"logger" namespace:"logger" env-namespace:"LOGGER"`
 	MysqlConnConfig  `group:"mysql" namespace:"mysql" env-namespace:"MYSQL"`
 	RedisConfig      `group:"redis" namespace:"redis" env-namespace:"REDIS"`
 	LocalCacheConfig `group:"lc" namespace:"lc" env-namespace:"LOCAL_CACHE"`
+	CacheConfig      `group:"cache" namespace:"cache" env-namespace:"CACHE"`
 	EnvConfig        `group:"env" namespace:"env" env-namespace:"ENV"`
 	MetricConfig     `group:"metric" namespace:"metric" env-namespace:"METRIC"`
 	MonitorConfig    `group:"monitor" namespace:"monitor" env-namespace:"MONITOR"`
 	EtcdConfig       `group:"etcd" namespace:"etcd" env-namespace:"ETCD"`
+	PageTokenConfig  `group:"pagetoken" namespace:"pagetoken" env-namespace:"PAGE_TOKEN"`
+	ShutdownConfig   `group:"shutdown" namespace:"shutdown" env-namespace:"SHUTDOWN"`
+	HealthConfig     `group:"health" namespace:"health" env-namespace:"HEALTH"`
+	AuthConfig       `group:"auth" namespace:"auth" env-namespace:"AUTH"`
 }
 
 func init() {
@@ -2,8 +2,13 @@ package main
 
 import (
 	"context"
+	"errors"
 	"net"
-	"sync"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -11,8 +16,13 @@ import (
 	etcd "go.etcd.io/etcd/client/v3"
 	"google.golang.org/grpc"
 
+	"github.com/AmazingTalker/go-amazing/pkg/auth"
+	"github.com/AmazingTalker/go-amazing/pkg/cachestats"
 	"github.com/AmazingTalker/go-amazing/pkg/dao"
+	"github.com/AmazingTalker/go-amazing/pkg/health"
+	"github.com/AmazingTalker/go-amazing/pkg/interceptor"
 	"github.com/AmazingTalker/go-amazing/pkg/pb"
+	"github.com/AmazingTalker/go-amazing/pkg/ratelimit"
 	"github.com/AmazingTalker/go-amazing/pkg/rpc"
 	"github.com/AmazingTalker/go-rpc-kit/cachekit"
 	"github.com/AmazingTalker/go-rpc-kit/configkit"
@@ -32,11 +42,20 @@ const (
 )
 
 type ServiceLauncher struct {
-	Run    func() error
-	Labels []string
+	Run func() error
+	// Shutdown stops the service gracefully, the in-flight requests are dropped when ctx is done.
+	Shutdown func(ctx context.Context) error
+	Labels   []string
 }
 
 func main() {
+	// exit with the code after all the deferred cleanups are done
+	exitCode := 0
+	defer func() {
+		if exitCode != 0 {
+			os.Exit(exitCode)
+		}
+	}()
 
 	// get env
 	if err := flagkit.Parse(); err != nil {
@@ -83,7 +102,12 @@ func main() {
 		// Because eks-staging-v2 haven't deployed the ETCD. I ignore this Fatal, use normal Error instead.
 		logkit.ErrorV2(ctx, "init etcd failed", err, nil)
 	}
-	defer etcdCli.Close()
+	defer func() {
+		// etcdCli is nil when etcd is not deployed
+		if etcdCli != nil {
+			etcdCli.Close()
+		}
+	}()
 
 	// publishing the configs to etcd in development env
 	if envkit.Namespace() == envkit.EnvDevelopment {
@@ -139,12 +163,16 @@ func main() {
 	if err != nil {
 		logkit.FatalV2(ctx, "init redis failed", err, nil)
 	}
+	defer ring.Close()
 
 	// init cache
 	logkit.Info(ctx, "init cache", logkit.Payload{"size": env.LocalCacheConfig.Size})
+	// the tiers are metered, and the occupancy of the local one is reported by /debug/cache
+	cacheMet := metrickit.New("cache")
+	localCache := cachestats.NewLocalAdapter(cachekit.NewLocalCache(env.LocalCacheConfig.Size), env.LocalCacheConfig.Size, cacheMet)
 	cacheSrv := cachekit.NewCache(
-		cachekit.NewSharedCache(ring),
-		cachekit.NewLocalCache(env.LocalCacheConfig.Size),
+		cachestats.NewAdapter(cachestats.TierShared, cachekit.NewSharedCache(ring), cacheMet),
+		localCache,
 	)
 
 	mysqlCfg, err := mysqlkit.NewMySQLConfig(mysqlkit.MysqlConnConf{
@@ -198,73 +226,214 @@ func main() {
 	logkit.Infof(ctx, "init validator")
 	validator := validatorkit.NewGoPlaygroundValidator()
 
+	// init authenticator
+	logkit.Info(ctx, "init authenticator", logkit.Payload{
+		"jwtHS256":     env.AuthConfig.JWTHMACSecret != "",
+		"jwtRS256":     env.AuthConfig.JWTRSAPublicKeyFile,
+		"apiKeysCount": len(env.AuthConfig.APIKeys),
+	})
+	authn := auth.Authenticators{}
+	if env.AuthConfig.JWTHMACSecret != "" || env.AuthConfig.JWTRSAPublicKeyFile != "" {
+		opt := auth.JWTOpt{
+			HMACSecret: []byte(env.AuthConfig.JWTHMACSecret),
+			Issuer:     env.AuthConfig.JWTIssuer,
+			Audience:   env.AuthConfig.JWTAudience,
+		}
+
+		if env.AuthConfig.JWTRSAPublicKeyFile != "" {
+			pem, err := os.ReadFile(env.AuthConfig.JWTRSAPublicKeyFile)
+			if err != nil {
+				logkit.FatalV2(ctx, "read rsa public key failed", err, nil)
+			}
+
+			if opt.RSAPublicKey, err = auth.ParseRSAPublicKey(pem); err != nil {
+				logkit.FatalV2(ctx, "parse rsa public key failed", err, nil)
+			}
+		}
+
+		authn = append(authn, auth.NewJWTAuthenticator(opt))
+	}
+	if len(env.AuthConfig.APIKeys) > 0 {
+		keys := map[string]auth.APIKey{}
+		for name, key := range env.AuthConfig.APIKeys {
+			keys[name] = auth.APIKey{Key: key, Roles: strings.Fields(env.AuthConfig.APIKeyRoles[name])}
+		}
+
+		authn = append(authn, auth.NewAPIKeyAuthenticator(keys))
+	}
+	if len(authn) == 0 {
+		logkit.FatalV2(ctx, "init authenticator failed", errors.New("neither the jwt keys nor the api keys are configured"), nil)
+	}
+
+	// init health checker
+	logkit.Info(ctx, "init health checker", logkit.Payload{
+		"timeoutMs": env.HealthConfig.TimeoutMs,
+		"ttlMs":     env.HealthConfig.TTLMs,
+	})
+	checks := []health.Check{health.MySQLCheck(db)}
+	checks = append(checks, health.RedisShardChecks(ring, env.RedisConfig.Addrs)...)
+	checks = append(checks, health.EtcdCheck(etcdCli))
+	checker := health.NewChecker(health.CheckerOpt{
+		Checks:  checks,
+		Timeout: time.Duration(env.HealthConfig.TimeoutMs) * time.Millisecond,
+		TTL:     time.Duration(env.HealthConfig.TTLMs) * time.Millisecond,
+	})
+
+	recordDao := dao.NewRecordDAO(db, cacheSrv, ring, dao.RecordDAOOpt{
+		MySql: dao.MySqlOpt{
+			QueryTimeout: time.Duration(env.MysqlConnConfig.QueryTimeoutMs) * time.Millisecond,
+		},
+		Cache: dao.CacheOpt{
+			RebuildLock:      env.CacheConfig.RebuildLock,
+			RebuildWait:      time.Duration(env.CacheConfig.RebuildWaitMs) * time.Millisecond,
+			EarlyRefreshBeta: env.CacheConfig.EarlyRefreshBeta,
+			NegativeTTL:      time.Duration(env.CacheConfig.NegativeTTLMs) * time.Millisecond,
+		},
+		LocalCache: localCache,
+	})
+
+	// the page tokens are signed by the secret shared by the pods, a token issued by a pod is
+	// served by any other
+	if env.PageTokenConfig.Secret == "" {
+		logkit.FatalV2(ctx, "init page token failed", errors.New("the page token secret isn't configured"), nil)
+	}
+
 	// init server base
 	logkit.Infof(ctx, "init server")
 	serv := rpc.NewGoAmazingServer(rpc.GoAmazingServerOpt{
-		Validator: validator,
-		RecordDao: dao.NewRecordDAO(db, cacheSrv),
+		Validator:       validator,
+		RecordDao:       recordDao,
+		PageTokenSecret: env.PageTokenConfig.Secret,
+		Health:          checker,
+		// the limits are per pod when redis is unreachable
+		RateLimiter: ratelimit.NewFallbackLimiter(ratelimit.NewRedisLimiter(ring), ratelimit.NewLocalLimiter()),
 	})
 
 	// init service
-	var wg sync.WaitGroup
-
 	launchers := []*ServiceLauncher{
-		NewGrpcSvcLauncher(env.GRPCAddr, serv),
-		NewHttpSvcLauncher(env.HTTPAddr, serv),
+		NewGrpcSvcLauncher(env.GRPCAddr, serv, checker, authn),
+		NewHttpSvcLauncher(env.HTTPAddr, serv, checker, authn, localCache),
 	}
 
 	logkit.Infof(ctx, "launching service")
 
 	// launch service
+	errCh := make(chan error, len(launchers))
 	for i := range launchers {
 		l := launchers[i]
-		wg.Add(1)
 		go func() {
-			defer wg.Done()
 			if err := l.Run(); err != nil {
-				logkit.FatalV2(ctx, "launcher failed", err, logkit.Payload{"labels": l.Labels})
+				logkit.ErrorV2(ctx, "launcher failed", err, logkit.Payload{"labels": l.Labels})
+				errCh <- err
 			}
 		}()
 	}
 
-	wg.Wait()
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGTERM, syscall.SIGINT)
+
+	select {
+	case sig := <-sigCh:
+		logkit.Info(ctx, "received signal, shutting down", logkit.Payload{"signal": sig.String()})
+	case <-errCh:
+		exitCode = 1
+	}
+
+	if err := shutdown(ctx, launchers, shutdownOpt{
+		Drain:      checker.Drain,
+		DrainDelay: time.Duration(env.ShutdownConfig.DrainSeconds) * time.Second,
+		Timeout:    time.Duration(env.ShutdownConfig.TimeoutSeconds) * time.Second,
+		Clock:      systemClock,
+	}); err != nil {
+		exitCode = 1
+	}
+
+	logkit.Infof(ctx, "service stopped, closing connections")
 }
 
 // NewGrpcSvcLauncher 3-1. You need add a gRPC listener and register the service.
-func NewGrpcSvcLauncher(addr string, serv pb.GoAmazingServer) *ServiceLauncher {
+func NewGrpcSvcLauncher(addr string, serv pb.GoAmazingServer, checker *health.Checker, authn auth.Authenticator) *ServiceLauncher {
 
 	lis, err := net.Listen("tcp", addr)
 	if err != nil {
 		logkit.FatalV2(context.TODO(), "failed to start listen tpc", err, nil)
 	}
 
-	s := grpc.NewServer()
+	s := grpc.NewServer(interceptor.ServerOptions(interceptor.Opt{
+		Metric: metrickit.New("grpc"),
+		// the rate limit takes the peer ip and sets the retry-after metadata for the limits of
+		// the services, the authentication puts the caller into the context.
+		Unary:  []grpc.UnaryServerInterceptor{ratelimit.UnaryServerInterceptor(), auth.UnaryServerInterceptor(authn)},
+		Stream: []grpc.StreamServerInterceptor{auth.StreamServerInterceptor(authn)},
+	})...)
 
 	pb.RegisterGoAmazingGrpcService(s, serv) // 3-2. Run "RegisterGoAmazingGrpcService"
 
+	// grpc.health.v1 reports the readiness of all the services registered above
+	reflect := env.GRPCReflection && envkit.Namespace() != envkit.EnvProduction
+	if reflect {
+		logkit.Infof(context.TODO(), "enable grpc reflection")
+	}
+	healthSrv := health.RegisterGrpcServer(s, checker, reflect)
+
+	healthCtx, stopHealth := context.WithCancel(context.Background())
+
 	return &ServiceLauncher{
 		Labels: []string{"grpc"},
 		Run: func() error {
+			go healthSrv.Run(healthCtx)
 			return s.Serve(lis)
 		},
+		Shutdown: func(ctx context.Context) error {
+			stopHealth()
+			// NOT_SERVING for the clients still watching
+			healthSrv.Shutdown()
+
+			done := make(chan struct{})
+			go func() {
+				s.GracefulStop()
+				close(done)
+			}()
+
+			select {
+			case <-done:
+				return nil
+			case <-ctx.Done():
+				// cut the remaining connections, GracefulStop returns then
+				s.Stop()
+				return ctx.Err()
+			}
+		},
 	}
 }
 
 // NewHttpSvcLauncher 4-1. You need add a HTTP listener and register the service.
-func NewHttpSvcLauncher(addr string, serv pb.GoAmazingServer) *ServiceLauncher {
+func NewHttpSvcLauncher(addr string, serv pb.GoAmazingServer, checker *health.Checker, authn auth.Authenticator, localCache *cachestats.LocalAdapter) *ServiceLauncher {
 
 	// TODO: move details into RegisterGoAmazingHttpService
 
 	s := gin.New()
 	s.Use(gin.Recovery())
 	s.Use(metrickit.Middleware(metrickit.New("gin")))
+	s.Use(interceptor.GinRequestIDMiddleware())
+	s.Use(ratelimit.GinMiddleware())
+	s.Use(auth.GinMiddleware(authn))
+	s.Use(rpc.GinMiddleware())
 
 	pb.RegisterGoAmazingHttpService(s, serv) // 4-2. Run "RegisterGoAmazingHttpService"
+	health.EnrichGinRouter(s, checker)
+	cachestats.EnrichGinRouter(s, localCache)
+
+	srv := &http.Server{Addr: addr, Handler: s}
 
 	return &ServiceLauncher{
 		Labels: []string{"http"},
 		Run: func() error {
-			return s.Run(addr)
+			if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+				return err
+			}
+			return nil
 		},
+		Shutdown: srv.Shutdown,
 	}
 }
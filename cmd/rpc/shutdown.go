@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+
+	"github.com/AmazingTalker/go-rpc-kit/logkit"
+)
+
+// clock is the time of the shutdown, the tests fake it.
+type clock struct {
+	Sleep       func(time.Duration)
+	WithTimeout func(context.Context, time.Duration) (context.Context, context.CancelFunc)
+}
+
+var systemClock = clock{Sleep: time.Sleep, WithTimeout: context.WithTimeout}
+
+type shutdownOpt struct {
+	// Drain fails the readiness.
+	Drain func()
+	// DrainDelay gives the load balancers time to notice the failing readiness.
+	DrainDelay time.Duration
+	// Timeout is the deadline for the launchers to finish the in-flight requests.
+	Timeout time.Duration
+	Clock   clock
+}
+
+// shutdown fails the readiness, waits for the load balancers to notice it, then shuts down all
+// the launchers in parallel within the timeout. The errors of all the launchers are combined.
+func shutdown(ctx context.Context, launchers []*ServiceLauncher, opt shutdownOpt) error {
+	opt.Drain()
+	opt.Clock.Sleep(opt.DrainDelay)
+
+	ctx, cancel := opt.Clock.WithTimeout(ctx, opt.Timeout)
+	defer cancel()
+
+	errs := make([]error, len(launchers))
+	var wg sync.WaitGroup
+	for i := range launchers {
+		i, l := i, launchers[i]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := l.Shutdown(ctx); err != nil {
+				logkit.ErrorV2(ctx, "launcher shutdown failed", err, logkit.Payload{"labels": l.Labels})
+				errs[i] = fmt.Errorf("shutdown %v: %w", l.Labels, err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return multierr.Combine(errs...)
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/multierr"
+)
+
+var (
+	mockCTX = context.Background()
+	mockErr = errors.New("XD")
+)
+
+type shutdownSuite struct {
+	suite.Suite
+
+	mu     sync.Mutex
+	events []string
+}
+
+func TestShutdownSuite(t *testing.T) {
+	suite.Run(t, new(shutdownSuite))
+}
+
+func (s *shutdownSuite) SetupTest() {
+	s.events = nil
+}
+
+func (s *shutdownSuite) record(event string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+}
+
+// fakeClock records the sleeps and the timeouts, the deadline is cut to timeout.
+func (s *shutdownSuite) fakeClock(timeout time.Duration) clock {
+	return clock{
+		Sleep: func(d time.Duration) {
+			s.record("sleep " + d.String())
+		},
+		WithTimeout: func(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+			s.record("timeout " + d.String())
+			return context.WithTimeout(ctx, timeout)
+		},
+	}
+}
+
+// launcher returns the launcher shutting down by fn.
+func (s *shutdownSuite) launcher(label string, fn func(ctx context.Context) error) *ServiceLauncher {
+	return &ServiceLauncher{
+		Labels: []string{label},
+		Shutdown: func(ctx context.Context) error {
+			s.record("shutdown " + label)
+			return fn(ctx)
+		},
+	}
+}
+
+func (s *shutdownSuite) TestShutdown() {
+	ok := func(context.Context) error { return nil }
+	fail := func(context.Context) error { return mockErr }
+	// hang ignores the in-flight requests until the deadline
+	hang := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	tests := []struct {
+		Desc      string
+		Launchers func() []*ServiceLauncher
+		ExpErrs   []error
+	}{
+		{
+			Desc: "normal case",
+			Launchers: func() []*ServiceLauncher {
+				return []*ServiceLauncher{s.launcher("grpc", ok), s.launcher("http", ok)}
+			},
+		},
+		{
+			Desc: "shutdown failed",
+			Launchers: func() []*ServiceLauncher {
+				return []*ServiceLauncher{s.launcher("grpc", fail), s.launcher("http", ok)}
+			},
+			ExpErrs: []error{mockErr},
+		},
+		{
+			Desc: "all failed",
+			Launchers: func() []*ServiceLauncher {
+				return []*ServiceLauncher{s.launcher("grpc", fail), s.launcher("http", hang)}
+			},
+			ExpErrs: []error{mockErr, context.DeadlineExceeded},
+		},
+		{
+			Desc: "timeout",
+			Launchers: func() []*ServiceLauncher {
+				return []*ServiceLauncher{s.launcher("grpc", hang), s.launcher("http", ok)}
+			},
+			ExpErrs: []error{context.DeadlineExceeded},
+		},
+		{
+			Desc: "in parallel",
+			Launchers: func() []*ServiceLauncher {
+				// grpc finishes only when http is shutting down as well
+				started := make(chan struct{})
+				return []*ServiceLauncher{
+					s.launcher("grpc", func(ctx context.Context) error {
+						select {
+						case <-started:
+							return nil
+						case <-ctx.Done():
+							return ctx.Err()
+						}
+					}),
+					s.launcher("http", func(context.Context) error {
+						close(started)
+						return nil
+					}),
+				}
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.SetupTest()
+
+		err := shutdown(mockCTX, t.Launchers(), shutdownOpt{
+			Drain:      func() { s.record("drain") },
+			DrainDelay: 5 * time.Second,
+			Timeout:    20 * time.Second,
+			Clock:      s.fakeClock(100 * time.Millisecond),
+		})
+
+		s.Require().Equal([]string{"drain", "sleep 5s", "timeout 20s"}, s.events[:3], t.Desc)
+		s.Require().ElementsMatch([]string{"shutdown grpc", "shutdown http"}, s.events[3:], t.Desc)
+
+		errs := multierr.Errors(err)
+		s.Require().Len(errs, len(t.ExpErrs), t.Desc)
+		for i, exp := range t.ExpErrs {
+			s.Require().ErrorIs(errs[i], exp, t.Desc)
+		}
+	}
+}
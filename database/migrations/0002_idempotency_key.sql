@@ -0,0 +1,10 @@
+-- +goose Up
+ALTER TABLE `records`
+	ADD COLUMN `idempotency_key` varchar(255) NULL,
+	ADD COLUMN `request_hash`    char(64) NULL,
+	ADD UNIQUE KEY `uk_records_idempotency_key` (`idempotency_key`);
+-- +goose Down
+ALTER TABLE `records`
+	DROP INDEX `uk_records_idempotency_key`,
+	DROP COLUMN `request_hash`,
+	DROP COLUMN `idempotency_key`;
@@ -0,0 +1,26 @@
+-- +goose Up
+UPDATE `records` SET `id` = UUID() WHERE `id` IS NULL;
+UPDATE `records` SET `the_num` = 0 WHERE `the_num` IS NULL;
+UPDATE `records` SET `the_str` = '' WHERE `the_str` IS NULL;
+UPDATE `records` SET `created_at` = CURRENT_TIMESTAMP WHERE `created_at` IS NULL;
+UPDATE `records` SET `updated_at` = `created_at` WHERE `updated_at` IS NULL;
+
+ALTER TABLE `records`
+	MODIFY `id`         varchar(36) NOT NULL,
+	MODIFY `the_num`    INTEGER NOT NULL DEFAULT 0,
+	MODIFY `the_str`    varchar(255) NOT NULL DEFAULT '',
+	MODIFY `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	MODIFY `updated_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+	ADD PRIMARY KEY (`id`),
+	ADD INDEX `idx_records_created_at` (`created_at`),
+	ADD INDEX `idx_records_the_num` (`the_num`);
+-- +goose Down
+ALTER TABLE `records`
+	DROP INDEX `idx_records_the_num`,
+	DROP INDEX `idx_records_created_at`,
+	DROP PRIMARY KEY,
+	MODIFY `id`         varchar(255),
+	MODIFY `the_num`    INTEGER,
+	MODIFY `the_str`    varchar(255),
+	MODIFY `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	MODIFY `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP;
@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS `record_revisions` (
+	`id`         BIGINT NOT NULL AUTO_INCREMENT,
+	`record_id`  varchar(36) NOT NULL,
+	`action`     varchar(16) NOT NULL,
+	`actor`      varchar(255) NOT NULL DEFAULT '',
+	`request_id` varchar(255) NOT NULL DEFAULT '',
+	`old_value`  JSON NULL,
+	`new_value`  JSON NULL,
+	`created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (`id`),
+	INDEX `idx_record_revisions_record_id` (`record_id`, `id`)
+);
+-- +goose Down
+DROP TABLE `record_revisions`;
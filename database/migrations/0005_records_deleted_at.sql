@@ -0,0 +1,12 @@
+-- +goose Up
+ALTER TABLE `records`
+	ADD COLUMN `deleted_at` TIMESTAMP NULL DEFAULT NULL,
+	ADD INDEX `idx_records_deleted_at` (`deleted_at`);
+-- +goose Down
+-- the soft-deleted records would come back as live ones without deleted_at, so the rollback fails
+-- till they're purged or restored. The subquery has a second row for them, and mysql fails it with
+-- "Subquery returns more than 1 row".
+DO (SELECT 1 UNION ALL (SELECT 1 FROM `records` WHERE `deleted_at` IS NOT NULL LIMIT 1));
+ALTER TABLE `records`
+	DROP INDEX `idx_records_deleted_at`,
+	DROP COLUMN `deleted_at`;
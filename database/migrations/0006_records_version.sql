@@ -0,0 +1,6 @@
+-- +goose Up
+ALTER TABLE `records`
+	ADD COLUMN `version` BIGINT NOT NULL DEFAULT 1;
+-- +goose Down
+ALTER TABLE `records`
+	DROP COLUMN `version`;
@@ -11,6 +11,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	testing "testing"
+
+	time "time"
 )
 
 // RecordDAO is an autogenerated mock type for the RecordDAO type
@@ -18,6 +20,50 @@ type RecordDAO struct {
 	mock.Mock
 }
 
+// BatchGetRecords provides a mock function with given fields: _a0, _a1
+func (_m *RecordDAO) BatchGetRecords(_a0 context.Context, _a1 []string) ([]*dao.Record, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 []*dao.Record
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []*dao.Record); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dao.Record)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountRecords provides a mock function with given fields: _a0, _a1
+func (_m *RecordDAO) CountRecords(_a0 context.Context, _a1 dao.RecordFilter) (int64, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, dao.RecordFilter) int64); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, dao.RecordFilter) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateRecord provides a mock function with given fields: _a0, _a1, _a2
 func (_m *RecordDAO) CreateRecord(_a0 context.Context, _a1 *dao.Record, _a2 ...daokit.Enrich) error {
 	_va := make([]interface{}, len(_a2))
@@ -39,6 +85,27 @@ func (_m *RecordDAO) CreateRecord(_a0 context.Context, _a1 *dao.Record, _a2 ...d
 	return r0
 }
 
+// DeleteRecord provides a mock function with given fields: _a0, _a1, _a2
+func (_m *RecordDAO) DeleteRecord(_a0 context.Context, _a1 string, _a2 ...daokit.Enrich) error {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...daokit.Enrich) error); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetRecord provides a mock function with given fields: _a0, _a1
 func (_m *RecordDAO) GetRecord(_a0 context.Context, _a1 string) (*dao.Record, error) {
 	ret := _m.Called(_a0, _a1)
@@ -62,6 +129,29 @@ func (_m *RecordDAO) GetRecord(_a0 context.Context, _a1 string) (*dao.Record, er
 	return r0, r1
 }
 
+// ListRecordRevisions provides a mock function with given fields: _a0, _a1, _a2
+func (_m *RecordDAO) ListRecordRevisions(_a0 context.Context, _a1 string, _a2 dao.ListRevisionsOpt) ([]dao.RecordRevision, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 []dao.RecordRevision
+	if rf, ok := ret.Get(0).(func(context.Context, string, dao.ListRevisionsOpt) []dao.RecordRevision); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dao.RecordRevision)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, dao.ListRevisionsOpt) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListRecords provides a mock function with given fields: _a0, _a1
 func (_m *RecordDAO) ListRecords(_a0 context.Context, _a1 dao.ListRecordsOpt) ([]dao.Record, error) {
 	ret := _m.Called(_a0, _a1)
@@ -85,6 +175,80 @@ func (_m *RecordDAO) ListRecords(_a0 context.Context, _a1 dao.ListRecordsOpt) ([
 	return r0, r1
 }
 
+// PurgeRecords provides a mock function with given fields: _a0, _a1, _a2
+func (_m *RecordDAO) PurgeRecords(_a0 context.Context, _a1 time.Time, _a2 int) ([]string, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []string); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreRecord provides a mock function with given fields: _a0, _a1, _a2
+func (_m *RecordDAO) RestoreRecord(_a0 context.Context, _a1 string, _a2 ...daokit.Enrich) (*dao.Record, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *dao.Record
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...daokit.Enrich) *dao.Record); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dao.Record)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...daokit.Enrich) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateRecord provides a mock function with given fields: _a0, _a1, _a2
+func (_m *RecordDAO) UpdateRecord(_a0 context.Context, _a1 *dao.Record, _a2 ...daokit.Enrich) error {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dao.Record, ...daokit.Enrich) error); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewRecordDAO creates a new instance of RecordDAO. It also registers the testing.TB interface on the mock and a cleanup function to assert the mocks expectations.
 func NewRecordDAO(t testing.TB) *RecordDAO {
 	mock := &RecordDAO{}
@@ -19,6 +19,36 @@ type GoAmazingClient struct {
 	mock.Mock
 }
 
+// BatchGetRecords provides a mock function with given fields: ctx, in, opts
+func (_m *GoAmazingClient) BatchGetRecords(ctx context.Context, in *pb.BatchGetRecordsReq, opts ...grpc.CallOption) (*pb.BatchGetRecordsRes, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *pb.BatchGetRecordsRes
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.BatchGetRecordsReq, ...grpc.CallOption) *pb.BatchGetRecordsRes); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.BatchGetRecordsRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.BatchGetRecordsReq, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Config provides a mock function with given fields: ctx, in, opts
 func (_m *GoAmazingClient) Config(ctx context.Context, in *pb.ConfigReq, opts ...grpc.CallOption) (*pb.ConfigRes, error) {
 	_va := make([]interface{}, len(opts))
@@ -79,6 +109,36 @@ func (_m *GoAmazingClient) CreateRecord(ctx context.Context, in *pb.CreateRecord
 	return r0, r1
 }
 
+// DeleteRecord provides a mock function with given fields: ctx, in, opts
+func (_m *GoAmazingClient) DeleteRecord(ctx context.Context, in *pb.DeleteRecordReq, opts ...grpc.CallOption) (*pb.DeleteRecordRes, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *pb.DeleteRecordRes
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.DeleteRecordReq, ...grpc.CallOption) *pb.DeleteRecordRes); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.DeleteRecordRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.DeleteRecordReq, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetRecord provides a mock function with given fields: ctx, in, opts
 func (_m *GoAmazingClient) GetRecord(ctx context.Context, in *pb.GetRecordReq, opts ...grpc.CallOption) (*pb.GetRecordRes, error) {
 	_va := make([]interface{}, len(opts))
@@ -169,6 +229,96 @@ func (_m *GoAmazingClient) ListRecord(ctx context.Context, in *pb.ListRecordReq,
 	return r0, r1
 }
 
+// ListRecordRevisions provides a mock function with given fields: ctx, in, opts
+func (_m *GoAmazingClient) ListRecordRevisions(ctx context.Context, in *pb.ListRecordRevisionsReq, opts ...grpc.CallOption) (*pb.ListRecordRevisionsRes, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *pb.ListRecordRevisionsRes
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.ListRecordRevisionsReq, ...grpc.CallOption) *pb.ListRecordRevisionsRes); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.ListRecordRevisionsRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.ListRecordRevisionsReq, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreRecord provides a mock function with given fields: ctx, in, opts
+func (_m *GoAmazingClient) RestoreRecord(ctx context.Context, in *pb.RestoreRecordReq, opts ...grpc.CallOption) (*pb.RestoreRecordRes, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *pb.RestoreRecordRes
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.RestoreRecordReq, ...grpc.CallOption) *pb.RestoreRecordRes); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.RestoreRecordRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.RestoreRecordReq, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateRecord provides a mock function with given fields: ctx, in, opts
+func (_m *GoAmazingClient) UpdateRecord(ctx context.Context, in *pb.UpdateRecordReq, opts ...grpc.CallOption) (*pb.UpdateRecordRes, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *pb.UpdateRecordRes
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.UpdateRecordReq, ...grpc.CallOption) *pb.UpdateRecordRes); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.UpdateRecordRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.UpdateRecordReq, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewGoAmazingClient creates a new instance of GoAmazingClient. It also registers the testing.TB interface on the mock and a cleanup function to assert the mocks expectations.
 func NewGoAmazingClient(t testing.TB) *GoAmazingClient {
 	mock := &GoAmazingClient{}
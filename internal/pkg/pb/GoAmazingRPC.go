@@ -17,6 +17,29 @@ type GoAmazingRPC struct {
 	mock.Mock
 }
 
+// BatchGetRecords provides a mock function with given fields: _a0, _a1
+func (_m *GoAmazingRPC) BatchGetRecords(_a0 context.Context, _a1 *pb.BatchGetRecordsReq) (*pb.BatchGetRecordsRes, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *pb.BatchGetRecordsRes
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.BatchGetRecordsReq) *pb.BatchGetRecordsRes); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.BatchGetRecordsRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.BatchGetRecordsReq) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Config provides a mock function with given fields: _a0, _a1
 func (_m *GoAmazingRPC) Config(_a0 context.Context, _a1 *pb.ConfigReq) (*pb.ConfigRes, error) {
 	ret := _m.Called(_a0, _a1)
@@ -63,6 +86,29 @@ func (_m *GoAmazingRPC) CreateRecord(_a0 context.Context, _a1 *pb.CreateRecordRe
 	return r0, r1
 }
 
+// DeleteRecord provides a mock function with given fields: _a0, _a1
+func (_m *GoAmazingRPC) DeleteRecord(_a0 context.Context, _a1 *pb.DeleteRecordReq) (*pb.DeleteRecordRes, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *pb.DeleteRecordRes
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.DeleteRecordReq) *pb.DeleteRecordRes); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.DeleteRecordRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.DeleteRecordReq) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetRecord provides a mock function with given fields: _a0, _a1
 func (_m *GoAmazingRPC) GetRecord(_a0 context.Context, _a1 *pb.GetRecordReq) (*pb.GetRecordRes, error) {
 	ret := _m.Called(_a0, _a1)
@@ -132,6 +178,75 @@ func (_m *GoAmazingRPC) ListRecord(_a0 context.Context, _a1 *pb.ListRecordReq) (
 	return r0, r1
 }
 
+// ListRecordRevisions provides a mock function with given fields: _a0, _a1
+func (_m *GoAmazingRPC) ListRecordRevisions(_a0 context.Context, _a1 *pb.ListRecordRevisionsReq) (*pb.ListRecordRevisionsRes, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *pb.ListRecordRevisionsRes
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.ListRecordRevisionsReq) *pb.ListRecordRevisionsRes); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.ListRecordRevisionsRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.ListRecordRevisionsReq) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreRecord provides a mock function with given fields: _a0, _a1
+func (_m *GoAmazingRPC) RestoreRecord(_a0 context.Context, _a1 *pb.RestoreRecordReq) (*pb.RestoreRecordRes, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *pb.RestoreRecordRes
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.RestoreRecordReq) *pb.RestoreRecordRes); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.RestoreRecordRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.RestoreRecordReq) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateRecord provides a mock function with given fields: _a0, _a1
+func (_m *GoAmazingRPC) UpdateRecord(_a0 context.Context, _a1 *pb.UpdateRecordReq) (*pb.UpdateRecordRes, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *pb.UpdateRecordRes
+	if rf, ok := ret.Get(0).(func(context.Context, *pb.UpdateRecordReq) *pb.UpdateRecordRes); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pb.UpdateRecordRes)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *pb.UpdateRecordReq) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewGoAmazingRPC creates a new instance of GoAmazingRPC. It also registers the testing.TB interface on the mock and a cleanup function to assert the mocks expectations.
 func NewGoAmazingRPC(t testing.TB) *GoAmazingRPC {
 	mock := &GoAmazingRPC{}
@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+)
+
+// APIKey is the static API key of a service, and the roles bound to it.
+type APIKey struct {
+	Key   string
+	Roles []string
+}
+
+type apiKeyAuthenticator struct {
+	// keys is the map of service name => api key.
+	keys map[string]APIKey
+}
+
+// NewAPIKeyAuthenticator verifies the static API keys of the services, keys is the map of service name => api key.
+func NewAPIKeyAuthenticator(keys map[string]APIKey) Authenticator {
+	return &apiKeyAuthenticator{keys: keys}
+}
+
+func (a *apiKeyAuthenticator) Authenticate(_ context.Context, cred Credentials) (Identity, error) {
+	if cred.APIKey == "" {
+		return Identity{}, ErrNoCredentials
+	}
+
+	// every key is compared in constant time, the time doesn't tell which key is close.
+	name := ""
+	for n, key := range a.keys {
+		if subtle.ConstantTimeCompare([]byte(cred.APIKey), []byte(key.Key)) == 1 {
+			name = n
+		}
+	}
+
+	if name == "" {
+		return Identity{}, ErrInvalidCredentials
+	}
+
+	// the roles are bound to the key, the callers can't claim any other
+	return Identity{Subject: name, Method: MethodAPIKey, Roles: a.keys[name].Roles}, nil
+}
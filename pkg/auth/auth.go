@@ -0,0 +1,98 @@
+// Package auth authenticates the callers of the HTTP and gRPC services, and carries their identity in the context.
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	errCodes "github.com/AmazingTalker/at-error-code"
+	"github.com/AmazingTalker/go-rpc-kit/errorkit"
+	"github.com/AmazingTalker/go-rpc-kit/logkit"
+)
+
+const (
+	MethodJWT    = "jwt"
+	MethodAPIKey = "apikey"
+)
+
+var (
+	// ErrNoCredentials means the credentials are not for the authenticator, the next one is tried.
+	ErrNoCredentials = errors.New("no credentials")
+	// ErrInvalidCredentials means the credentials are for the authenticator, but they are wrong.
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
+
+// Credentials are taken from the HTTP headers or the gRPC metadata.
+type Credentials struct {
+	// Bearer is the token of "Authorization: Bearer <token>".
+	Bearer string
+	// APIKey is the value of X-API-Key.
+	APIKey string
+}
+
+// Identity is the authenticated caller.
+type Identity struct {
+	// Subject is the sub claim of the JWT, or the service name of the API key.
+	Subject string
+	// Method is how the caller is authenticated, MethodJWT or MethodAPIKey.
+	Method string
+	// Roles are the roles claim of the JWT, or the roles bound to the API key.
+	Roles []string
+}
+
+// Authenticator verifies the credentials. It returns ErrNoCredentials when the credentials are not for it.
+type Authenticator interface {
+	Authenticate(ctx context.Context, cred Credentials) (Identity, error)
+}
+
+// Authenticators tries the authenticators in order, until one of them accepts or rejects the credentials.
+type Authenticators []Authenticator
+
+func (as Authenticators) Authenticate(ctx context.Context, cred Credentials) (Identity, error) {
+	for _, a := range as {
+		id, err := a.Authenticate(ctx, cred)
+		if errors.Is(err, ErrNoCredentials) {
+			continue
+		}
+		return id, err
+	}
+
+	return Identity{}, ErrNoCredentials
+}
+
+type identityKey struct{}
+
+// WithIdentity puts the identity into ctx, and the subject into the logs.
+func WithIdentity(ctx context.Context, id Identity) context.Context {
+	ctx = context.WithValue(ctx, identityKey{}, id)
+	return logkit.EnrichPayload(ctx, logkit.Payload{"caller": id.Subject, "authMethod": id.Method})
+}
+
+// IdentityFromContext returns the identity of the caller, ok is false for the exempted routes.
+func IdentityFromContext(ctx context.Context) (Identity, bool) {
+	id, ok := ctx.Value(identityKey{}).(Identity)
+	return id, ok
+}
+
+// authenticate runs a for the credentials, the failures are AT errors with 401.
+func authenticate(ctx context.Context, a Authenticator, cred Credentials) (context.Context, error) {
+	id, err := a.Authenticate(ctx, cred)
+	if err != nil {
+		logkit.Debug(ctx, "authenticate failed", logkit.Payload{"err": err})
+		return ctx, errorkit.NewFromError(errCodes.ErrUnauthenticated, err, errorkit.WithHttpStatusCode(http.StatusUnauthorized))
+	}
+
+	return WithIdentity(ctx, id), nil
+}
+
+// bearerToken returns the token of an Authorization header, the scheme is case insensitive.
+func bearerToken(header string) string {
+	const prefix = "bearer "
+	if len(header) < len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+		return ""
+	}
+
+	return strings.TrimSpace(header[len(prefix):])
+}
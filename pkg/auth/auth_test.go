@@ -0,0 +1,329 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+var (
+	mockCTX    = context.Background()
+	mockSecret = []byte("secret")
+	mockAPIKey = "api-key"
+	// mockReaderAPIKey has the reader role
+	mockReaderAPIKey = "reader-api-key"
+	mockNow          = time.Now()
+)
+
+type authSuite struct {
+	suite.Suite
+
+	rsaKey *rsa.PrivateKey
+	authn  Authenticator
+}
+
+func (s *authSuite) SetupSuite() {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	s.rsaKey = key
+
+	s.authn = Authenticators{
+		NewJWTAuthenticator(JWTOpt{
+			HMACSecret:   mockSecret,
+			RSAPublicKey: &key.PublicKey,
+			Issuer:       "issuer",
+		}),
+		NewAPIKeyAuthenticator(map[string]APIKey{
+			"svc":    {Key: mockAPIKey},
+			"reader": {Key: mockReaderAPIKey, Roles: []string{"reader"}},
+		}),
+	}
+}
+
+func TestAuthSuite(t *testing.T) {
+	suite.Run(t, new(authSuite))
+}
+
+func (s *authSuite) sign(method jwt.SigningMethod, key interface{}, claims jwtClaims) string {
+	token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	s.Require().NoError(err)
+	return token
+}
+
+func mockClaims(sub string, exp time.Time) jwtClaims {
+	return jwtClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   sub,
+			Issuer:    "issuer",
+			ExpiresAt: jwt.NewNumericDate(exp),
+		},
+		Roles: []string{"admin"},
+	}
+}
+
+func (s *authSuite) TestAuthenticate() {
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+
+	wrongIssuer := mockClaims("user", mockNow.Add(time.Hour))
+	wrongIssuer.Issuer = "XD"
+
+	tests := []struct {
+		Desc   string
+		Cred   Credentials
+		ExpID  Identity
+		ExpErr error
+	}{
+		{
+			Desc:   "no credentials",
+			Cred:   Credentials{},
+			ExpErr: ErrNoCredentials,
+		},
+		{
+			Desc:  "HS256",
+			Cred:  Credentials{Bearer: s.sign(jwt.SigningMethodHS256, mockSecret, mockClaims("user", mockNow.Add(time.Hour)))},
+			ExpID: Identity{Subject: "user", Method: MethodJWT, Roles: []string{"admin"}},
+		},
+		{
+			Desc:  "RS256",
+			Cred:  Credentials{Bearer: s.sign(jwt.SigningMethodRS256, s.rsaKey, mockClaims("user", mockNow.Add(time.Hour)))},
+			ExpID: Identity{Subject: "user", Method: MethodJWT, Roles: []string{"admin"}},
+		},
+		{
+			Desc:   "expired",
+			Cred:   Credentials{Bearer: s.sign(jwt.SigningMethodHS256, mockSecret, mockClaims("user", mockNow.Add(-time.Hour)))},
+			ExpErr: ErrInvalidCredentials,
+		},
+		{
+			Desc:   "wrong secret",
+			Cred:   Credentials{Bearer: s.sign(jwt.SigningMethodHS256, []byte("XD"), mockClaims("user", mockNow.Add(time.Hour)))},
+			ExpErr: ErrInvalidCredentials,
+		},
+		{
+			Desc:   "wrong rsa key",
+			Cred:   Credentials{Bearer: s.sign(jwt.SigningMethodRS256, otherKey, mockClaims("user", mockNow.Add(time.Hour)))},
+			ExpErr: ErrInvalidCredentials,
+		},
+		{
+			Desc:   "unsupported method",
+			Cred:   Credentials{Bearer: s.sign(jwt.SigningMethodHS512, mockSecret, mockClaims("user", mockNow.Add(time.Hour)))},
+			ExpErr: ErrInvalidCredentials,
+		},
+		{
+			Desc:   "none method",
+			Cred:   Credentials{Bearer: s.sign(jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, mockClaims("user", mockNow.Add(time.Hour)))},
+			ExpErr: ErrInvalidCredentials,
+		},
+		{
+			Desc:   "wrong issuer",
+			Cred:   Credentials{Bearer: s.sign(jwt.SigningMethodHS256, mockSecret, wrongIssuer)},
+			ExpErr: ErrInvalidCredentials,
+		},
+		{
+			Desc:   "no subject",
+			Cred:   Credentials{Bearer: s.sign(jwt.SigningMethodHS256, mockSecret, mockClaims("", mockNow.Add(time.Hour)))},
+			ExpErr: ErrInvalidCredentials,
+		},
+		{
+			Desc:  "api key",
+			Cred:  Credentials{APIKey: mockAPIKey},
+			ExpID: Identity{Subject: "svc", Method: MethodAPIKey},
+		},
+		{
+			Desc:  "api key with roles",
+			Cred:  Credentials{APIKey: mockReaderAPIKey},
+			ExpID: Identity{Subject: "reader", Method: MethodAPIKey, Roles: []string{"reader"}},
+		},
+		{
+			Desc:   "wrong api key",
+			Cred:   Credentials{APIKey: "XD"},
+			ExpErr: ErrInvalidCredentials,
+		},
+		{
+			Desc:   "wrong token with api key",
+			Cred:   Credentials{Bearer: "XD", APIKey: mockAPIKey},
+			ExpErr: ErrInvalidCredentials,
+		},
+	}
+
+	for _, t := range tests {
+		id, err := s.authn.Authenticate(mockCTX, t.Cred)
+		if t.ExpErr != nil {
+			s.Require().True(errors.Is(err, t.ExpErr), "%s: %v", t.Desc, err)
+			continue
+		}
+
+		s.Require().NoError(err, t.Desc)
+		s.Require().Equal(t.ExpID, id, t.Desc)
+	}
+}
+
+func (s *authSuite) TestBearerToken() {
+	s.Require().Equal("token", bearerToken("Bearer token"))
+	s.Require().Equal("token", bearerToken("bearer token"))
+	s.Require().Equal("", bearerToken("Basic token"))
+	s.Require().Equal("", bearerToken(""))
+}
+
+func (s *authSuite) TestGinMiddleware() {
+	tests := []struct {
+		Desc   string
+		Method string
+		Path   string
+		APIKey string
+		// Roles are claimed by the caller
+		Roles     string
+		ExpStatus int
+		ExpCaller string
+		ExpRoles  []string
+	}{
+		{
+			Desc:      "health is exempted",
+			Method:    http.MethodGet,
+			Path:      "/health",
+			ExpStatus: http.StatusOK,
+		},
+		{
+			Desc:      "probes are exempted",
+			Method:    http.MethodGet,
+			Path:      "/health/ready",
+			ExpStatus: http.StatusOK,
+		},
+		{
+			Desc:      "liveness is exempted",
+			Method:    http.MethodGet,
+			Path:      "/health/live",
+			ExpStatus: http.StatusOK,
+		},
+		{
+			Desc:      "the report of the dependencies isn't exempted",
+			Method:    http.MethodGet,
+			Path:      "/health/deps",
+			ExpStatus: http.StatusUnauthorized,
+		},
+		{
+			Desc:      "the report of the dependencies is authenticated",
+			Method:    http.MethodGet,
+			Path:      "/health/deps",
+			APIKey:    mockAPIKey,
+			ExpStatus: http.StatusOK,
+			ExpCaller: "svc",
+		},
+		{
+			Desc:      "preflight is exempted",
+			Method:    http.MethodOptions,
+			Path:      "/api/records",
+			ExpStatus: http.StatusOK,
+		},
+		{
+			Desc:      "authenticated",
+			Method:    http.MethodGet,
+			Path:      "/api/records",
+			APIKey:    mockAPIKey,
+			ExpStatus: http.StatusOK,
+			ExpCaller: "svc",
+		},
+		{
+			Desc:      "unauthenticated",
+			Method:    http.MethodGet,
+			Path:      "/api/records",
+			ExpStatus: http.StatusUnauthorized,
+		},
+		{
+			Desc:      "the roles are bound to the api key",
+			Method:    http.MethodGet,
+			Path:      "/api/records",
+			APIKey:    mockReaderAPIKey,
+			Roles:     "admin",
+			ExpStatus: http.StatusOK,
+			ExpCaller: "reader",
+			ExpRoles:  []string{"reader"},
+		},
+	}
+
+	gin.SetMode(gin.TestMode)
+
+	for _, t := range tests {
+		caller, roles := "", []string(nil)
+		e := gin.New()
+		e.Use(GinMiddleware(s.authn))
+		e.Handle(t.Method, t.Path, func(ctx *gin.Context) {
+			id, _ := IdentityFromContext(ctx.Request.Context())
+			caller, roles = id.Subject, id.Roles
+			ctx.Status(http.StatusOK)
+		})
+
+		req := httptest.NewRequest(t.Method, t.Path, nil)
+		if t.APIKey != "" {
+			req.Header.Set(headerAPIKey, t.APIKey)
+		}
+		if t.Roles != "" {
+			req.Header.Set("X-Caller-Roles", t.Roles)
+		}
+
+		w := httptest.NewRecorder()
+		e.ServeHTTP(w, req)
+
+		s.Require().Equal(t.ExpStatus, w.Code, t.Desc)
+		s.Require().Equal(t.ExpCaller, caller, t.Desc)
+		s.Require().Equal(t.ExpRoles, roles, t.Desc)
+	}
+}
+
+func (s *authSuite) TestUnaryServerInterceptor() {
+	tests := []struct {
+		Desc      string
+		Method    string
+		Ctx       context.Context
+		ExpErr    bool
+		ExpCaller string
+	}{
+		{
+			Desc:   "grpc health is exempted",
+			Method: "/grpc.health.v1.Health/Check",
+			Ctx:    mockCTX,
+		},
+		{
+			Desc:   "health is exempted",
+			Method: "/pb.GoAmazing/Health",
+			Ctx:    mockCTX,
+		},
+		{
+			Desc:      "authenticated",
+			Method:    "/pb.GoAmazing/CreateRecord",
+			Ctx:       metadata.NewIncomingContext(mockCTX, metadata.Pairs(mdAuthorization, "Bearer "+s.sign(jwt.SigningMethodHS256, mockSecret, mockClaims("user", mockNow.Add(time.Hour))))),
+			ExpCaller: "user",
+		},
+		{
+			Desc:   "unauthenticated",
+			Method: "/pb.GoAmazing/CreateRecord",
+			Ctx:    mockCTX,
+			ExpErr: true,
+		},
+	}
+
+	interceptor := UnaryServerInterceptor(s.authn)
+
+	for _, t := range tests {
+		caller := ""
+		_, err := interceptor(t.Ctx, nil, &grpc.UnaryServerInfo{FullMethod: t.Method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			id, _ := IdentityFromContext(ctx)
+			caller = id.Subject
+			return nil, nil
+		})
+
+		s.Require().Equal(t.ExpErr, err != nil, t.Desc)
+		s.Require().Equal(t.ExpCaller, caller, t.Desc)
+	}
+}
@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/AmazingTalker/go-rpc-kit/errorkit"
+)
+
+const headerAPIKey = "X-API-Key"
+
+// exemptPaths are served without credentials, the probes of k8s have none. The other paths under
+// /health, like the report of the dependencies, still need the credentials.
+var exemptPaths = map[string]bool{
+	"/health":       true,
+	"/health/live":  true,
+	"/health/ready": true,
+}
+
+func isExemptPath(path string) bool {
+	return exemptPaths[path]
+}
+
+// GinMiddleware authenticates the requests by the Authorization or the X-API-Key header. It has to be used
+// before the routes are registered, the preflight requests of CORS and the probes are exempted.
+func GinMiddleware(a Authenticator) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ctx.Request.Method == http.MethodOptions || isExemptPath(ctx.Request.URL.Path) {
+			ctx.Next()
+			return
+		}
+
+		cred := Credentials{
+			Bearer: bearerToken(ctx.GetHeader("Authorization")),
+			APIKey: ctx.GetHeader(headerAPIKey),
+		}
+
+		c, err := authenticate(ctx.Request.Context(), a, cred)
+		if err != nil {
+			e := errorkit.FormatError(err)
+			ctx.AbortWithStatusJSON(e.HttpStatus(), e.GinHashMap())
+			return
+		}
+
+		ctx.Request = ctx.Request.WithContext(c)
+		ctx.Next()
+	}
+}
@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	mdAuthorization = "authorization"
+	mdAPIKey        = "x-api-key"
+)
+
+// exemptMethods are the prefixes of the methods served without credentials.
+var exemptMethods = []string{
+	"/grpc.health.v1.Health/",
+	"/grpc.reflection.",
+	"/pb.GoAmazing/Health",
+}
+
+func isExemptMethod(method string) bool {
+	for _, m := range exemptMethods {
+		if strings.HasPrefix(method, m) {
+			return true
+		}
+	}
+	return false
+}
+
+func grpcCredentials(ctx context.Context) Credentials {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	first := func(key string) string {
+		if values := md.Get(key); len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	}
+
+	return Credentials{
+		Bearer: bearerToken(first(mdAuthorization)),
+		APIKey: first(mdAPIKey),
+	}
+}
+
+// UnaryServerInterceptor authenticates the calls by the authorization or the x-api-key metadata.
+func UnaryServerInterceptor(a Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if isExemptMethod(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		ctx, err := authenticate(ctx, a, grpcCredentials(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// serverStream overrides the context of a grpc.ServerStream.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// StreamServerInterceptor is UnaryServerInterceptor for the streams.
+func StreamServerInterceptor(a Authenticator) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if isExemptMethod(info.FullMethod) {
+			return handler(srv, ss)
+		}
+
+		ctx, err := authenticate(ss.Context(), a, grpcCredentials(ss.Context()))
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
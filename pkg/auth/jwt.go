@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+type JWTOpt struct {
+	// HMACSecret verifies the HS256 tokens, they are rejected when it's empty.
+	HMACSecret []byte
+	// RSAPublicKey verifies the RS256 tokens, they are rejected when it's nil.
+	RSAPublicKey *rsa.PublicKey
+	// Issuer and Audience are checked when they are given.
+	Issuer   string
+	Audience string
+}
+
+type jwtClaims struct {
+	jwt.RegisteredClaims
+	Roles []string `json:"roles"`
+}
+
+type jwtAuthenticator struct {
+	opt    JWTOpt
+	parser *jwt.Parser
+}
+
+// NewJWTAuthenticator verifies the bearer tokens signed by HS256 or RS256, the other algorithms are rejected.
+func NewJWTAuthenticator(opt JWTOpt) Authenticator {
+	methods := []string{}
+	if len(opt.HMACSecret) > 0 {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if opt.RSAPublicKey != nil {
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+
+	return &jwtAuthenticator{
+		opt:    opt,
+		parser: jwt.NewParser(jwt.WithValidMethods(methods)),
+	}
+}
+
+// ParseRSAPublicKey parses the PEM encoded public key for JWTOpt.
+func ParseRSAPublicKey(pem []byte) (*rsa.PublicKey, error) {
+	return jwt.ParseRSAPublicKeyFromPEM(pem)
+}
+
+func (a *jwtAuthenticator) key(t *jwt.Token) (interface{}, error) {
+	switch t.Method.Alg() {
+	case jwt.SigningMethodHS256.Alg():
+		return a.opt.HMACSecret, nil
+	case jwt.SigningMethodRS256.Alg():
+		return a.opt.RSAPublicKey, nil
+	}
+
+	return nil, fmt.Errorf("unexpected signing method %s", t.Method.Alg())
+}
+
+func (a *jwtAuthenticator) Authenticate(_ context.Context, cred Credentials) (Identity, error) {
+	if cred.Bearer == "" {
+		return Identity{}, ErrNoCredentials
+	}
+
+	claims := &jwtClaims{}
+	if _, err := a.parser.ParseWithClaims(cred.Bearer, claims, a.key); err != nil {
+		return Identity{}, fmt.Errorf("%w: %v", ErrInvalidCredentials, err)
+	}
+
+	if a.opt.Issuer != "" && !claims.VerifyIssuer(a.opt.Issuer, true) {
+		return Identity{}, fmt.Errorf("%w: unexpected issuer %s", ErrInvalidCredentials, claims.Issuer)
+	}
+	if a.opt.Audience != "" && !claims.VerifyAudience(a.opt.Audience, true) {
+		return Identity{}, fmt.Errorf("%w: unexpected audience", ErrInvalidCredentials)
+	}
+	if claims.Subject == "" {
+		return Identity{}, fmt.Errorf("%w: the subject is required", ErrInvalidCredentials)
+	}
+
+	return Identity{Subject: claims.Subject, Method: MethodJWT, Roles: claims.Roles}, nil
+}
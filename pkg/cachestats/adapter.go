@@ -0,0 +1,89 @@
+package cachestats
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/AmazingTalker/go-cache"
+	"github.com/AmazingTalker/go-rpc-kit/metrickit"
+)
+
+// The tiers of go-cache.
+const (
+	TierShared = "shared"
+	TierLocal  = "local"
+)
+
+// Adapter meters a tier of go-cache by the prefixes of the keys.
+//   - get: the hits, the misses and the errors of the keys read, a miss of the shared tier misses both tiers.
+//   - size: the size in bytes of the serialized values written.
+//   - eviction: the keys deleted, the records evict them on every write.
+type Adapter struct {
+	cache.Adapter
+	tier string
+	met  metrickit.Metric
+}
+
+func NewAdapter(tier string, adapter cache.Adapter, met metrickit.Metric) *Adapter {
+	return &Adapter{Adapter: adapter, tier: tier, met: met}
+}
+
+func (a *Adapter) MGet(ctx context.Context, keys []string) ([]cache.Value, error) {
+	vals, err := a.Adapter.MGet(ctx, keys)
+	if err != nil {
+		for _, key := range keys {
+			a.met.IncrCounter([]string{"get"}, 1, a.labels(key, "error"))
+		}
+		return nil, err
+	}
+
+	for i, v := range vals {
+		outcome := "miss"
+		if v.Valid {
+			outcome = "hit"
+		}
+		a.met.IncrCounter([]string{"get"}, 1, a.labels(keys[i], outcome))
+	}
+
+	return vals, nil
+}
+
+func (a *Adapter) MSet(ctx context.Context, keyVals map[string][]byte, ttl time.Duration, options ...cache.MSetOptions) error {
+	if err := a.Adapter.MSet(ctx, keyVals, ttl, options...); err != nil {
+		return err
+	}
+
+	for key, b := range keyVals {
+		a.met.AddSample([]string{"size"}, float64(len(b)), map[string]string{"tier": a.tier, "prefix": keyPrefix(key)})
+	}
+
+	return nil
+}
+
+func (a *Adapter) Del(ctx context.Context, keys ...string) error {
+	if err := a.Adapter.Del(ctx, keys...); err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		a.met.IncrCounter([]string{"eviction"}, 1, map[string]string{"tier": a.tier, "prefix": keyPrefix(key)})
+	}
+
+	return nil
+}
+
+func (a *Adapter) labels(key, outcome string) map[string]string {
+	return map[string]string{"tier": a.tier, "prefix": keyPrefix(key), "outcome": outcome}
+}
+
+// keyPrefix returns the prefix of the keys of go-cache. ex: records of ca:records:<key>
+// go-cache doesn't export the format, it's pinned by TestLocalCacheKey of pkg/dao.
+func keyPrefix(key string) string {
+	parts := strings.SplitN(key, ":", 3)
+	if len(parts) < 3 {
+		return "unknown"
+	}
+
+	return parts[1]
+}
@@ -0,0 +1,258 @@
+package cachestats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/AmazingTalker/go-cache"
+	"github.com/AmazingTalker/go-rpc-kit/metrickit"
+)
+
+var (
+	mockCTX = context.Background()
+	mockErr = errors.New("XD")
+)
+
+// fakeAdapter keeps the values in a map, it fails all the calls when err is set.
+type fakeAdapter struct {
+	vals map[string][]byte
+	err  error
+}
+
+func (a *fakeAdapter) MGet(_ context.Context, keys []string) ([]cache.Value, error) {
+	if a.err != nil {
+		return nil, a.err
+	}
+
+	vals := make([]cache.Value, len(keys))
+	for i, key := range keys {
+		b, ok := a.vals[key]
+		vals[i] = cache.Value{Valid: ok, Bytes: b}
+	}
+	return vals, nil
+}
+
+func (a *fakeAdapter) MSet(_ context.Context, keyVals map[string][]byte, _ time.Duration, _ ...cache.MSetOptions) error {
+	if a.err != nil {
+		return a.err
+	}
+
+	for key, b := range keyVals {
+		a.vals[key] = b
+	}
+	return nil
+}
+
+func (a *fakeAdapter) Del(_ context.Context, keys ...string) error {
+	if a.err != nil {
+		return a.err
+	}
+
+	for _, key := range keys {
+		delete(a.vals, key)
+	}
+	return nil
+}
+
+// fakeMetric keeps the labels of the counters and the samples, the methods not used by the
+// adapters are left nil.
+type fakeMetric struct {
+	metrickit.Metric
+	counters map[string][]map[string]string
+	samples  map[string][]float64
+}
+
+func (m *fakeMetric) IncrCounter(keys []string, _ float64, labels map[string]string) {
+	m.counters[keys[0]] = append(m.counters[keys[0]], labels)
+}
+
+func (m *fakeMetric) AddSample(keys []string, val float64, _ map[string]string) {
+	m.samples[keys[0]] = append(m.samples[keys[0]], val)
+}
+
+func (m *fakeMetric) SetGauge(_ []string, _ float64, _ map[string]string) {}
+
+func newFakeMetric() *fakeMetric {
+	return &fakeMetric{counters: map[string][]map[string]string{}, samples: map[string][]float64{}}
+}
+
+type cacheStatsSuite struct {
+	suite.Suite
+}
+
+func TestCacheStatsSuite(t *testing.T) {
+	suite.Run(t, new(cacheStatsSuite))
+}
+
+func (s *cacheStatsSuite) TestMGet() {
+	tests := []struct {
+		Desc        string
+		Err         error
+		ExpCounters []map[string]string
+	}{
+		{
+			Desc: "normal case",
+			ExpCounters: []map[string]string{
+				{"tier": TierShared, "prefix": "records", "outcome": "hit"},
+				{"tier": TierShared, "prefix": "records", "outcome": "miss"},
+				{"tier": TierShared, "prefix": "unknown", "outcome": "miss"},
+			},
+		},
+		{
+			Desc: "error",
+			Err:  mockErr,
+			ExpCounters: []map[string]string{
+				{"tier": TierShared, "prefix": "records", "outcome": "error"},
+				{"tier": TierShared, "prefix": "records", "outcome": "error"},
+				{"tier": TierShared, "prefix": "unknown", "outcome": "error"},
+			},
+		},
+	}
+
+	for _, t := range tests {
+		met := newFakeMetric()
+		a := NewAdapter(TierShared, &fakeAdapter{vals: map[string][]byte{"ca:records:1": []byte("AT")}, err: t.Err}, met)
+
+		vals, err := a.MGet(mockCTX, []string{"ca:records:1", "ca:records:2", "records"})
+		s.Require().Equal(t.Err, err, t.Desc)
+		if err == nil {
+			s.Require().Equal([]cache.Value{{Valid: true, Bytes: []byte("AT")}, {}, {}}, vals, t.Desc)
+		}
+		s.Require().Equal(t.ExpCounters, met.counters["get"], t.Desc)
+	}
+}
+
+func (s *cacheStatsSuite) TestMSetAndDel() {
+	met := newFakeMetric()
+	a := NewAdapter(TierShared, &fakeAdapter{vals: map[string][]byte{}}, met)
+
+	s.Require().NoError(a.MSet(mockCTX, map[string][]byte{"ca:records:1": []byte("ATAT")}, time.Minute))
+	s.Require().Equal([]float64{4}, met.samples["size"])
+
+	s.Require().NoError(a.Del(mockCTX, "ca:records:1", "ca:missing-records:2"))
+	s.Require().Equal([]map[string]string{
+		{"tier": TierShared, "prefix": "records"},
+		{"tier": TierShared, "prefix": "missing-records"},
+	}, met.counters["eviction"])
+
+	failed := NewAdapter(TierShared, &fakeAdapter{err: mockErr}, met)
+	s.Require().Equal(mockErr, failed.MSet(mockCTX, map[string][]byte{"ca:records:1": []byte("AT")}, time.Minute))
+	s.Require().Equal(mockErr, failed.Del(mockCTX, "ca:records:1"))
+	s.Require().Len(met.samples["size"], 1, "failures aren't metered")
+	s.Require().Len(met.counters["eviction"], 2, "failures aren't metered")
+}
+
+func (s *cacheStatsSuite) TestOccupancy() {
+	tests := []struct {
+		Desc   string
+		Limit  int
+		Action func(a *LocalAdapter)
+		Exp    Occupancy
+	}{
+		{
+			Desc:   "empty",
+			Limit:  100,
+			Action: func(a *LocalAdapter) {},
+			Exp:    Occupancy{Limit: 100, Prefixes: map[string]PrefixOccupancy{}},
+		},
+		{
+			Desc:  "set",
+			Limit: 100,
+			Action: func(a *LocalAdapter) {
+				s.Require().NoError(a.MSet(mockCTX, map[string][]byte{
+					"ca:records:1":         []byte("AT"),
+					"ca:records:2":         []byte("ATAT"),
+					"ca:missing-records:3": []byte("1"),
+				}, time.Minute))
+			},
+			Exp: Occupancy{Entries: 3, Bytes: 14 + 16 + 21, Limit: 100, Prefixes: map[string]PrefixOccupancy{
+				"records":         {Entries: 2, Bytes: 14 + 16},
+				"missing-records": {Entries: 1, Bytes: 21},
+			}},
+		},
+		{
+			Desc:  "overwrite and delete",
+			Limit: 100,
+			Action: func(a *LocalAdapter) {
+				s.Require().NoError(a.MSet(mockCTX, map[string][]byte{"ca:records:1": []byte("AT"), "ca:records:2": []byte("AT")}, time.Minute))
+				s.Require().NoError(a.MSet(mockCTX, map[string][]byte{"ca:records:1": []byte("ATAT")}, time.Minute))
+				s.Require().NoError(a.Del(mockCTX, "ca:records:2", "ca:records:3"))
+			},
+			Exp: Occupancy{Entries: 1, Bytes: 16, Limit: 100, Prefixes: map[string]PrefixOccupancy{
+				"records": {Entries: 1, Bytes: 16},
+			}},
+		},
+		{
+			Desc:  "expired",
+			Limit: 100,
+			Action: func(a *LocalAdapter) {
+				s.Require().NoError(a.MSet(mockCTX, map[string][]byte{"ca:records:1": []byte("AT")}, time.Millisecond))
+				s.Require().NoError(a.MSet(mockCTX, map[string][]byte{"ca:records:2": []byte("AT")}, 0))
+				time.Sleep(5 * time.Millisecond)
+			},
+			Exp: Occupancy{Entries: 1, Bytes: 14, Limit: 100, Prefixes: map[string]PrefixOccupancy{
+				"records": {Entries: 1, Bytes: 14},
+			}},
+		},
+		{
+			Desc:  "the oldest are dropped over the limit",
+			Limit: 30,
+			Action: func(a *LocalAdapter) {
+				s.Require().NoError(a.MSet(mockCTX, map[string][]byte{"ca:records:1": []byte("AT")}, 0))
+				s.Require().NoError(a.MSet(mockCTX, map[string][]byte{"ca:records:2": []byte("ATAT")}, 0))
+				s.Require().NoError(a.MSet(mockCTX, map[string][]byte{"ca:records:3": []byte("AT")}, 0))
+			},
+			Exp: Occupancy{Entries: 2, Bytes: 16 + 14, Limit: 30, Prefixes: map[string]PrefixOccupancy{
+				"records": {Entries: 2, Bytes: 16 + 14},
+			}},
+		},
+		{
+			Desc:  "an overwrite is the newest",
+			Limit: 30,
+			Action: func(a *LocalAdapter) {
+				s.Require().NoError(a.MSet(mockCTX, map[string][]byte{"ca:records:1": []byte("AT")}, 0))
+				s.Require().NoError(a.MSet(mockCTX, map[string][]byte{"ca:records:2": []byte("ATA")}, 0))
+				s.Require().NoError(a.MSet(mockCTX, map[string][]byte{"ca:records:1": []byte("AT")}, 0))
+				s.Require().NoError(a.MSet(mockCTX, map[string][]byte{"ca:records:3": []byte("A")}, 0))
+			},
+			Exp: Occupancy{Entries: 2, Bytes: 14 + 13, Limit: 30, Prefixes: map[string]PrefixOccupancy{
+				"records": {Entries: 2, Bytes: 14 + 13},
+			}},
+		},
+	}
+
+	for _, t := range tests {
+		a := NewLocalAdapter(&fakeAdapter{vals: map[string][]byte{}}, t.Limit, newFakeMetric())
+		t.Action(a)
+
+		s.Require().Equal(t.Exp, a.Occupancy(), t.Desc)
+	}
+}
+
+func (s *cacheStatsSuite) TestOccupancyHandler() {
+	gin.SetMode(gin.TestMode)
+
+	a := NewLocalAdapter(&fakeAdapter{vals: map[string][]byte{}}, 100, newFakeMetric())
+	s.Require().NoError(a.MSet(mockCTX, map[string][]byte{"ca:records:1": []byte("AT")}, time.Minute))
+
+	e := gin.New()
+	EnrichGinRouter(e, a)
+
+	w := httptest.NewRecorder()
+	e.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/cache", nil))
+	s.Require().Equal(http.StatusOK, w.Code)
+
+	o := Occupancy{}
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &o))
+	s.Require().Equal(Occupancy{Entries: 1, Bytes: 14, Limit: 100, Prefixes: map[string]PrefixOccupancy{
+		"records": {Entries: 1, Bytes: 14},
+	}}, o)
+}
@@ -0,0 +1,161 @@
+package cachestats
+
+import (
+	"container/list"
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/AmazingTalker/go-cache"
+	"github.com/AmazingTalker/go-rpc-kit/metrickit"
+)
+
+// pruneInterval drops the expired entries from the occupancy, and reports it as the gauge.
+const pruneInterval = 10 * time.Second
+
+// Occupancy is the approximate usage of the local tier by the keys and the values written
+// through it. The entries dropped by the local cache itself to make room aren't seen, so the
+// oldest written ones are taken as dropped once Bytes is over Limit.
+type Occupancy struct {
+	Entries  int                        `json:"entries"`
+	Bytes    int                        `json:"bytes"`
+	Limit    int                        `json:"limit"`
+	Prefixes map[string]PrefixOccupancy `json:"prefixes"`
+}
+
+type PrefixOccupancy struct {
+	Entries int `json:"entries"`
+	Bytes   int `json:"bytes"`
+}
+
+type localEntry struct {
+	key    string
+	prefix string
+	size   int
+	// expireAt is zero for the entries without a TTL
+	expireAt time.Time
+}
+
+// LocalAdapter is the Adapter of the local tier, it tracks the occupancy of the tier against
+// the size limit of the local cache.
+type LocalAdapter struct {
+	*Adapter
+	limit int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	// order keeps the entries by the time they're written, the oldest in the front.
+	order    *list.List
+	bytes    int
+	prunedAt time.Time
+}
+
+func NewLocalAdapter(adapter cache.Adapter, limit int, met metrickit.Metric) *LocalAdapter {
+	return &LocalAdapter{
+		Adapter:  NewAdapter(TierLocal, adapter, met),
+		limit:    limit,
+		entries:  map[string]*list.Element{},
+		order:    list.New(),
+		prunedAt: time.Now(),
+	}
+}
+
+func (a *LocalAdapter) MSet(ctx context.Context, keyVals map[string][]byte, ttl time.Duration, options ...cache.MSetOptions) error {
+	if err := a.Adapter.MSet(ctx, keyVals, ttl, options...); err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for key, b := range keyVals {
+		a.remove(key)
+
+		e := &localEntry{key: key, prefix: keyPrefix(key), size: len(key) + len(b)}
+		if ttl > 0 {
+			e.expireAt = now.Add(ttl)
+		}
+		a.entries[key] = a.order.PushBack(e)
+		a.bytes += e.size
+	}
+
+	for a.bytes > a.limit && a.order.Len() > 0 {
+		a.remove(a.order.Front().Value.(*localEntry).key)
+	}
+
+	if now.Sub(a.prunedAt) >= pruneInterval {
+		a.prune(now)
+	}
+
+	return nil
+}
+
+func (a *LocalAdapter) Del(ctx context.Context, keys ...string) error {
+	if err := a.Adapter.Del(ctx, keys...); err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, key := range keys {
+		a.remove(key)
+	}
+
+	return nil
+}
+
+// Occupancy reports the live entries of the local tier.
+func (a *LocalAdapter) Occupancy() Occupancy {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.prune(time.Now())
+
+	o := Occupancy{Entries: len(a.entries), Bytes: a.bytes, Limit: a.limit, Prefixes: map[string]PrefixOccupancy{}}
+
+	for _, el := range a.entries {
+		e := el.Value.(*localEntry)
+		p := o.Prefixes[e.prefix]
+		p.Entries++
+		p.Bytes += e.size
+		o.Prefixes[e.prefix] = p
+	}
+
+	return o
+}
+
+// OccupancyHandler reports Occupancy, it's for debugging the size of the local cache.
+func (a *LocalAdapter) OccupancyHandler(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, a.Occupancy())
+}
+
+func (a *LocalAdapter) remove(key string) {
+	if el, ok := a.entries[key]; ok {
+		a.bytes -= el.Value.(*localEntry).size
+		a.order.Remove(el)
+		delete(a.entries, key)
+	}
+}
+
+// prune drops the expired entries, the caller holds the lock.
+func (a *LocalAdapter) prune(now time.Time) {
+	for key, el := range a.entries {
+		if e := el.Value.(*localEntry); !e.expireAt.IsZero() && !now.Before(e.expireAt) {
+			a.remove(key)
+		}
+	}
+	a.prunedAt = now
+
+	a.met.SetGauge([]string{"occupancy"}, float64(a.bytes), map[string]string{"tier": TierLocal})
+}
+
+// EnrichGinRouter adds /debug/cache reporting the occupancy of the local tier.
+func EnrichGinRouter(e *gin.Engine, a *LocalAdapter) {
+	e.Handle(http.MethodGet, "/debug/cache", a.OccupancyHandler)
+}
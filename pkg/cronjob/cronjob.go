@@ -4,19 +4,18 @@ import (
 	"context"
 	"time"
 
-	"github.com/AmazingTalker/go-rpc-kit/logkit"
+	"github.com/AmazingTalker/go-amazing/pkg/dao"
 )
 
-func Execute(ctx context.Context) error {
-
-	// here is a demo
-
-	jobs := make([]int, 360)
-
-	for _, i := range jobs {
-		logkit.Infof(ctx, "Job %d executed", i)
-		time.Sleep(time.Second)
-	}
+type Opt struct {
+	RecordDao dao.RecordDAO
+	// Retention keeps the deleted records restorable for the period, they are purged after that.
+	Retention time.Duration
+	// PurgeBatchSize is the number of records purged in a transaction.
+	PurgeBatchSize int
+}
 
-	return nil
+func Execute(ctx context.Context, opt Opt) error {
+	_, err := PurgeDeletedRecords(ctx, opt.RecordDao, opt.Retention, opt.PurgeBatchSize)
+	return err
 }
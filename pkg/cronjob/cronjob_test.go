@@ -0,0 +1,137 @@
+package cronjob
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	mockDAO "github.com/AmazingTalker/go-amazing/internal/pkg/dao"
+	"github.com/AmazingTalker/go-amazing/pkg/dao"
+	"github.com/AmazingTalker/go-rpc-kit/logkit"
+)
+
+var (
+	mockCTX = context.Background()
+)
+
+type cronjobSuite struct {
+	suite.Suite
+
+	mockRecord *mockDAO.RecordDAO
+}
+
+func (s *cronjobSuite) SetupSuite() {
+	logkit.RegisterAmazingLogger(&logkit.Config{
+		Logger:              logkit.LoggerZap,
+		Development:         true,
+		IntegrationAirbrake: &logkit.IntegrationAirbrake{},
+	})
+}
+
+func (s *cronjobSuite) TearDownSuite() {
+	logkit.Flush()
+}
+
+func (s *cronjobSuite) SetupTest() {
+	s.mockRecord = mockDAO.NewRecordDAO(s.T())
+}
+
+func (s *cronjobSuite) TearDownTest() {
+	s.mockRecord.AssertExpectations(s.T())
+}
+
+func TestCronjobSuite(t *testing.T) {
+	suite.Run(t, new(cronjobSuite))
+}
+
+func (s *cronjobSuite) TestPurgeDeletedRecords() {
+	retention := 24 * time.Hour
+
+	// deletedBefore is fixed for all the batches of a run
+	var deletedBefore time.Time
+	purgeArgs := func(desc string) []interface{} {
+		return []interface{}{
+			mock.MatchedBy(func(ctx context.Context) bool {
+				return dao.AuditFromContext(ctx).Actor == purgeActor
+			}),
+			mock.MatchedBy(func(t time.Time) bool {
+				if deletedBefore.IsZero() {
+					deletedBefore = t
+				}
+				return t.Equal(deletedBefore) && time.Since(t) >= retention
+			}),
+			2,
+		}
+	}
+
+	tests := []struct {
+		Desc      string
+		SetupTest func(string)
+		Retention time.Duration
+		BatchSize int
+		ExpTotal  int
+		ExpErr    error
+	}{
+		{
+			Desc:      "invalid retention",
+			Retention: 0,
+			BatchSize: 2,
+			ExpErr:    errInvalidPurgeOpt,
+		},
+		{
+			Desc:      "invalid batch size",
+			Retention: retention,
+			BatchSize: 0,
+			ExpErr:    errInvalidPurgeOpt,
+		},
+		{
+			Desc: "nothing to purge",
+			SetupTest: func(desc string) {
+				s.mockRecord.On("PurgeRecords", purgeArgs(desc)...).Return([]string{}, nil).Once()
+			},
+			Retention: retention,
+			BatchSize: 2,
+			ExpTotal:  0,
+		},
+		{
+			Desc: "till none is left",
+			SetupTest: func(desc string) {
+				s.mockRecord.On("PurgeRecords", purgeArgs(desc)...).Return([]string{"a", "b"}, nil).Twice()
+				s.mockRecord.On("PurgeRecords", purgeArgs(desc)...).Return([]string{"c"}, nil).Once()
+			},
+			Retention: retention,
+			BatchSize: 2,
+			ExpTotal:  5,
+		},
+		{
+			Desc: "purge failed",
+			SetupTest: func(desc string) {
+				s.mockRecord.On("PurgeRecords", purgeArgs(desc)...).Return([]string{"a", "b"}, nil).Once()
+				s.mockRecord.On("PurgeRecords", purgeArgs(desc)...).Return(nil, errors.New("XD")).Once()
+			},
+			Retention: retention,
+			BatchSize: 2,
+			ExpTotal:  2,
+			ExpErr:    errors.New("XD"),
+		},
+	}
+
+	for _, t := range tests {
+		s.SetupTest()
+		deletedBefore = time.Time{}
+
+		if t.SetupTest != nil {
+			t.SetupTest(t.Desc)
+		}
+
+		total, err := PurgeDeletedRecords(mockCTX, s.mockRecord, t.Retention, t.BatchSize)
+		s.Require().Equal(t.ExpErr, err, t.Desc)
+		s.Require().Equal(t.ExpTotal, total, t.Desc)
+
+		s.TearDownTest()
+	}
+}
@@ -0,0 +1,52 @@
+package cronjob
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/AmazingTalker/go-amazing/pkg/dao"
+	"github.com/AmazingTalker/go-rpc-kit/logkit"
+)
+
+// purgeActor is the actor of the purge revisions.
+const purgeActor = "cronjob:purge-deleted-records"
+
+var errInvalidPurgeOpt = errors.New("the retention and the batch size of the purge must be positive")
+
+// PurgeDeletedRecords permanently deletes the records soft deleted longer than the retention batch by batch,
+// till none is left. It returns the number of the purged records.
+func PurgeDeletedRecords(ctx context.Context, recordDao dao.RecordDAO, retention time.Duration, batchSize int) (int, error) {
+	// a zero retention would purge the records deleted a moment ago
+	if retention <= 0 || batchSize <= 0 {
+		return 0, errInvalidPurgeOpt
+	}
+
+	runID := uuid.New().String()
+	deletedBefore := time.Now().Add(-retention)
+
+	ctx = dao.WithAudit(ctx, dao.Audit{Actor: purgeActor, RequestID: runID})
+	ctx = logkit.EnrichPayload(ctx, logkit.Payload{"runId": runID, "deletedBefore": deletedBefore})
+
+	total := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+
+		ids, err := recordDao.PurgeRecords(ctx, deletedBefore, batchSize)
+		if err != nil {
+			logkit.ErrorV2(ctx, "dao.PurgeRecords failed", err, logkit.Payload{"total": total})
+			return total, err
+		}
+
+		total += len(ids)
+
+		if len(ids) < batchSize {
+			logkit.Info(ctx, "deleted records purged", logkit.Payload{"total": total})
+			return total, nil
+		}
+	}
+}
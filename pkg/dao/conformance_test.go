@@ -0,0 +1,580 @@
+package dao
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+// conformanceSuite checks the semantics shared by all the RecordDAOs through the interface only,
+// it runs against MemoryRecordDAO here, and against MySqlRecordDAO and the cached RecordDAO in
+// daoSuite.TestConformance, which owns mysql and redis.
+type conformanceSuite struct {
+	suite.Suite
+
+	// newDAO returns an empty RecordDAO for every test.
+	newDAO func() RecordDAO
+	// cleanup empties the storage after every test, it's optional.
+	cleanup func()
+
+	dao RecordDAO
+}
+
+func (s *conformanceSuite) SetupTest() {
+	s.dao = s.newDAO()
+}
+
+func (s *conformanceSuite) TearDownTest() {
+	if s.cleanup != nil {
+		s.cleanup()
+	}
+}
+
+func TestMemoryRecordDAOConformance(t *testing.T) {
+	suite.Run(t, &conformanceSuite{newDAO: func() RecordDAO { return NewMemoryRecordDAO() }})
+}
+
+// createRecords creates the records of the nums in order, the_str of them are AT<num>.
+func (s *conformanceSuite) createRecords(desc string, nums ...int64) []*Record {
+	records := make([]*Record, len(nums))
+	for i, n := range nums {
+		records[i] = &Record{TheNum: n, TheStr: fmt.Sprintf("AT%d", n)}
+		s.Require().NoError(s.dao.CreateRecord(mockCTX, records[i]), desc)
+	}
+
+	return records
+}
+
+// requireSameRecord compares the records read back from the storage, mysql keeps the timestamps in seconds.
+func (s *conformanceSuite) requireSameRecord(exp, act *Record, desc string) {
+	s.Require().NotNil(act, desc)
+	s.Require().Equal(exp.ID, act.ID, desc)
+	s.Require().Equal(exp.TheNum, act.TheNum, desc)
+	s.Require().Equal(exp.TheStr, act.TheStr, desc)
+	s.Require().Equal(exp.Version, act.Version, desc)
+	s.Require().Equal(exp.DeletedAt.Valid, act.DeletedAt.Valid, desc)
+	s.Require().WithinDuration(*exp.CreatedAt, *act.CreatedAt, time.Second, desc)
+}
+
+func recordNums(records []Record) []int64 {
+	nums := make([]int64, len(records))
+	for i, r := range records {
+		nums[i] = r.TheNum
+	}
+
+	return nums
+}
+
+func revisionActions(revs []RecordRevision) []string {
+	actions := make([]string, len(revs))
+	for i, r := range revs {
+		actions[i] = r.Action
+	}
+
+	return actions
+}
+
+func (s *conformanceSuite) TestCreateRecord() {
+	tests := []struct {
+		Desc      string
+		SetupTest func(string)
+		Record    *Record
+		ExpErr    error
+		CheckFunc func(*Record, string)
+	}{
+		{
+			Desc:   "normal case",
+			Record: &Record{TheNum: 1, TheStr: "AT"},
+			ExpErr: nil,
+			CheckFunc: func(record *Record, desc string) {
+				s.Require().NotEqual(uuid.Nil, record.ID, desc)
+				s.Require().Equal(int64(1), record.Version, desc)
+				s.Require().NotNil(record.CreatedAt, desc)
+				s.Require().NotNil(record.UpdatedAt, desc)
+
+				got, err := s.dao.GetRecord(mockCTX, record.ID.String())
+				s.Require().NoError(err, desc)
+				s.requireSameRecord(record, got, desc)
+			},
+		},
+		{
+			Desc:   "the_str too long",
+			Record: &Record{TheNum: 1, TheStr: strings.Repeat("a", 256)},
+			ExpErr: ErrInvalidArgument,
+		},
+		{
+			Desc:   "the_num out of range",
+			Record: &Record{TheNum: 1 << 32, TheStr: "AT"},
+			ExpErr: ErrInvalidArgument,
+		},
+		{
+			Desc: "idempotency key reused with another payload",
+			SetupTest: func(desc string) {
+				key := "key"
+				s.Require().NoError(s.dao.CreateRecord(mockCTX, &Record{TheNum: 1, TheStr: "AT", IdempotencyKey: &key}), desc)
+			},
+			Record: func(key string) *Record { return &Record{TheNum: 2, TheStr: "AT", IdempotencyKey: &key} }("key"),
+			ExpErr: ErrConflict,
+		},
+	}
+
+	for _, t := range tests {
+		s.SetupTest()
+
+		if t.SetupTest != nil {
+			t.SetupTest(t.Desc)
+		}
+
+		err := s.dao.CreateRecord(mockCTX, t.Record)
+		s.Require().ErrorIs(err, t.ExpErr, t.Desc)
+
+		if t.CheckFunc != nil {
+			t.CheckFunc(t.Record, t.Desc)
+		}
+
+		s.TearDownTest()
+	}
+}
+
+func (s *conformanceSuite) TestGetRecord() {
+	// record is created by SetupTest of the case
+	var record *Record
+
+	tests := []struct {
+		Desc      string
+		SetupTest func(string)
+		ID        func() string
+		ExpErr    error
+	}{
+		{
+			Desc:   "not existed",
+			ID:     func() string { return uuid.New().String() },
+			ExpErr: ErrNotFound,
+		},
+		{
+			Desc:   "not an uuid",
+			ID:     func() string { return "nothing" },
+			ExpErr: ErrNotFound,
+		},
+		{
+			Desc: "deleted",
+			SetupTest: func(desc string) {
+				record = s.createRecords(desc, 1)[0]
+				s.Require().NoError(s.dao.DeleteRecord(mockCTX, record.ID.String()), desc)
+			},
+			ID:     func() string { return record.ID.String() },
+			ExpErr: ErrNotFound,
+		},
+		{
+			Desc: "normal case",
+			SetupTest: func(desc string) {
+				record = s.createRecords(desc, 1)[0]
+			},
+			ID:     func() string { return record.ID.String() },
+			ExpErr: nil,
+		},
+	}
+
+	for _, t := range tests {
+		s.SetupTest()
+
+		if t.SetupTest != nil {
+			t.SetupTest(t.Desc)
+		}
+
+		got, err := s.dao.GetRecord(mockCTX, t.ID())
+		s.Require().ErrorIs(err, t.ExpErr, t.Desc)
+		if err == nil {
+			s.requireSameRecord(record, got, t.Desc)
+		}
+
+		s.TearDownTest()
+	}
+}
+
+func (s *conformanceSuite) TestBatchGetRecords() {
+	records := s.createRecords("batch get", 1, 2, 3)
+	s.Require().NoError(s.dao.DeleteRecord(mockCTX, records[2].ID.String()))
+
+	got, err := s.dao.BatchGetRecords(mockCTX, []string{})
+	s.Require().NoError(err, "no ids")
+	s.Require().Empty(got, "no ids")
+
+	ids := []string{records[1].ID.String(), uuid.New().String(), records[0].ID.String(), records[2].ID.String()}
+	got, err = s.dao.BatchGetRecords(mockCTX, ids)
+	s.Require().NoError(err)
+	s.Require().Equal(len(ids), len(got))
+	s.requireSameRecord(records[1], got[0], "in the order of the ids")
+	s.Require().Nil(got[1], "missing")
+	s.requireSameRecord(records[0], got[2], "in the order of the ids")
+	s.Require().Nil(got[3], "deleted")
+}
+
+func (s *conformanceSuite) TestListRecords() {
+	numMin, numMax := int64(2), int64(4)
+	byNum := []RecordOrder{{Field: "the_num"}}
+
+	// records are 1 to 4, and 5 is deleted
+	var records []*Record
+
+	tests := []struct {
+		Desc    string
+		Opt     func() ListRecordsOpt
+		ExpErr  error
+		ExpNums []int64
+	}{
+		{
+			Desc:    "normal case",
+			Opt:     func() ListRecordsOpt { return ListRecordsOpt{Size: 10, OrderBy: byNum} },
+			ExpNums: []int64{1, 2, 3, 4},
+		},
+		{
+			Desc:    "page",
+			Opt:     func() ListRecordsOpt { return ListRecordsOpt{Size: 2, Page: 1, OrderBy: byNum} },
+			ExpNums: []int64{3, 4},
+		},
+		{
+			Desc:    "page out of range",
+			Opt:     func() ListRecordsOpt { return ListRecordsOpt{Size: 2, Page: 5, OrderBy: byNum} },
+			ExpNums: []int64{},
+		},
+		{
+			Desc: "after cursor",
+			Opt: func() ListRecordsOpt {
+				c := records[1].Cursor()
+				return ListRecordsOpt{Size: 10, OrderBy: byNum, After: &c}
+			},
+			ExpNums: []int64{3, 4},
+		},
+		{
+			Desc: "order desc",
+			Opt: func() ListRecordsOpt {
+				return ListRecordsOpt{Size: 2, OrderBy: []RecordOrder{{Field: "the_num", Desc: true}}}
+			},
+			ExpNums: []int64{4, 3},
+		},
+		{
+			Desc: "order desc after cursor",
+			Opt: func() ListRecordsOpt {
+				c := records[2].Cursor()
+				return ListRecordsOpt{Size: 10, OrderBy: []RecordOrder{{Field: "the_num", Desc: true}}, After: &c}
+			},
+			ExpNums: []int64{2, 1},
+		},
+		{
+			Desc: "filter the_num range",
+			Opt: func() ListRecordsOpt {
+				return ListRecordsOpt{Size: 10, OrderBy: byNum, Filter: RecordFilter{TheNumMin: &numMin, TheNumMax: &numMax}}
+			},
+			ExpNums: []int64{2, 3},
+		},
+		{
+			Desc:    "filter the_str",
+			Opt:     func() ListRecordsOpt { return ListRecordsOpt{Size: 10, Filter: RecordFilter{TheStr: "AT3"}} },
+			ExpNums: []int64{3},
+		},
+		{
+			Desc: "filter the_str prefix",
+			Opt: func() ListRecordsOpt {
+				return ListRecordsOpt{Size: 10, Filter: RecordFilter{TheStrPrefix: "AT"}, OrderBy: byNum}
+			},
+			ExpNums: []int64{1, 2, 3, 4},
+		},
+		{
+			Desc: "filter created window",
+			Opt: func() ListRecordsOpt {
+				after, before := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+				return ListRecordsOpt{Size: 10, OrderBy: byNum, Filter: RecordFilter{CreatedAfter: &after, CreatedBefore: &before}}
+			},
+			ExpNums: []int64{1, 2, 3, 4},
+		},
+		{
+			Desc: "filter created in the future",
+			Opt: func() ListRecordsOpt {
+				after := time.Now().Add(time.Hour)
+				return ListRecordsOpt{Size: 10, Filter: RecordFilter{CreatedAfter: &after}}
+			},
+			ExpNums: []int64{},
+		},
+		{
+			Desc: "include deleted",
+			Opt: func() ListRecordsOpt {
+				return ListRecordsOpt{Size: 10, OrderBy: byNum, Filter: RecordFilter{IncludeDeleted: true}}
+			},
+			ExpNums: []int64{1, 2, 3, 4, 5},
+		},
+		{
+			Desc: "unsupported order field",
+			Opt: func() ListRecordsOpt {
+				return ListRecordsOpt{Size: 10, OrderBy: []RecordOrder{{Field: "id; DROP TABLE records"}}}
+			},
+			ExpErr: ErrInvalidArgument,
+		},
+	}
+
+	for _, t := range tests {
+		s.SetupTest()
+
+		records = s.createRecords(t.Desc, 1, 2, 3, 4, 5)
+		s.Require().NoError(s.dao.DeleteRecord(mockCTX, records[4].ID.String()), t.Desc)
+
+		list, err := s.dao.ListRecords(mockCTX, t.Opt())
+		s.Require().ErrorIs(err, t.ExpErr, t.Desc)
+		if err == nil {
+			s.Require().Equal(t.ExpNums, recordNums(list), t.Desc)
+		}
+
+		s.TearDownTest()
+	}
+}
+
+func (s *conformanceSuite) TestCountRecords() {
+	numMin := int64(3)
+
+	tests := []struct {
+		Desc     string
+		Filter   RecordFilter
+		ExpCount int64
+	}{
+		{
+			Desc:     "normal case",
+			Filter:   RecordFilter{},
+			ExpCount: 3,
+		},
+		{
+			Desc:     "filtered",
+			Filter:   RecordFilter{TheNumMin: &numMin},
+			ExpCount: 1,
+		},
+		{
+			Desc:     "include deleted",
+			Filter:   RecordFilter{IncludeDeleted: true},
+			ExpCount: 4,
+		},
+		{
+			Desc:     "nothing matched",
+			Filter:   RecordFilter{TheStr: "nothing"},
+			ExpCount: 0,
+		},
+	}
+
+	for _, t := range tests {
+		s.SetupTest()
+
+		records := s.createRecords(t.Desc, 1, 2, 3, 4)
+		s.Require().NoError(s.dao.DeleteRecord(mockCTX, records[3].ID.String()), t.Desc)
+
+		count, err := s.dao.CountRecords(mockCTX, t.Filter)
+		s.Require().NoError(err, t.Desc)
+		s.Require().Equal(t.ExpCount, count, t.Desc)
+
+		s.TearDownTest()
+	}
+}
+
+func (s *conformanceSuite) TestUpdateRecord() {
+	// record is created before every case
+	var record *Record
+
+	tests := []struct {
+		Desc        string
+		SetupTest   func(string)
+		Record      func() *Record
+		ExpErr      error
+		ExpConflict *VersionConflictError
+		CheckFunc   func(*Record, string)
+	}{
+		{
+			Desc:   "not existed",
+			Record: func() *Record { return &Record{ID: uuid.New(), TheNum: 2, TheStr: "ATT", Version: 1} },
+			ExpErr: ErrNotFound,
+		},
+		{
+			Desc: "deleted",
+			SetupTest: func(desc string) {
+				s.Require().NoError(s.dao.DeleteRecord(mockCTX, record.ID.String()), desc)
+			},
+			Record: func() *Record { return &Record{ID: record.ID, TheNum: 2, TheStr: "ATT", Version: 1} },
+			ExpErr: ErrNotFound,
+		},
+		{
+			Desc:   "the_str too long",
+			Record: func() *Record { return &Record{ID: record.ID, TheNum: 2, TheStr: strings.Repeat("a", 256), Version: 1} },
+			ExpErr: ErrInvalidArgument,
+		},
+		{
+			Desc:        "version conflict",
+			Record:      func() *Record { return &Record{ID: record.ID, TheNum: 2, TheStr: "ATT", Version: 2} },
+			ExpErr:      ErrConflict,
+			ExpConflict: &VersionConflictError{Expected: 2, Current: 1},
+			CheckFunc: func(_ *Record, desc string) {
+				got, err := s.dao.GetRecord(mockCTX, record.ID.String())
+				s.Require().NoError(err, desc)
+				s.requireSameRecord(record, got, desc)
+			},
+		},
+		{
+			Desc:   "normal case",
+			Record: func() *Record { return &Record{ID: record.ID, TheNum: 2, TheStr: "ATT", Version: 1} },
+			ExpErr: nil,
+			CheckFunc: func(updated *Record, desc string) {
+				s.Require().Equal(int64(2), updated.Version, desc)
+				s.Require().WithinDuration(*record.CreatedAt, *updated.CreatedAt, time.Second, desc)
+				s.Require().False(updated.UpdatedAt.Before(record.UpdatedAt.Truncate(time.Second)), desc)
+
+				got, err := s.dao.GetRecord(mockCTX, record.ID.String())
+				s.Require().NoError(err, desc)
+				s.requireSameRecord(updated, got, desc)
+				s.Require().Equal(int64(2), got.TheNum, desc)
+				s.Require().Equal("ATT", got.TheStr, desc)
+
+				// the next update takes the new version
+				next := &Record{ID: record.ID, TheNum: 3, TheStr: "ATT", Version: 2}
+				s.Require().NoError(s.dao.UpdateRecord(mockCTX, next), desc)
+				s.Require().Equal(int64(3), next.Version, desc)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.SetupTest()
+
+		record = s.createRecords(t.Desc, 1)[0]
+		if t.SetupTest != nil {
+			t.SetupTest(t.Desc)
+		}
+
+		r := t.Record()
+		err := s.dao.UpdateRecord(mockCTX, r)
+		s.Require().ErrorIs(err, t.ExpErr, t.Desc)
+
+		if t.ExpConflict != nil {
+			var versionErr *VersionConflictError
+			s.Require().ErrorAs(err, &versionErr, t.Desc)
+			s.Require().Equal(t.ExpConflict, versionErr, t.Desc)
+		}
+
+		if t.CheckFunc != nil {
+			t.CheckFunc(r, t.Desc)
+		}
+
+		s.TearDownTest()
+	}
+}
+
+func (s *conformanceSuite) TestDeleteAndRestoreRecord() {
+	record := s.createRecords("delete", 1)[0]
+	id := record.ID.String()
+
+	s.Require().ErrorIs(s.dao.DeleteRecord(mockCTX, uuid.New().String()), ErrNotFound, "delete not existed")
+	_, err := s.dao.RestoreRecord(mockCTX, id)
+	s.Require().ErrorIs(err, ErrNotFound, "restore not deleted")
+
+	s.Require().NoError(s.dao.DeleteRecord(mockCTX, id), "delete")
+	_, err = s.dao.GetRecord(mockCTX, id)
+	s.Require().ErrorIs(err, ErrNotFound, "get deleted")
+	s.Require().ErrorIs(s.dao.DeleteRecord(mockCTX, id), ErrNotFound, "delete deleted")
+
+	list, err := s.dao.ListRecords(mockCTX, ListRecordsOpt{Size: 10, Filter: RecordFilter{IncludeDeleted: true}})
+	s.Require().NoError(err, "list deleted")
+	s.Require().Equal(1, len(list), "list deleted")
+	s.Require().True(list[0].DeletedAt.Valid, "list deleted")
+
+	restored, err := s.dao.RestoreRecord(mockCTX, id)
+	s.Require().NoError(err, "restore")
+	s.requireSameRecord(record, restored, "restore")
+
+	got, err := s.dao.GetRecord(mockCTX, id)
+	s.Require().NoError(err, "get restored")
+	s.requireSameRecord(record, got, "get restored")
+
+	_, err = s.dao.RestoreRecord(mockCTX, id)
+	s.Require().ErrorIs(err, ErrNotFound, "restore restored")
+}
+
+func (s *conformanceSuite) TestPurgeRecords() {
+	records := s.createRecords("purge", 1, 2, 3)
+	for _, r := range records[:2] {
+		s.Require().NoError(s.dao.DeleteRecord(mockCTX, r.ID.String()))
+	}
+
+	ids, err := s.dao.PurgeRecords(mockCTX, time.Now().Add(-time.Hour), 10)
+	s.Require().NoError(err, "within the retention")
+	s.Require().Empty(ids, "within the retention")
+
+	purged := []string{}
+	for i := 0; i < 2; i++ {
+		ids, err := s.dao.PurgeRecords(mockCTX, time.Now().Add(time.Hour), 1)
+		s.Require().NoError(err, "limited")
+		s.Require().Equal(1, len(ids), "limited")
+		purged = append(purged, ids...)
+	}
+	s.Require().ElementsMatch([]string{records[0].ID.String(), records[1].ID.String()}, purged)
+
+	ids, err = s.dao.PurgeRecords(mockCTX, time.Now().Add(time.Hour), 1)
+	s.Require().NoError(err, "all purged")
+	s.Require().Empty(ids, "all purged")
+
+	_, err = s.dao.RestoreRecord(mockCTX, records[0].ID.String())
+	s.Require().ErrorIs(err, ErrNotFound, "restore purged")
+
+	list, err := s.dao.ListRecords(mockCTX, ListRecordsOpt{Size: 10, Filter: RecordFilter{IncludeDeleted: true}})
+	s.Require().NoError(err, "list purged")
+	s.Require().Equal([]int64{3}, recordNums(list), "list purged")
+
+	revs, err := s.dao.ListRecordRevisions(mockCTX, records[0].ID.String(), ListRevisionsOpt{})
+	s.Require().NoError(err, "revisions of purged")
+	s.Require().Equal([]string{RevisionPurge, RevisionDelete, RevisionCreate}, revisionActions(revs), "revisions of purged")
+	for _, rev := range revs {
+		s.Require().Nil(rev.OldValue, "revisions of purged erased")
+		s.Require().Nil(rev.NewValue, "revisions of purged erased")
+	}
+
+	revs, err = s.dao.ListRecordRevisions(mockCTX, records[2].ID.String(), ListRevisionsOpt{})
+	s.Require().NoError(err, "revisions of live")
+	s.Require().NotNil(revs[0].NewValue, "revisions of live")
+}
+
+func (s *conformanceSuite) TestListRecordRevisions() {
+	auditCTX := WithAudit(mockCTX, Audit{Actor: "alice", RequestID: "req-1"})
+
+	record := &Record{TheNum: 1, TheStr: "AT"}
+	s.Require().NoError(s.dao.CreateRecord(auditCTX, record))
+	id := record.ID.String()
+	s.Require().NoError(s.dao.UpdateRecord(auditCTX, &Record{ID: record.ID, TheNum: 2, TheStr: "AT", Version: 1}))
+	s.Require().NoError(s.dao.DeleteRecord(auditCTX, id))
+	_, err := s.dao.RestoreRecord(auditCTX, id)
+	s.Require().NoError(err)
+
+	// another record doesn't mix in
+	s.createRecords("another record", 3)
+
+	revs, err := s.dao.ListRecordRevisions(mockCTX, id, ListRevisionsOpt{})
+	s.Require().NoError(err)
+	s.Require().Equal(4, len(revs))
+	for i, action := range []string{RevisionRestore, RevisionDelete, RevisionUpdate, RevisionCreate} {
+		s.Require().Equal(action, revs[i].Action, action)
+		s.Require().Equal(id, revs[i].RecordID, action)
+		s.Require().Equal("alice", revs[i].Actor, action)
+		s.Require().Equal("req-1", revs[i].RequestID, action)
+	}
+	s.Require().Nil(revs[3].OldValue, "create")
+	s.Require().Equal(int64(2), revs[2].NewValue.TheNum, "update")
+	s.Require().Nil(revs[1].NewValue, "delete")
+
+	first, err := s.dao.ListRecordRevisions(mockCTX, id, ListRevisionsOpt{Size: 3})
+	s.Require().NoError(err, "first page")
+	s.Require().Equal(revs[:3], first, "first page")
+
+	last, err := s.dao.ListRecordRevisions(mockCTX, id, ListRevisionsOpt{Size: 3, Before: first[2].ID})
+	s.Require().NoError(err, "last page")
+	s.Require().Equal(revs[3:], last, "last page")
+
+	none, err := s.dao.ListRecordRevisions(mockCTX, uuid.New().String(), ListRevisionsOpt{})
+	s.Require().NoError(err, "no revisions")
+	s.Require().Empty(none, "no revisions")
+}
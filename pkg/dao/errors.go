@@ -0,0 +1,116 @@
+package dao
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+)
+
+// The kinds of failures RecordDAO reports. Check them with errors.Is.
+var (
+	ErrNotFound        = errors.New("not found")
+	ErrConflict        = errors.New("conflict")
+	ErrInvalidArgument = errors.New("invalid argument")
+	ErrUnavailable     = errors.New("unavailable")
+	// ErrCanceled is the cancellation of the caller, ex: the client is gone.
+	ErrCanceled = errors.New("canceled")
+)
+
+// mysql server error numbers
+// https://dev.mysql.com/doc/mysql-errors/8.0/en/server-error-reference.html
+const (
+	mysqlErrBadNull         = 1048
+	mysqlErrDupEntry        = 1062
+	mysqlErrOutOfRange      = 1264
+	mysqlErrWrongValue      = 1366
+	mysqlErrDataTooLong     = 1406
+	mysqlErrLockWaitTimeout = 1205
+	mysqlErrLockDeadlock    = 1213
+)
+
+// Error keeps the original error along with its kind.
+type Error struct {
+	Kind error
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return e.Kind.Error() + ": " + e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func (e *Error) Is(target error) bool {
+	return e.Kind == target
+}
+
+// VersionConflictError is the Err of the ErrConflict when the version to update isn't the current one.
+type VersionConflictError struct {
+	Expected int64
+	Current  int64
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("version %d doesn't match the current version %d", e.Expected, e.Current)
+}
+
+// formatError classifies errors from gorm and the mysql driver.
+// Errors it does not recognize are returned as they are.
+func formatError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var daoErr *Error
+	if errors.As(err, &daoErr) {
+		return err
+	}
+
+	if kind := errorKind(err); kind != nil {
+		return &Error{Kind: kind, Err: err}
+	}
+
+	return err
+}
+
+func errorKind(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotFound
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return ErrCanceled
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, mysql.ErrInvalidConn) {
+		return ErrUnavailable
+	}
+
+	var myErr *mysql.MySQLError
+	if errors.As(err, &myErr) {
+		switch myErr.Number {
+		case mysqlErrDupEntry:
+			return ErrConflict
+		case mysqlErrBadNull, mysqlErrOutOfRange, mysqlErrWrongValue, mysqlErrDataTooLong:
+			return ErrInvalidArgument
+		case mysqlErrLockWaitTimeout, mysqlErrLockDeadlock:
+			return ErrUnavailable
+		}
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return ErrUnavailable
+	}
+
+	return nil
+}
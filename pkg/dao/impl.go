@@ -2,9 +2,13 @@ package dao
 
 import (
 	"context"
+	"crypto/sha1"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/go-redis/redis/v8"
 	"gorm.io/gorm"
 
 	"github.com/AmazingTalker/go-cache"
@@ -15,6 +19,22 @@ import (
 
 const (
 	pfxRecord = "records"
+	sharedTTL = time.Minute
+	localTTL  = 10 * time.Second
+	// pfxMissingRecord remembers the ids not found in mysql for CacheOpt.NegativeTTL.
+	pfxMissingRecord = "missing-records"
+
+	// keyRecordListGen is bumped on every write, all the cached list pages are
+	// keyed by it so they are invalidated at once.
+	keyRecordListGen = "go-amazing:records:list-gen"
+	// chRecordEviction broadcasts evicted record ids, so the other pods can drop
+	// their local copies.
+	chRecordEviction = "go-amazing:records:evict"
+
+	// pfxIdempotencyKey keeps the used idempotency keys of CreateRecord for a day, the
+	// unique constraint of the records table still catches the retries after that.
+	pfxIdempotencyKey = "go-amazing:records:idempotency:"
+	idempotencyKeyTTL = 24 * time.Hour
 )
 
 var (
@@ -23,23 +43,64 @@ var (
 	)
 )
 
+var errIdempotencyKeyReused = &Error{Kind: ErrConflict, Err: errors.New("the idempotency key is used with another payload")}
+
+// errMissingRecord is errRecordNotFound served from the cache of the missing ids.
+var errMissingRecord = &Error{Kind: ErrNotFound, Err: gorm.ErrRecordNotFound}
+
+// idempotencyEntry is what the used idempotency keys are stored with in redis.
+type idempotencyEntry struct {
+	RequestHash string `json:"h"`
+	RecordID    string `json:"i"`
+}
+
+// RecordDAOOpt configures the DAO of NewRecordDAO.
+type RecordDAOOpt struct {
+	MySql MySqlOpt
+	Cache CacheOpt
+	// LocalCache is the local tier of the cache service, the evictions broadcast by the other
+	// pods are deleted from it only since they've deleted the shared copies already.
+	// The broadcasts aren't subscribed when it's nil.
+	LocalCache cache.Adapter
+}
+
 type impl struct {
-	mysql MySqlRecordDAO
-	cache cache.Cache
+	mysql     MySqlRecordDAO
+	cache     cache.Cache
+	local     cache.Adapter
+	ring      *redis.Ring
+	opt       CacheOpt
+	flights   flightGroup
+	loadStats loadStats
 }
 
-func NewRecordDAO(db *gorm.DB, cacheSrv cache.Service) RecordDAO {
-	im := &impl{mysql: NewMySqlRecordDAO(db)}
+func NewRecordDAO(db *gorm.DB, cacheSrv cache.Service, ring *redis.Ring, opt RecordDAOOpt) RecordDAO {
+	im := &impl{mysql: NewMySqlRecordDAO(db, opt.MySql), local: opt.LocalCache, ring: ring, opt: opt.Cache}
 
-	im.cache = cacheSrv.Create([]cache.Setting{
+	settings := []cache.Setting{
 		{
 			Prefix: pfxRecord,
 			CacheAttributes: map[cache.Type]cache.Attribute{
-				cache.SharedCacheType: {TTL: time.Minute},
-				cache.LocalCacheType:  {TTL: 10 * time.Second},
+				cache.SharedCacheType: {TTL: sharedTTL},
+				cache.LocalCacheType:  {TTL: localTTL},
 			},
 		},
-	})
+	}
+	if opt.Cache.NegativeTTL > 0 {
+		localNegativeTTL := opt.Cache.NegativeTTL
+		if localNegativeTTL > localTTL {
+			localNegativeTTL = localTTL
+		}
+
+		settings = append(settings, cache.Setting{
+			Prefix: pfxMissingRecord,
+			CacheAttributes: map[cache.Type]cache.Attribute{
+				cache.SharedCacheType: {TTL: opt.Cache.NegativeTTL},
+				cache.LocalCacheType:  {TTL: localNegativeTTL},
+			},
+		})
+	}
+	im.cache = cacheSrv.Create(settings)
 
 	/*
 		Use cases:
@@ -73,13 +134,158 @@ func NewRecordDAO(db *gorm.DB, cacheSrv cache.Service) RecordDAO {
 		More examples: https://github.com/AmazingTalker/go-cache
 	*/
 
+	if im.local != nil {
+		go im.subscribeEvictions(context.Background())
+	}
+
 	return im
 }
 
 func (im *impl) CreateRecord(ctx context.Context, record *Record, enrich ...daokit.Enrich) error {
 	defer met.RecordDuration([]string{"time"}, map[string]string{}).End()
 
-	return im.mysql.CreateRecord(ctx, record, enrich...)
+	if record.IdempotencyKey != nil {
+		ctx = logkit.EnrichPayload(ctx, logkit.Payload{"idempotencyKey": *record.IdempotencyKey})
+
+		replayed, err := im.replayCreate(ctx, record)
+		if err != nil {
+			return err
+		}
+		if replayed {
+			return nil
+		}
+	}
+
+	if err := im.mysql.CreateRecord(ctx, record, enrich...); err != nil {
+		if record.IdempotencyKey != nil && errors.Is(err, ErrConflict) {
+			// a concurrent request with the same key has won
+			return im.replayCreateFromDB(ctx, record, err)
+		}
+		return err
+	}
+
+	if record.IdempotencyKey != nil {
+		im.rememberIdempotencyKey(ctx, record)
+	}
+
+	// the id may be cached as missing
+	im.invalidate(ctx, record.ID.String())
+
+	return nil
+}
+
+// replayCreate fills the record with the one created by the same idempotency key, it reports
+// false when the key isn't in redis, or the record has been deleted since.
+func (im *impl) replayCreate(ctx context.Context, record *Record) (bool, error) {
+	b, err := im.ring.Get(ctx, pfxIdempotencyKey+*record.IdempotencyKey).Bytes()
+	if err == redis.Nil {
+		return false, nil
+	}
+	if err != nil {
+		// the unique constraint catches the retry anyway
+		logkit.ErrorV2(ctx, "get idempotency key failed", err, nil)
+		return false, nil
+	}
+
+	entry := idempotencyEntry{}
+	if err := json.Unmarshal(b, &entry); err != nil {
+		logkit.ErrorV2(ctx, "unmarshal idempotency entry failed", err, nil)
+		return false, nil
+	}
+
+	hash := record.requestHash()
+	if entry.RequestHash != hash {
+		return false, errIdempotencyKeyReused
+	}
+
+	origin, err := im.GetRecord(ctx, entry.RecordID)
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	// the cached records don't carry the idempotency key
+	origin.IdempotencyKey = record.IdempotencyKey
+	origin.RequestHash = &hash
+	*record = *origin
+
+	return true, nil
+}
+
+// replayCreateFromDB fills the record with the one holding the same idempotency key in mysql,
+// createErr is returned when the conflict isn't about the key. A deleted record holds its key
+// until it's purged, the retries are conflicts till then.
+func (im *impl) replayCreateFromDB(ctx context.Context, record *Record, createErr error) error {
+	origin, err := im.mysql.GetRecordByIdempotencyKey(ctx, *record.IdempotencyKey)
+	if errors.Is(err, ErrNotFound) {
+		return createErr
+	}
+	if err != nil {
+		return err
+	}
+
+	if origin.RequestHash == nil || *origin.RequestHash != record.requestHash() {
+		return errIdempotencyKeyReused
+	}
+
+	*record = *origin
+	im.rememberIdempotencyKey(ctx, record)
+
+	return nil
+}
+
+// rememberIdempotencyKey is a shortcut for the retries, failures are only logged.
+func (im *impl) rememberIdempotencyKey(ctx context.Context, record *Record) {
+	b, err := json.Marshal(idempotencyEntry{RequestHash: *record.RequestHash, RecordID: record.ID.String()})
+	if err != nil {
+		logkit.ErrorV2(ctx, "marshal idempotency entry failed", err, nil)
+		return
+	}
+
+	if err := im.ring.Set(ctx, pfxIdempotencyKey+*record.IdempotencyKey, b, idempotencyKeyTTL).Err(); err != nil {
+		logkit.ErrorV2(ctx, "set idempotency key failed", err, nil)
+	}
+}
+
+// getByFunc reads the key through the cache, the loader is bound to ctx since the getters of
+// go-cache take no context. The concurrent misses of the key are loaded once per pod, and once
+// among the pods with the rebuild lock. The hits may refresh the key before it expires.
+// The negative keys are the ids cached as missing, see rebuild.
+// The lookups are counted by the kind of the key and the outcome.
+func (im *impl) getByFunc(ctx context.Context, kind, key string, container interface{}, negative bool, load func(context.Context) (interface{}, error)) error {
+	loaded, outcome := false, "miss"
+
+	err := im.cache.GetByFunc(ctx, pfxRecord, key, container, func() (interface{}, error) {
+		loaded = true
+
+		v, err := im.flights.do(ctx, key, func() (interface{}, error) {
+			return im.rebuild(logkit.EnrichPayload(ctx, logkit.Payload{"cacheHit": false}), key, container, negative, load)
+		})
+		if err == errMissingRecord {
+			outcome = "negative_hit"
+		}
+		return v, err
+	})
+
+	switch {
+	case !loaded && err != nil:
+		outcome = "error"
+	case !loaded:
+		outcome = "hit"
+	}
+	met.IncrCounter([]string{"cache", "lookup"}, 1, map[string]string{"kind": kind, "outcome": outcome})
+
+	if err != nil {
+		return err
+	}
+
+	if !loaded {
+		im.refreshEarly(ctx, key, load)
+	}
+
+	return nil
 }
 
 func (im *impl) GetRecord(ctx context.Context, id string) (*Record, error) {
@@ -88,10 +294,8 @@ func (im *impl) GetRecord(ctx context.Context, id string) (*Record, error) {
 	record := &Record{}
 	ctx = logkit.EnrichPayload(ctx, logkit.Payload{"usingCachePrefix": pfxRecord})
 
-	if err := im.cache.GetByFunc(ctx, pfxRecord, id, record, func() (interface{}, error) {
-		// TODO: cache GetByFunc should pass the context
-		ctx = logkit.EnrichPayload(ctx, logkit.Payload{"cacheHit": false})
-		return im.mysql.GetRecord(ctx, id)
+	if err := im.getByFunc(ctx, "record", id, record, true, func(ctx context.Context) (interface{}, error) {
+		return im.loadRecord(ctx, id)
 	}); err != nil {
 		return nil, err
 	}
@@ -99,14 +303,121 @@ func (im *impl) GetRecord(ctx context.Context, id string) (*Record, error) {
 	return record, nil
 }
 
+// loadRecord remembers the ids not found in mysql for NegativeTTL, so the lookups of the missing
+// ids don't reach mysql. An id looked up while its record is created may be missing till then.
+// The remembered ids are reported before the load, see rebuild.
+func (im *impl) loadRecord(ctx context.Context, id string) (interface{}, error) {
+	if im.opt.NegativeTTL <= 0 {
+		return im.mysql.GetRecord(ctx, id)
+	}
+
+	record, err := im.mysql.GetRecord(ctx, id)
+	if errors.Is(err, ErrNotFound) {
+		if err := im.cache.Set(ctx, pfxMissingRecord, id, true); err != nil {
+			logkit.ErrorV2(ctx, "set missing record failed", err, logkit.Payload{"id": id})
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// isMissing reports whether the id is remembered as missing by loadRecord.
+func (im *impl) isMissing(ctx context.Context, id string) bool {
+	if im.opt.NegativeTTL <= 0 {
+		return false
+	}
+
+	missing := false
+	err := im.cache.Get(ctx, pfxMissingRecord, id, &missing)
+	if err != nil && !errors.Is(err, cache.ErrCacheMiss) {
+		logkit.ErrorV2(ctx, "get missing record failed", err, logkit.Payload{"id": id})
+	}
+
+	return err == nil && missing
+}
+
+// BatchGetRecords reads all the ids from the cache at once, and backfills the misses from mysql.
+func (im *impl) BatchGetRecords(ctx context.Context, ids []string) ([]*Record, error) {
+	defer met.RecordDuration([]string{"time"}, map[string]string{}).End()
+
+	records := make([]*Record, len(ids))
+	if len(ids) == 0 {
+		return records, nil
+	}
+
+	ctx = logkit.EnrichPayload(ctx, logkit.Payload{"usingCachePrefix": pfxRecord})
+
+	res, err := im.cache.MGet(ctx, pfxRecord, ids...)
+	if err != nil {
+		logkit.ErrorV2(ctx, "cache.MGet failed, bypass the cache", err, nil)
+		return im.mysql.BatchGetRecords(ctx, ids)
+	}
+
+	misses := []string{}
+	for i := 0; i < res.Len(); i++ {
+		r := &Record{}
+		if err := res.Get(ctx, i, r); err != nil {
+			if !errors.Is(err, cache.ErrCacheMiss) {
+				logkit.ErrorV2(ctx, "get cached record failed", err, logkit.Payload{"id": ids[i]})
+			}
+			misses = append(misses, ids[i])
+			continue
+		}
+		records[i] = r
+	}
+
+	if len(misses) == 0 {
+		return records, nil
+	}
+
+	missed, err := im.mysql.BatchGetRecords(ctx, misses)
+	if err != nil {
+		return nil, err
+	}
+
+	backfill := make(map[string]interface{}, len(missed))
+	for _, r := range missed {
+		if r != nil {
+			backfill[r.ID.String()] = r
+		}
+	}
+
+	for i, id := range ids {
+		if records[i] == nil && backfill[id] != nil {
+			records[i] = backfill[id].(*Record)
+		}
+	}
+
+	if len(backfill) > 0 {
+		if err := im.cache.MSet(ctx, pfxRecord, backfill); err != nil {
+			logkit.ErrorV2(ctx, "cache.MSet failed", err, logkit.Payload{"count": len(backfill)})
+		}
+	}
+
+	return records, nil
+}
+
 func (im *impl) ListRecords(ctx context.Context, opt ListRecordsOpt) ([]Record, error) {
 	defer met.RecordDuration([]string{"time"}, map[string]string{}).End()
 
 	var records []Record
 
-	key := fmt.Sprintf("%v-%v", opt.Page, opt.Size)
-	if err := im.cache.GetByFunc(ctx, pfxRecord, key, &records, func() (interface{}, error) {
-		// TODO: cache GetByFunc should pass the context
+	gen, err := im.listGeneration(ctx)
+	if err != nil {
+		logkit.ErrorV2(ctx, "get list generation failed, bypass the cache", err, nil)
+		return im.mysql.ListRecords(ctx, opt)
+	}
+
+	key, err := listCacheKey(gen, opt)
+	if err != nil {
+		logkit.ErrorV2(ctx, "build list cache key failed, bypass the cache", err, nil)
+		return im.mysql.ListRecords(ctx, opt)
+	}
+
+	if err := im.getByFunc(ctx, "list", key, &records, false, func(ctx context.Context) (interface{}, error) {
 		return im.mysql.ListRecords(ctx, opt)
 	}); err != nil {
 		return nil, err
@@ -114,3 +425,215 @@ func (im *impl) ListRecords(ctx context.Context, opt ListRecordsOpt) ([]Record,
 
 	return records, nil
 }
+
+// CountRecords caches the count apart from the pages, all the pages of a list share it.
+func (im *impl) CountRecords(ctx context.Context, filter RecordFilter) (int64, error) {
+	defer met.RecordDuration([]string{"time"}, map[string]string{}).End()
+
+	var count int64
+
+	gen, err := im.listGeneration(ctx)
+	if err != nil {
+		logkit.ErrorV2(ctx, "get list generation failed, bypass the cache", err, nil)
+		return im.mysql.CountRecords(ctx, filter)
+	}
+
+	key, err := countCacheKey(gen, filter)
+	if err != nil {
+		logkit.ErrorV2(ctx, "build count cache key failed, bypass the cache", err, nil)
+		return im.mysql.CountRecords(ctx, filter)
+	}
+
+	if err := im.getByFunc(ctx, "count", key, &count, false, func(ctx context.Context) (interface{}, error) {
+		return im.mysql.CountRecords(ctx, filter)
+	}); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (im *impl) UpdateRecord(ctx context.Context, record *Record, enrich ...daokit.Enrich) error {
+	defer met.RecordDuration([]string{"time"}, map[string]string{}).End()
+
+	if err := im.mysql.UpdateRecord(ctx, record, enrich...); err != nil {
+		return err
+	}
+
+	im.invalidate(ctx, record.ID.String())
+
+	return nil
+}
+
+func (im *impl) DeleteRecord(ctx context.Context, id string, enrich ...daokit.Enrich) error {
+	defer met.RecordDuration([]string{"time"}, map[string]string{}).End()
+
+	if err := im.mysql.DeleteRecord(ctx, id, enrich...); err != nil {
+		return err
+	}
+
+	im.invalidate(ctx, id)
+
+	return nil
+}
+
+func (im *impl) RestoreRecord(ctx context.Context, id string, enrich ...daokit.Enrich) (*Record, error) {
+	defer met.RecordDuration([]string{"time"}, map[string]string{}).End()
+
+	record, err := im.mysql.RestoreRecord(ctx, id, enrich...)
+	if err != nil {
+		return nil, err
+	}
+
+	im.invalidate(ctx, id)
+
+	return record, nil
+}
+
+// PurgeRecords evicts the purged records, the cached lists including the deleted ones may have them.
+func (im *impl) PurgeRecords(ctx context.Context, deletedBefore time.Time, limit int) ([]string, error) {
+	defer met.RecordDuration([]string{"time"}, map[string]string{}).End()
+
+	ids, err := im.mysql.PurgeRecords(ctx, deletedBefore, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ids) > 0 {
+		im.invalidate(ctx, ids...)
+	}
+
+	return ids, nil
+}
+
+// ListRecordRevisions isn't cached, the history is read rarely.
+func (im *impl) ListRecordRevisions(ctx context.Context, recordID string, opt ListRevisionsOpt) ([]RecordRevision, error) {
+	defer met.RecordDuration([]string{"time"}, map[string]string{}).End()
+
+	return im.mysql.ListRecordRevisions(ctx, recordID, opt)
+}
+
+// invalidate evicts the given ids from both cache tiers, tells the other pods to
+// do the same, and invalidates all the cached list pages.
+// The write has been done already, so failures are only logged.
+// The generation is bumped before the evictions, so the early refreshes seeing it unchanged
+// after their writes are always followed by the evictions, see refresh.
+func (im *impl) invalidate(ctx context.Context, ids ...string) {
+	if err := im.ring.Incr(ctx, keyRecordListGen).Err(); err != nil {
+		logkit.ErrorV2(ctx, "bump list generation failed", err, nil)
+	}
+
+	if len(ids) > 0 {
+		im.evict(ctx, ids...)
+		im.publishEvictions(ctx, ids...)
+	}
+}
+
+// publishEvictions tells the other pods to drop their local copies of the ids.
+func (im *impl) publishEvictions(ctx context.Context, ids ...string) {
+	for _, id := range ids {
+		if err := im.ring.Publish(ctx, chRecordEviction, id).Err(); err != nil {
+			logkit.ErrorV2(ctx, "publish eviction failed", err, logkit.Payload{"id": id})
+		}
+	}
+}
+
+func (im *impl) listGeneration(ctx context.Context) (int64, error) {
+	gen, err := im.ring.Get(ctx, keyRecordListGen).Int64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+
+	return gen, err
+}
+
+// listCacheKey keys a list page by the generation and the hash of the normalized options,
+// since the filters are unbounded strings. ex: 3-2fd4e1c67a2d28fced849ee1bb76e7391b93eb12
+func listCacheKey(gen int64, opt ListRecordsOpt) (string, error) {
+	opt.Filter = opt.Filter.normalize()
+
+	if opt.After != nil {
+		after := *opt.After
+		after.CreatedAt = after.CreatedAt.UTC()
+		after.UpdatedAt = after.UpdatedAt.UTC()
+		opt.After = &after
+		opt.Page = 0
+	}
+
+	b, err := json.Marshal(opt)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%v-%x", gen, sha1.Sum(b)), nil
+}
+
+// countCacheKey keys the count like listCacheKey. ex: count-3-2fd4e1c67a2d28fced849ee1bb76e7391b93eb12
+func countCacheKey(gen int64, filter RecordFilter) (string, error) {
+	b, err := json.Marshal(filter.normalize())
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("count-%v-%x", gen, sha1.Sum(b)), nil
+}
+
+// normalize puts the times in UTC, the same instant in different locations has to hit the same key.
+func (f RecordFilter) normalize() RecordFilter {
+	utc := func(t *time.Time) *time.Time {
+		if t == nil {
+			return nil
+		}
+		u := t.UTC()
+		return &u
+	}
+
+	f.CreatedAfter = utc(f.CreatedAfter)
+	f.CreatedBefore = utc(f.CreatedBefore)
+	f.UpdatedAfter = utc(f.UpdatedAfter)
+	f.UpdatedBefore = utc(f.UpdatedBefore)
+
+	return f
+}
+
+// subscribeEvictions drops the local copies of records evicted by other pods.
+// It stops when the redis ring is closed.
+func (im *impl) subscribeEvictions(ctx context.Context) {
+	sub := im.ring.Subscribe(ctx, chRecordEviction)
+	defer sub.Close()
+
+	for msg := range sub.Channel() {
+		im.evictLocal(ctx, msg.Payload)
+	}
+}
+
+// evictLocal drops the id from the records and the missing ids in the local tier.
+func (im *impl) evictLocal(ctx context.Context, id string) {
+	keys := []string{localCacheKey(pfxRecord, id)}
+	if im.opt.NegativeTTL > 0 {
+		keys = append(keys, localCacheKey(pfxMissingRecord, id))
+	}
+
+	if err := im.local.Del(ctx, keys...); err != nil {
+		logkit.ErrorV2(ctx, "local cache.Del failed", err, logkit.Payload{"id": id})
+	}
+}
+
+// localCacheKey is the key of go-cache for the prefix. ex: ca:records:<key>
+// go-cache doesn't export it, TestLocalCacheKey pins it.
+func localCacheKey(pfx, key string) string {
+	return fmt.Sprintf("ca:%s:%s", pfx, key)
+}
+
+// evict drops the ids from the records and the missing ids in both cache tiers.
+func (im *impl) evict(ctx context.Context, ids ...string) {
+	if err := im.cache.Del(ctx, pfxRecord, ids...); err != nil {
+		logkit.ErrorV2(ctx, "cache.Del failed", err, logkit.Payload{"ids": ids})
+	}
+
+	if im.opt.NegativeTTL > 0 {
+		if err := im.cache.Del(ctx, pfxMissingRecord, ids...); err != nil {
+			logkit.ErrorV2(ctx, "cache.Del failed", err, logkit.Payload{"ids": ids})
+		}
+	}
+}
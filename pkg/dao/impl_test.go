@@ -3,6 +3,7 @@ package dao
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -10,12 +11,16 @@ import (
 	"time"
 
 	"github.com/go-redis/redis/v8"
+	"github.com/go-sql-driver/mysql"
 	"github.com/google/uuid"
+	"github.com/pressly/goose"
 	"github.com/stretchr/testify/suite"
 	"gorm.io/gorm"
 
+	"github.com/AmazingTalker/go-amazing/pkg/cachestats"
 	"github.com/AmazingTalker/go-cache"
 	"github.com/AmazingTalker/go-rpc-kit/cachekit"
+	"github.com/AmazingTalker/go-rpc-kit/daokit"
 	"github.com/AmazingTalker/go-rpc-kit/dockerkit"
 	"github.com/AmazingTalker/go-rpc-kit/logkit"
 	"github.com/AmazingTalker/go-rpc-kit/migrationkit"
@@ -33,11 +38,22 @@ var (
 	mockUUID    = uuid.New()
 	mockTimeNow time.Time
 	mockLoc     *time.Location
+
+	mockTimeLater      time.Time
+	mockOrderedRecords []Record
 )
 
 func init() {
 	mockLoc, _ = time.LoadLocation("")
 	mockTimeNow = time.Unix(1629446406, 0).In(mockLoc)
+	mockTimeLater = mockTimeNow.Add(time.Second)
+
+	// in the default order of the list
+	mockOrderedRecords = []Record{
+		{ID: uuid.MustParse("00000000-0000-0000-0000-000000000003"), CreatedAt: &mockTimeNow, UpdatedAt: &mockTimeNow, TheNum: 1, TheStr: "AT1", Version: 1},
+		{ID: uuid.MustParse("00000000-0000-0000-0000-000000000001"), CreatedAt: &mockTimeLater, UpdatedAt: &mockTimeLater, TheNum: 2, TheStr: "AT2", Version: 1},
+		{ID: uuid.MustParse("00000000-0000-0000-0000-000000000002"), CreatedAt: &mockTimeLater, UpdatedAt: &mockTimeLater, TheNum: 3, TheStr: "AT3", Version: 1},
+	}
 }
 
 type daoSuite struct {
@@ -46,6 +62,7 @@ type daoSuite struct {
 	ring  *redis.Ring
 	db    *gorm.DB
 	cache cache.Service
+	local cache.Adapter
 	im    *impl
 
 	redisPort string
@@ -144,12 +161,17 @@ func (s *daoSuite) TearDownSuite() {
 
 func (s *daoSuite) SetupTest() {
 	cache.ClearPrefix()
+	s.local = cachekit.NewLocalCache(1024)
 	s.cache = cachekit.NewCache(
 		cachekit.NewSharedCache(s.ring),
-		cachekit.NewLocalCache(1024),
+		s.local,
 	)
 
-	s.im = NewRecordDAO(s.db, s.cache).(*impl)
+	s.im = NewRecordDAO(s.db, s.cache, s.ring, RecordDAOOpt{
+		MySql:      MySqlOpt{QueryTimeout: 5 * time.Second},
+		Cache:      CacheOpt{RebuildLock: true, RebuildWait: time.Second, EarlyRefreshBeta: 1, NegativeTTL: 5 * time.Second},
+		LocalCache: s.local,
+	}).(*impl)
 }
 
 func (s *daoSuite) TearDownTest() {
@@ -161,13 +183,135 @@ func (s *daoSuite) TearDownTest() {
 	}))
 
 	// clean all in mysql
-	s.Require().NoError(s.db.Where("1 = 1").Delete(&Record{}).Error)
+	s.Require().NoError(s.db.Unscoped().Where("1 = 1").Delete(&Record{}).Error)
+	s.Require().NoError(s.db.Where("1 = 1").Delete(&RecordRevision{}).Error)
 }
 
 func TestDAOSuite(t *testing.T) {
 	suite.Run(t, new(daoSuite))
 }
 
+func (s *daoSuite) TestMigrations() {
+	sqlDB, err := s.db.DB()
+	s.Require().NoError(err)
+	s.Require().NoError(goose.SetDialect("mysql"))
+
+	latest, err := goose.GetDBVersion(sqlDB)
+	s.Require().NoError(err)
+
+	type column struct {
+		ColumnName string
+		IsNullable string
+		ColumnKey  string
+		Extra      string
+	}
+
+	tableColumns := func(table, desc string) map[string]column {
+		cs := []column{}
+		s.Require().NoError(s.db.Raw(
+			"SELECT column_name AS column_name, is_nullable AS is_nullable, column_key AS column_key, extra AS extra "+
+				"FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = ?",
+			table,
+		).Scan(&cs).Error, desc)
+
+		m := map[string]column{}
+		for _, c := range cs {
+			m[c.ColumnName] = c
+		}
+		return m
+	}
+
+	columns := func(desc string) map[string]column {
+		return tableColumns("records", desc)
+	}
+
+	indexes := func(desc string) map[string]bool {
+		names := []string{}
+		s.Require().NoError(s.db.Raw(
+			"SELECT DISTINCT index_name AS index_name FROM information_schema.statistics WHERE table_schema = DATABASE() AND table_name = ?",
+			"records",
+		).Scan(&names).Error, desc)
+
+		m := map[string]bool{}
+		for _, n := range names {
+			m[n] = true
+		}
+		return m
+	}
+
+	checkLatest := func(desc string) {
+		cs := columns(desc)
+		s.Require().Equal("PRI", cs["id"].ColumnKey, desc)
+		for _, name := range []string{"id", "the_num", "the_str", "created_at", "updated_at", "version"} {
+			s.Require().Equal("NO", cs[name].IsNullable, "%s: %s", desc, name)
+		}
+		s.Require().Contains(strings.ToLower(cs["updated_at"].Extra), "on update current_timestamp", desc)
+
+		idx := indexes(desc)
+		s.Require().True(idx["idx_records_created_at"], desc)
+		s.Require().True(idx["idx_records_the_num"], desc)
+		s.Require().True(idx["idx_records_deleted_at"], desc)
+		s.Require().Equal("YES", cs["deleted_at"].IsNullable, desc)
+
+		revs := tableColumns("record_revisions", desc)
+		s.Require().Equal("PRI", revs["id"].ColumnKey, desc)
+		s.Require().Contains(strings.ToLower(revs["id"].Extra), "auto_increment", desc)
+		s.Require().Equal("YES", revs["old_value"].IsNullable, desc)
+		s.Require().Equal("YES", revs["new_value"].IsNullable, desc)
+	}
+
+	checkLatest("latest")
+
+	// the records survive a step down and up
+	s.Require().NoError(s.db.Create(&mockOrderedRecords).Error)
+
+	s.Require().NoError(goose.Down(sqlDB, s.migrationDir()), "down one step")
+	s.Require().NotContains(columns("down one step"), "version", "down one step")
+
+	// the soft-deleted records block the rollback of deleted_at
+	softDeleted := mockOrderedRecords[0].ID.String()
+	s.Require().NoError(s.db.Exec("UPDATE `records` SET `deleted_at` = ? WHERE `id` = ?", mockTimeNow, softDeleted).Error)
+	s.Require().Error(goose.DownTo(sqlDB, s.migrationDir(), 4), "down to 4 with the soft-deleted records")
+	version, err := goose.GetDBVersion(sqlDB)
+	s.Require().NoError(err)
+	s.Require().Equal(int64(5), version, "down to 4 with the soft-deleted records")
+	s.Require().Contains(columns("down to 4 with the soft-deleted records"), "deleted_at")
+
+	s.Require().NoError(s.db.Exec("UPDATE `records` SET `deleted_at` = NULL WHERE `id` = ?", softDeleted).Error)
+	s.Require().NoError(goose.DownTo(sqlDB, s.migrationDir(), 4), "down to 4")
+	s.Require().NotContains(columns("down to 4"), "deleted_at", "down to 4")
+	s.Require().False(indexes("down to 4")["idx_records_deleted_at"], "down to 4")
+
+	s.Require().NoError(goose.DownTo(sqlDB, s.migrationDir(), 3), "down to 3")
+	s.Require().Empty(tableColumns("record_revisions", "down to 3"), "the revisions are dropped")
+	s.Require().Equal("PRI", columns("down to 3")["id"].ColumnKey, "down to 3")
+
+	s.Require().NoError(goose.DownTo(sqlDB, s.migrationDir(), 2), "down to 2")
+	cs := columns("down to 2")
+	s.Require().Equal("", cs["id"].ColumnKey, "down to 2")
+	s.Require().Equal("YES", cs["the_num"].IsNullable, "down to 2")
+
+	s.Require().NoError(goose.Up(sqlDB, s.migrationDir()), "up again")
+	checkLatest("up again")
+
+	records := []Record{}
+	s.Require().NoError(s.db.Order("created_at, id").Find(&records).Error)
+	s.Require().Equal(mockOrderedRecords, records)
+
+	// all the way down and up
+	s.Require().NoError(s.db.Unscoped().Where("1 = 1").Delete(&Record{}).Error)
+
+	s.Require().NoError(goose.DownTo(sqlDB, s.migrationDir(), 0), "down to 0")
+	s.Require().Empty(columns("down to 0"), "the table is dropped")
+
+	s.Require().NoError(goose.Up(sqlDB, s.migrationDir()), "up from 0")
+	checkLatest("up from 0")
+
+	version, err = goose.GetDBVersion(sqlDB)
+	s.Require().NoError(err)
+	s.Require().Equal(latest, version)
+}
+
 func (s *daoSuite) TestCreateRecord() {
 	tests := []struct {
 		Desc      string
@@ -191,6 +335,7 @@ func (s *daoSuite) TestCreateRecord() {
 				s.Require().Equal(mockTimeNow, *record.CreatedAt, desc)
 				s.Require().Equal(int64(1), record.TheNum, desc)
 				s.Require().Equal("normal", record.TheStr, desc)
+				s.Require().Equal(int64(1), record.Version, desc)
 			},
 		},
 	}
@@ -209,6 +354,115 @@ func (s *daoSuite) TestCreateRecord() {
 	}
 }
 
+func (s *daoSuite) TestCreateRecordIdempotency() {
+	key := "key"
+	var first *Record
+
+	createFirst := func(desc string) {
+		first = &Record{TheNum: 1, TheStr: "AT", IdempotencyKey: &key}
+		s.Require().NoError(s.im.CreateRecord(mockCTX, first), desc)
+	}
+
+	countRecords := func(desc string) int64 {
+		var count int64
+		s.Require().NoError(s.db.Model(&Record{}).Count(&count).Error, desc)
+		return count
+	}
+
+	tests := []struct {
+		Desc      string
+		SetupTest func(string)
+		Record    *Record
+		ExpErr    error
+		CheckFunc func(string, *Record)
+	}{
+		{
+			Desc:   "first request",
+			Record: &Record{TheNum: 1, TheStr: "AT", IdempotencyKey: &key},
+			ExpErr: nil,
+			CheckFunc: func(desc string, r *Record) {
+				s.Require().Equal(int64(1), countRecords(desc), desc)
+
+				b, err := s.ring.Get(mockCTX, pfxIdempotencyKey+key).Bytes()
+				s.Require().NoError(err, desc)
+
+				entry := idempotencyEntry{}
+				s.Require().NoError(json.Unmarshal(b, &entry), desc)
+				s.Require().Equal(r.ID.String(), entry.RecordID, desc)
+			},
+		},
+		{
+			Desc:      "retry with the same payload",
+			SetupTest: createFirst,
+			Record:    &Record{TheNum: 1, TheStr: "AT", IdempotencyKey: &key},
+			ExpErr:    nil,
+			CheckFunc: func(desc string, r *Record) {
+				s.Require().Equal(first.ID, r.ID, desc)
+				s.Require().Equal(int64(1), countRecords(desc), desc)
+			},
+		},
+		{
+			Desc:      "retry with another payload",
+			SetupTest: createFirst,
+			Record:    &Record{TheNum: 2, TheStr: "AT", IdempotencyKey: &key},
+			ExpErr:    ErrConflict,
+		},
+		{
+			Desc: "retry after the key expired in redis",
+			SetupTest: func(desc string) {
+				createFirst(desc)
+				s.Require().NoError(s.ring.Del(mockCTX, pfxIdempotencyKey+key).Err(), desc)
+			},
+			Record: &Record{TheNum: 1, TheStr: "AT", IdempotencyKey: &key},
+			ExpErr: nil,
+			CheckFunc: func(desc string, r *Record) {
+				s.Require().Equal(first.ID, r.ID, desc)
+				s.Require().Equal(int64(1), countRecords(desc), desc)
+				s.Require().NoError(s.ring.Get(mockCTX, pfxIdempotencyKey+key).Err(), desc)
+			},
+		},
+		{
+			Desc: "retry with another payload after the key expired in redis",
+			SetupTest: func(desc string) {
+				createFirst(desc)
+				s.Require().NoError(s.ring.Del(mockCTX, pfxIdempotencyKey+key).Err(), desc)
+			},
+			Record: &Record{TheNum: 2, TheStr: "AT", IdempotencyKey: &key},
+			ExpErr: ErrConflict,
+		},
+		{
+			Desc: "retry after the record is deleted",
+			SetupTest: func(desc string) {
+				createFirst(desc)
+				s.Require().NoError(s.im.DeleteRecord(mockCTX, first.ID.String()), desc)
+			},
+			Record: &Record{TheNum: 1, TheStr: "AT", IdempotencyKey: &key},
+			ExpErr: nil,
+			CheckFunc: func(desc string, r *Record) {
+				s.Require().NotEqual(first.ID, r.ID, desc)
+				s.Require().Equal(int64(1), countRecords(desc), desc)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.SetupTest()
+
+		if t.SetupTest != nil {
+			t.SetupTest(t.Desc)
+		}
+
+		err := s.im.CreateRecord(mockCTX, t.Record)
+		s.Require().ErrorIs(err, t.ExpErr, t.Desc)
+
+		if t.CheckFunc != nil {
+			t.CheckFunc(t.Desc, t.Record)
+		}
+
+		s.TearDownTest()
+	}
+}
+
 func (s *daoSuite) TestGetRecord() {
 	tests := []struct {
 		Desc      string
@@ -221,7 +475,7 @@ func (s *daoSuite) TestGetRecord() {
 		{
 			Desc:   "not existed",
 			ID:     "nothing",
-			ExpErr: fmt.Errorf("record not found"),
+			ExpErr: ErrNotFound,
 		},
 		{
 			Desc: "normal case",
@@ -266,7 +520,7 @@ func (s *daoSuite) TestGetRecord() {
 		}
 
 		record, err := s.im.GetRecord(mockCTX, t.ID)
-		s.Require().Equal(t.ExpErr, err, t.Desc)
+		s.Require().ErrorIs(err, t.ExpErr, t.Desc)
 		if err == nil {
 			s.Require().Equal(t.ExpRecord, record, t.Desc)
 		}
@@ -279,7 +533,281 @@ func (s *daoSuite) TestGetRecord() {
 	}
 }
 
+func (s *daoSuite) TestGetRecordRebuildLock() {
+	lockKey := pfxRebuildLock + mockUUID.String()
+
+	tests := []struct {
+		Desc      string
+		SetupTest func(string)
+		ExpErr    error
+		ExpNum    int64
+		CheckFunc func(string)
+	}{
+		{
+			Desc: "the record is loaded by another pod",
+			SetupTest: func(desc string) {
+				s.Require().NoError(s.ring.Set(mockCTX, lockKey, "another", rebuildLockTTL).Err(), desc)
+				go func() {
+					time.Sleep(100 * time.Millisecond)
+					s.NoError(s.im.cache.Set(mockCTX, pfxRecord, mockUUID.String(), &Record{ID: mockUUID, TheNum: 80}), desc)
+				}()
+			},
+			ExpNum: 80,
+		},
+		{
+			Desc: "the record isn't found by another pod",
+			SetupTest: func(desc string) {
+				s.Require().NoError(s.ring.Set(mockCTX, lockKey, "another", rebuildLockTTL).Err(), desc)
+				go func() {
+					time.Sleep(100 * time.Millisecond)
+					s.NoError(s.im.cache.Set(mockCTX, pfxMissingRecord, mockUUID.String(), true), desc)
+				}()
+			},
+			ExpErr: ErrNotFound,
+		},
+		{
+			Desc: "the missing record doesn't wait for the lock",
+			SetupTest: func(desc string) {
+				s.Require().NoError(s.ring.Set(mockCTX, lockKey, "another", rebuildLockTTL).Err(), desc)
+				s.Require().NoError(s.im.cache.Set(mockCTX, pfxMissingRecord, mockUUID.String(), true), desc)
+			},
+			ExpErr: ErrNotFound,
+		},
+		{
+			Desc: "another pod takes too long",
+			SetupTest: func(desc string) {
+				s.Require().NoError(s.ring.Set(mockCTX, lockKey, "another", rebuildLockTTL).Err(), desc)
+				s.Require().NoError(s.db.Create(&Record{ID: mockUUID, TheNum: 81, TheStr: "AT"}).Error, desc)
+			},
+			ExpNum: 81,
+			CheckFunc: func(desc string) {
+				// the lock of another pod is kept
+				s.Require().Equal("another", s.ring.Get(mockCTX, lockKey).Val(), desc)
+			},
+		},
+		{
+			Desc: "the lock is released after the load",
+			SetupTest: func(desc string) {
+				s.Require().NoError(s.db.Create(&Record{ID: mockUUID, TheNum: 82, TheStr: "AT"}).Error, desc)
+			},
+			ExpNum: 82,
+			CheckFunc: func(desc string) {
+				s.Require().Zero(s.ring.Exists(mockCTX, lockKey).Val(), desc)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.SetupTest()
+
+		if t.SetupTest != nil {
+			t.SetupTest(t.Desc)
+		}
+
+		start := time.Now()
+		record, err := s.im.GetRecord(mockCTX, mockUUID.String())
+		s.Require().ErrorIs(err, t.ExpErr, t.Desc)
+		if err == nil {
+			s.Require().Equal(t.ExpNum, record.TheNum, t.Desc)
+		} else {
+			// the missing ones don't wait for RebuildWait
+			s.Require().Less(time.Since(start), s.im.opt.RebuildWait, t.Desc)
+		}
+
+		if t.CheckFunc != nil {
+			t.CheckFunc(t.Desc)
+		}
+
+		s.TearDownTest()
+	}
+}
+
+func (s *daoSuite) TestGetRecordNegativeCache() {
+	tests := []struct {
+		Desc      string
+		SetupTest func(string)
+		ExpErr    error
+		ExpNum    int64
+	}{
+		{
+			Desc: "the missing id is cached",
+			SetupTest: func(desc string) {
+				_, err := s.im.GetRecord(mockCTX, mockUUID.String())
+				s.Require().ErrorIs(err, ErrNotFound, desc)
+
+				missing := false
+				s.Require().NoError(s.im.cache.Get(mockCTX, pfxMissingRecord, mockUUID.String(), &missing), desc)
+				s.Require().True(missing, desc)
+
+				// the record written behind the cache stays missing
+				s.Require().NoError(s.db.Create(&Record{ID: mockUUID, TheNum: 80, TheStr: "AT"}).Error, desc)
+			},
+			ExpErr: ErrNotFound,
+		},
+		{
+			Desc: "the restored record isn't missing",
+			SetupTest: func(desc string) {
+				s.Require().NoError(s.db.Create(&Record{ID: mockUUID, TheNum: 81, TheStr: "AT"}).Error, desc)
+				s.Require().NoError(s.im.DeleteRecord(mockCTX, mockUUID.String()), desc)
+
+				_, err := s.im.GetRecord(mockCTX, mockUUID.String())
+				s.Require().ErrorIs(err, ErrNotFound, desc)
+
+				_, err = s.im.RestoreRecord(mockCTX, mockUUID.String())
+				s.Require().NoError(err, desc)
+			},
+			ExpNum: 81,
+		},
+	}
+
+	for _, t := range tests {
+		s.SetupTest()
+
+		if t.SetupTest != nil {
+			t.SetupTest(t.Desc)
+		}
+
+		record, err := s.im.GetRecord(mockCTX, mockUUID.String())
+		s.Require().ErrorIs(err, t.ExpErr, t.Desc)
+		if err == nil {
+			s.Require().Equal(t.ExpNum, record.TheNum, t.Desc)
+		}
+
+		s.TearDownTest()
+	}
+}
+
+func (s *daoSuite) TestGetRecordEarlyRefresh() {
+	s.SetupTest()
+	defer s.TearDownTest()
+
+	s.Require().NoError(s.db.Create(&Record{ID: mockUUID, TheNum: 80, TheStr: "AT"}).Error)
+
+	record, err := s.im.GetRecord(mockCTX, mockUUID.String())
+	s.Require().NoError(err)
+	s.Require().Equal(int64(80), record.TheNum)
+	s.Require().Equal(int64(1), s.ring.Exists(mockCTX, pfxLoaded+mockUUID.String()).Val())
+
+	// change the record behind the cache, and pretend the load is too slow to wait for the expiry
+	s.Require().NoError(s.db.Model(&Record{}).Where("id = ?", mockUUID.String()).Update("the_num", 81).Error)
+	s.Require().NoError(s.ring.Set(mockCTX, pfxLoaded+mockUUID.String(), time.Hour.Milliseconds(), sharedTTL).Err())
+
+	// the cached copy is served while it's refreshed
+	record, err = s.im.GetRecord(mockCTX, mockUUID.String())
+	s.Require().NoError(err)
+	s.Require().Equal(int64(80), record.TheNum)
+
+	s.Require().Eventually(func() bool {
+		record, err := s.im.GetRecord(mockCTX, mockUUID.String())
+		return err == nil && record.TheNum == 81
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func (s *daoSuite) TestRefreshWrittenDuringLoad() {
+	s.SetupTest()
+	defer s.TearDownTest()
+
+	s.Require().NoError(s.db.Create(&Record{ID: mockUUID, TheNum: 80, TheStr: "AT", Version: 1}).Error)
+
+	s.im.refresh(mockUUID.String(), func(ctx context.Context) (interface{}, error) {
+		v, err := s.im.loadRecord(ctx, mockUUID.String())
+		// the record is written after it's loaded
+		s.Require().NoError(s.im.UpdateRecord(mockCTX, &Record{ID: mockUUID, TheNum: 81, TheStr: "AT", Version: 1}))
+		return v, err
+	})
+
+	// the loaded copy is stale, so it isn't kept
+	err := s.im.cache.Get(mockCTX, pfxRecord, mockUUID.String(), &Record{})
+	s.Require().ErrorIs(err, cache.ErrCacheMiss)
+
+	record, err := s.im.GetRecord(mockCTX, mockUUID.String())
+	s.Require().NoError(err)
+	s.Require().Equal(int64(81), record.TheNum)
+}
+
+func (s *daoSuite) TestBatchGetRecords() {
+	missingID := uuid.MustParse("00000000-0000-0000-0000-000000000009")
+	cachedOnly := Record{ID: mockUUID, CreatedAt: &mockTimeNow, UpdatedAt: &mockTimeNow, TheNum: 80, TheStr: "AT"}
+
+	tests := []struct {
+		Desc       string
+		SetupTest  func(string)
+		IDs        []string
+		ExpErr     error
+		ExpRecords []*Record
+		CheckFunc  func(string)
+	}{
+		{
+			Desc:       "no ids",
+			IDs:        []string{},
+			ExpErr:     nil,
+			ExpRecords: []*Record{},
+		},
+		{
+			Desc: "misses are backfilled from mysql",
+			SetupTest: func(desc string) {
+				s.Require().NoError(s.db.Create(&mockOrderedRecords).Error, desc)
+			},
+			IDs:        []string{mockOrderedRecords[2].ID.String(), missingID.String(), mockOrderedRecords[0].ID.String()},
+			ExpErr:     nil,
+			ExpRecords: []*Record{&mockOrderedRecords[2], nil, &mockOrderedRecords[0]},
+			CheckFunc: func(desc string) {
+				// check cache
+				for _, r := range []Record{mockOrderedRecords[2], mockOrderedRecords[0]} {
+					b, err := s.ring.Get(mockCTX, fmt.Sprintf("ca:records:%s", r.ID.String())).Bytes()
+					s.Require().NoError(err, desc)
+
+					cached := Record{}
+					s.Require().NoError(json.Unmarshal(b, &cached), desc)
+					s.Require().Equal(r, cached, desc)
+				}
+
+				err := s.ring.Get(mockCTX, fmt.Sprintf("ca:records:%s", missingID.String())).Err()
+				s.Require().ErrorIs(err, redis.Nil, desc)
+			},
+		},
+		{
+			Desc: "hits are served from the cache",
+			SetupTest: func(desc string) {
+				s.Require().NoError(s.db.Create(&mockOrderedRecords).Error, desc)
+				s.Require().NoError(s.im.cache.Set(mockCTX, pfxRecord, cachedOnly.ID.String(), &cachedOnly), desc)
+			},
+			IDs:        []string{cachedOnly.ID.String(), mockOrderedRecords[1].ID.String()},
+			ExpErr:     nil,
+			ExpRecords: []*Record{&cachedOnly, &mockOrderedRecords[1]},
+		},
+	}
+
+	for _, t := range tests {
+		s.SetupTest()
+
+		if t.SetupTest != nil {
+			t.SetupTest(t.Desc)
+		}
+
+		records, err := s.im.BatchGetRecords(mockCTX, t.IDs)
+		s.Require().ErrorIs(err, t.ExpErr, t.Desc)
+		if err == nil {
+			s.Require().Equal(t.ExpRecords, records, t.Desc)
+		}
+
+		if t.CheckFunc != nil {
+			t.CheckFunc(t.Desc)
+		}
+
+		s.TearDownTest()
+	}
+}
+
 func (s *daoSuite) TestListRecords() {
+	createWithDeleted := func(desc string) {
+		s.Require().NoError(s.db.Create(&mockOrderedRecords).Error, desc)
+		s.Require().NoError(s.db.Model(&Record{}).Where("id = ?", mockOrderedRecords[0].ID).Update("deleted_at", mockTimeNow).Error, desc)
+	}
+
+	deleted := append([]Record{}, mockOrderedRecords...)
+	deleted[0].DeletedAt = gorm.DeletedAt{Time: mockTimeNow, Valid: true}
+
 	tests := []struct {
 		Desc       string
 		SetupTest  func(string)
@@ -315,7 +843,10 @@ func (s *daoSuite) TestListRecords() {
 			},
 			CheckFunc: func(desc string) {
 				// check cache
-				b, err := s.ring.Get(mockCTX, "ca:records:0-10").Bytes()
+				key, err := listCacheKey(0, ListRecordsOpt{Size: 10, Page: 0})
+				s.Require().NoError(err, desc)
+
+				b, err := s.ring.Get(mockCTX, "ca:records:"+key).Bytes()
 				s.Require().NoError(err, desc)
 
 				rs := []Record{}
@@ -329,9 +860,117 @@ func (s *daoSuite) TestListRecords() {
 				}}, rs, desc)
 			},
 		},
-	}
-
-	for _, t := range tests {
+		{
+			Desc: "ordered by created_at then id",
+			SetupTest: func(desc string) {
+				s.Require().NoError(s.db.Create(&mockOrderedRecords).Error, desc)
+			},
+			Opt:        ListRecordsOpt{Size: 10, Page: 0},
+			ExpErr:     nil,
+			ExpRecords: mockOrderedRecords,
+		},
+		{
+			Desc: "after cursor",
+			SetupTest: func(desc string) {
+				s.Require().NoError(s.db.Create(&mockOrderedRecords).Error, desc)
+			},
+			Opt: ListRecordsOpt{Size: 10, After: &Cursor{
+				CreatedAt: *mockOrderedRecords[1].CreatedAt,
+				ID:        mockOrderedRecords[1].ID.String(),
+			}},
+			ExpErr:     nil,
+			ExpRecords: mockOrderedRecords[2:],
+		},
+		{
+			Desc: "filter the_num range",
+			SetupTest: func(desc string) {
+				s.Require().NoError(s.db.Create(&mockOrderedRecords).Error, desc)
+			},
+			Opt: ListRecordsOpt{Size: 10, Filter: RecordFilter{
+				TheNumMin: func(n int64) *int64 { return &n }(2),
+				TheNumMax: func(n int64) *int64 { return &n }(3),
+			}},
+			ExpErr:     nil,
+			ExpRecords: mockOrderedRecords[1:2],
+			CheckFunc: func(desc string) {
+				// the filtered list doesn't share the key of the whole list
+				key, err := listCacheKey(0, ListRecordsOpt{Size: 10})
+				s.Require().NoError(err, desc)
+
+				s.Require().ErrorIs(s.ring.Get(mockCTX, "ca:records:"+key).Err(), redis.Nil, desc)
+			},
+		},
+		{
+			Desc: "filter the_str",
+			SetupTest: func(desc string) {
+				s.Require().NoError(s.db.Create(&mockOrderedRecords).Error, desc)
+			},
+			Opt:        ListRecordsOpt{Size: 10, Filter: RecordFilter{TheStr: "AT3"}},
+			ExpErr:     nil,
+			ExpRecords: mockOrderedRecords[2:],
+		},
+		{
+			Desc: "filter the_str prefix escapes the wildcards",
+			SetupTest: func(desc string) {
+				s.Require().NoError(s.db.Create(&mockOrderedRecords).Error, desc)
+			},
+			Opt:        ListRecordsOpt{Size: 10, Filter: RecordFilter{TheStrPrefix: "A_"}},
+			ExpErr:     nil,
+			ExpRecords: []Record{},
+		},
+		{
+			Desc: "filter created window",
+			SetupTest: func(desc string) {
+				s.Require().NoError(s.db.Create(&mockOrderedRecords).Error, desc)
+			},
+			Opt:        ListRecordsOpt{Size: 10, Filter: RecordFilter{CreatedAfter: &mockTimeNow, CreatedBefore: &mockTimeLater}},
+			ExpErr:     nil,
+			ExpRecords: mockOrderedRecords[:1],
+		},
+		{
+			Desc: "order by the_num desc",
+			SetupTest: func(desc string) {
+				s.Require().NoError(s.db.Create(&mockOrderedRecords).Error, desc)
+			},
+			Opt:        ListRecordsOpt{Size: 10, OrderBy: []RecordOrder{{Field: "the_num", Desc: true}}},
+			ExpErr:     nil,
+			ExpRecords: []Record{mockOrderedRecords[2], mockOrderedRecords[1], mockOrderedRecords[0]},
+		},
+		{
+			Desc: "order by created_at desc after cursor",
+			SetupTest: func(desc string) {
+				s.Require().NoError(s.db.Create(&mockOrderedRecords).Error, desc)
+			},
+			Opt: ListRecordsOpt{
+				Size:    10,
+				OrderBy: []RecordOrder{{Field: "created_at", Desc: true}},
+				After:   func(c Cursor) *Cursor { return &c }(mockOrderedRecords[1].Cursor()),
+			},
+			ExpErr:     nil,
+			ExpRecords: []Record{mockOrderedRecords[2], mockOrderedRecords[0]},
+		},
+		{
+			Desc:       "deleted records are excluded",
+			SetupTest:  createWithDeleted,
+			Opt:        ListRecordsOpt{Size: 10},
+			ExpErr:     nil,
+			ExpRecords: mockOrderedRecords[1:],
+		},
+		{
+			Desc:       "filter include deleted",
+			SetupTest:  createWithDeleted,
+			Opt:        ListRecordsOpt{Size: 10, Filter: RecordFilter{IncludeDeleted: true}},
+			ExpErr:     nil,
+			ExpRecords: deleted,
+		},
+		{
+			Desc:   "unsupported order field",
+			Opt:    ListRecordsOpt{Size: 10, OrderBy: []RecordOrder{{Field: "id; DROP TABLE records"}}},
+			ExpErr: ErrInvalidArgument,
+		},
+	}
+
+	for _, t := range tests {
 		s.SetupTest()
 
 		if t.SetupTest != nil {
@@ -339,7 +978,7 @@ func (s *daoSuite) TestListRecords() {
 		}
 
 		records, err := s.im.ListRecords(mockCTX, t.Opt)
-		s.Require().Equal(t.ExpErr, err, t.Desc)
+		s.Require().ErrorIs(err, t.ExpErr, t.Desc)
 		if err == nil {
 			s.Require().Equal(t.ExpRecords, records, t.Desc)
 		}
@@ -351,3 +990,825 @@ func (s *daoSuite) TestListRecords() {
 		s.TearDownTest()
 	}
 }
+
+func (s *daoSuite) TestCountRecords() {
+	tests := []struct {
+		Desc      string
+		SetupTest func(string)
+		Filter    RecordFilter
+		ExpErr    error
+		ExpCount  int64
+		CheckFunc func(string)
+	}{
+		{
+			Desc:     "no records",
+			ExpErr:   nil,
+			ExpCount: 0,
+		},
+		{
+			Desc: "normal case",
+			SetupTest: func(desc string) {
+				s.Require().NoError(s.db.Create(&mockOrderedRecords).Error, desc)
+			},
+			ExpErr:   nil,
+			ExpCount: 3,
+			CheckFunc: func(desc string) {
+				// check cache
+				key, err := countCacheKey(0, RecordFilter{})
+				s.Require().NoError(err, desc)
+
+				n, err := s.ring.Get(mockCTX, "ca:records:"+key).Int64()
+				s.Require().NoError(err, desc)
+				s.Require().Equal(int64(3), n, desc)
+			},
+		},
+		{
+			Desc: "filtered",
+			SetupTest: func(desc string) {
+				s.Require().NoError(s.db.Create(&mockOrderedRecords).Error, desc)
+			},
+			Filter:   RecordFilter{TheStrPrefix: "AT", CreatedAfter: &mockTimeLater},
+			ExpErr:   nil,
+			ExpCount: 2,
+		},
+		{
+			Desc: "invalidated by writes",
+			SetupTest: func(desc string) {
+				count, err := s.im.CountRecords(mockCTX, RecordFilter{})
+				s.Require().NoError(err, desc)
+				s.Require().Equal(int64(0), count, desc)
+
+				r := &Record{TheNum: 1, TheStr: "AT"}
+				s.Require().NoError(s.im.CreateRecord(mockCTX, r), desc)
+			},
+			ExpErr:   nil,
+			ExpCount: 1,
+		},
+	}
+
+	for _, t := range tests {
+		s.SetupTest()
+
+		if t.SetupTest != nil {
+			t.SetupTest(t.Desc)
+		}
+
+		count, err := s.im.CountRecords(mockCTX, t.Filter)
+		s.Require().ErrorIs(err, t.ExpErr, t.Desc)
+		s.Require().Equal(t.ExpCount, count, t.Desc)
+
+		if t.CheckFunc != nil {
+			t.CheckFunc(t.Desc)
+		}
+
+		s.TearDownTest()
+	}
+}
+
+func (s *daoSuite) TestListCacheKey() {
+	inUTC8 := mockTimeNow.In(time.FixedZone("UTC+8", 8*60*60))
+
+	a, err := listCacheKey(1, ListRecordsOpt{Size: 10, Filter: RecordFilter{CreatedAfter: &mockTimeNow}})
+	s.Require().NoError(err)
+
+	b, err := listCacheKey(1, ListRecordsOpt{Size: 10, Filter: RecordFilter{CreatedAfter: &inUTC8}})
+	s.Require().NoError(err)
+	s.Require().Equal(a, b, "the same instant in another location")
+
+	c, err := listCacheKey(1, ListRecordsOpt{Size: 10, Filter: RecordFilter{TheStr: "AT"}})
+	s.Require().NoError(err)
+	s.Require().NotEqual(a, c, "different filters")
+
+	d, err := listCacheKey(2, ListRecordsOpt{Size: 10, Filter: RecordFilter{CreatedAfter: &mockTimeNow}})
+	s.Require().NoError(err)
+	s.Require().NotEqual(a, d, "different generations")
+
+	e, err := countCacheKey(1, RecordFilter{CreatedAfter: &mockTimeNow})
+	s.Require().NoError(err)
+	s.Require().NotEqual(a, e, "the count is cached apart from the pages")
+}
+
+func (s *daoSuite) TestUpdateRecord() {
+	tests := []struct {
+		Desc        string
+		SetupTest   func(string)
+		Record      *Record
+		ExpErr      error
+		ExpConflict *VersionConflictError
+		CheckFunc   func(string)
+	}{
+		{
+			Desc:   "not existed",
+			Record: &Record{ID: mockUUID, TheNum: 1, TheStr: "nothing", Version: 1},
+			ExpErr: ErrNotFound,
+		},
+		{
+			Desc: "normal case",
+			SetupTest: func(desc string) {
+				rs := []Record{
+					{ID: mockUUID, CreatedAt: &mockTimeNow, UpdatedAt: &mockTimeNow, TheNum: 80, TheStr: "AT", Version: 1},
+				}
+				s.Require().NoError(s.db.Create(&rs).Error, desc)
+			},
+			Record: &Record{ID: mockUUID, TheNum: 81, TheStr: "ATT", Version: 1},
+			ExpErr: nil,
+			CheckFunc: func(desc string) {
+				record := Record{}
+				s.Require().NoError(s.db.First(&record, "id = ?", mockUUID).Error, desc)
+				s.Require().Equal(int64(81), record.TheNum, desc)
+				s.Require().Equal("ATT", record.TheStr, desc)
+				s.Require().Equal(int64(2), record.Version, desc)
+				s.Require().Equal(mockTimeNow, *record.CreatedAt, desc)
+				s.Require().True(record.UpdatedAt.After(mockTimeNow), desc)
+			},
+		},
+		{
+			Desc: "version conflict",
+			SetupTest: func(desc string) {
+				rs := []Record{
+					{ID: mockUUID, CreatedAt: &mockTimeNow, UpdatedAt: &mockTimeNow, TheNum: 80, TheStr: "AT", Version: 2},
+				}
+				s.Require().NoError(s.db.Create(&rs).Error, desc)
+			},
+			Record:      &Record{ID: mockUUID, TheNum: 81, TheStr: "ATT", Version: 1},
+			ExpErr:      ErrConflict,
+			ExpConflict: &VersionConflictError{Expected: 1, Current: 2},
+			CheckFunc: func(desc string) {
+				record := Record{}
+				s.Require().NoError(s.db.First(&record, "id = ?", mockUUID).Error, desc)
+				s.Require().Equal(int64(80), record.TheNum, desc)
+				s.Require().Equal(int64(2), record.Version, desc)
+
+				revs, err := s.im.ListRecordRevisions(mockCTX, mockUUID.String(), ListRevisionsOpt{})
+				s.Require().NoError(err, desc)
+				s.Require().Empty(revs, desc)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.SetupTest()
+
+		if t.SetupTest != nil {
+			t.SetupTest(t.Desc)
+		}
+
+		err := s.im.UpdateRecord(mockCTX, t.Record)
+		s.Require().ErrorIs(err, t.ExpErr, t.Desc)
+
+		if t.ExpConflict != nil {
+			var versionErr *VersionConflictError
+			s.Require().ErrorAs(err, &versionErr, t.Desc)
+			s.Require().Equal(t.ExpConflict, versionErr, t.Desc)
+		}
+
+		if t.CheckFunc != nil {
+			t.CheckFunc(t.Desc)
+		}
+
+		s.TearDownTest()
+	}
+}
+
+func (s *daoSuite) TestDeleteRecord() {
+	tests := []struct {
+		Desc      string
+		SetupTest func(string)
+		ID        string
+		ExpErr    error
+		CheckFunc func(string)
+	}{
+		{
+			Desc:   "not existed",
+			ID:     "nothing",
+			ExpErr: ErrNotFound,
+		},
+		{
+			Desc: "normal case",
+			SetupTest: func(desc string) {
+				rs := []Record{
+					{ID: mockUUID, CreatedAt: &mockTimeNow, UpdatedAt: &mockTimeNow, TheNum: 80, TheStr: "AT"},
+				}
+				s.Require().NoError(s.db.Create(&rs).Error, desc)
+			},
+			ID:     mockUUID.String(),
+			ExpErr: nil,
+			CheckFunc: func(desc string) {
+				var count int64
+				s.Require().NoError(s.db.Model(&Record{}).Where("id = ?", mockUUID).Count(&count).Error, desc)
+				s.Require().Equal(int64(0), count, desc)
+
+				// it's soft deleted
+				deleted := Record{}
+				s.Require().NoError(s.db.Unscoped().Where("id = ?", mockUUID).First(&deleted).Error, desc)
+				s.Require().True(deleted.DeletedAt.Valid, desc)
+
+				_, err := s.im.GetRecord(mockCTX, mockUUID.String())
+				s.Require().ErrorIs(err, ErrNotFound, desc)
+				s.Require().ErrorIs(s.im.DeleteRecord(mockCTX, mockUUID.String()), ErrNotFound, desc)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.SetupTest()
+
+		if t.SetupTest != nil {
+			t.SetupTest(t.Desc)
+		}
+
+		err := s.im.DeleteRecord(mockCTX, t.ID)
+		s.Require().ErrorIs(err, t.ExpErr, t.Desc)
+
+		if t.CheckFunc != nil {
+			t.CheckFunc(t.Desc)
+		}
+
+		s.TearDownTest()
+	}
+}
+
+func (s *daoSuite) TestRestoreRecord() {
+	tests := []struct {
+		Desc      string
+		SetupTest func(string)
+		ID        string
+		ExpErr    error
+		ExpRecord *Record
+		CheckFunc func(string)
+	}{
+		{
+			Desc:   "not existed",
+			ID:     "nothing",
+			ExpErr: ErrNotFound,
+		},
+		{
+			Desc: "not deleted",
+			SetupTest: func(desc string) {
+				rs := []Record{
+					{ID: mockUUID, CreatedAt: &mockTimeNow, UpdatedAt: &mockTimeNow, TheNum: 80, TheStr: "AT"},
+				}
+				s.Require().NoError(s.db.Create(&rs).Error, desc)
+			},
+			ID:     mockUUID.String(),
+			ExpErr: ErrNotFound,
+		},
+		{
+			Desc: "normal case",
+			SetupTest: func(desc string) {
+				rs := []Record{
+					{ID: mockUUID, CreatedAt: &mockTimeNow, UpdatedAt: &mockTimeNow, TheNum: 80, TheStr: "AT"},
+				}
+				s.Require().NoError(s.db.Create(&rs).Error, desc)
+
+				// it's cached before the deletion
+				_, err := s.im.GetRecord(mockCTX, mockUUID.String())
+				s.Require().NoError(err, desc)
+				s.Require().NoError(s.im.DeleteRecord(mockCTX, mockUUID.String()), desc)
+				_, err = s.im.GetRecord(mockCTX, mockUUID.String())
+				s.Require().ErrorIs(err, ErrNotFound, desc)
+			},
+			ID:     mockUUID.String(),
+			ExpErr: nil,
+			ExpRecord: &Record{
+				ID:        mockUUID,
+				CreatedAt: &mockTimeNow,
+				TheNum:    80,
+				TheStr:    "AT",
+			},
+			CheckFunc: func(desc string) {
+				record, err := s.im.GetRecord(mockCTX, mockUUID.String())
+				s.Require().NoError(err, desc)
+				s.Require().False(record.DeletedAt.Valid, desc)
+
+				revs, err := s.im.ListRecordRevisions(mockCTX, mockUUID.String(), ListRevisionsOpt{})
+				s.Require().NoError(err, desc)
+				s.Require().Equal(RevisionRestore, revs[0].Action, desc)
+				s.Require().True(revs[0].OldValue.DeletedAt.Valid, desc)
+				s.Require().False(revs[0].NewValue.DeletedAt.Valid, desc)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.SetupTest()
+
+		if t.SetupTest != nil {
+			t.SetupTest(t.Desc)
+		}
+
+		record, err := s.im.RestoreRecord(mockCTX, t.ID)
+		s.Require().ErrorIs(err, t.ExpErr, t.Desc)
+		if t.ExpRecord != nil {
+			s.Require().Equal(t.ExpRecord.ID, record.ID, t.Desc)
+			s.Require().Equal(t.ExpRecord.CreatedAt, record.CreatedAt, t.Desc)
+			s.Require().Equal(t.ExpRecord.TheNum, record.TheNum, t.Desc)
+			s.Require().Equal(t.ExpRecord.TheStr, record.TheStr, t.Desc)
+			s.Require().False(record.DeletedAt.Valid, t.Desc)
+		}
+
+		if t.CheckFunc != nil {
+			t.CheckFunc(t.Desc)
+		}
+
+		s.TearDownTest()
+	}
+}
+
+func (s *daoSuite) TestPurgeRecords() {
+	deleteRecords := func(desc string, deletedAt time.Time) {
+		s.Require().NoError(s.db.Create(&mockOrderedRecords).Error, desc)
+		s.Require().NoError(s.db.Model(&Record{}).Where("1 = 1").Update("deleted_at", deletedAt).Error, desc)
+	}
+
+	tests := []struct {
+		Desc          string
+		SetupTest     func(string)
+		DeletedBefore time.Time
+		Limit         int
+		ExpIDs        []string
+		CheckFunc     func(string)
+	}{
+		{
+			Desc:          "no deleted records",
+			SetupTest:     func(desc string) { s.Require().NoError(s.db.Create(&mockOrderedRecords).Error, desc) },
+			DeletedBefore: mockTimeLater,
+			Limit:         10,
+			ExpIDs:        []string{},
+		},
+		{
+			Desc:          "deleted within the retention",
+			SetupTest:     func(desc string) { deleteRecords(desc, mockTimeLater) },
+			DeletedBefore: mockTimeNow,
+			Limit:         10,
+			ExpIDs:        []string{},
+		},
+		{
+			Desc:          "limited",
+			SetupTest:     func(desc string) { deleteRecords(desc, mockTimeNow) },
+			DeletedBefore: mockTimeLater,
+			Limit:         2,
+			CheckFunc: func(desc string) {
+				var count int64
+				s.Require().NoError(s.db.Unscoped().Model(&Record{}).Count(&count).Error, desc)
+				s.Require().Equal(int64(1), count, desc)
+			},
+		},
+		{
+			Desc: "normal case",
+			SetupTest: func(desc string) {
+				deleteRecords(desc, mockTimeNow)
+
+				// the deleted records are listed and cached
+				records, err := s.im.ListRecords(mockCTX, ListRecordsOpt{Size: 10, Filter: RecordFilter{IncludeDeleted: true}})
+				s.Require().NoError(err, desc)
+				s.Require().Equal(len(mockOrderedRecords), len(records), desc)
+			},
+			DeletedBefore: mockTimeLater,
+			Limit:         10,
+			ExpIDs: []string{
+				mockOrderedRecords[0].ID.String(),
+				mockOrderedRecords[1].ID.String(),
+				mockOrderedRecords[2].ID.String(),
+			},
+			CheckFunc: func(desc string) {
+				var count int64
+				s.Require().NoError(s.db.Unscoped().Model(&Record{}).Count(&count).Error, desc)
+				s.Require().Equal(int64(0), count, desc)
+
+				records, err := s.im.ListRecords(mockCTX, ListRecordsOpt{Size: 10, Filter: RecordFilter{IncludeDeleted: true}})
+				s.Require().NoError(err, desc)
+				s.Require().Empty(records, desc)
+
+				revs, err := s.im.ListRecordRevisions(mockCTX, mockOrderedRecords[0].ID.String(), ListRevisionsOpt{})
+				s.Require().NoError(err, desc)
+				s.Require().Equal(1, len(revs), desc)
+				s.Require().Equal(RevisionPurge, revs[0].Action, desc)
+				s.Require().Nil(revs[0].OldValue, desc)
+				s.Require().Nil(revs[0].NewValue, desc)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.SetupTest()
+
+		if t.SetupTest != nil {
+			t.SetupTest(t.Desc)
+		}
+
+		ids, err := s.im.PurgeRecords(mockCTX, t.DeletedBefore, t.Limit)
+		s.Require().NoError(err, t.Desc)
+		if t.ExpIDs != nil {
+			s.Require().ElementsMatch(t.ExpIDs, ids, t.Desc)
+		} else {
+			s.Require().Equal(t.Limit, len(ids), t.Desc)
+		}
+
+		if t.CheckFunc != nil {
+			t.CheckFunc(t.Desc)
+		}
+
+		s.TearDownTest()
+	}
+}
+
+func (s *daoSuite) TestRecordRevisions() {
+	auditCTX := WithAudit(mockCTX, Audit{Actor: "alice", RequestID: "req-1"})
+	// id is the record of the case
+	id := mockUUID
+
+	revisions := func(desc string) []RecordRevision {
+		revs, err := s.im.ListRecordRevisions(mockCTX, id.String(), ListRevisionsOpt{})
+		s.Require().NoError(err, desc)
+		return revs
+	}
+
+	createRecord := func(desc string) *Record {
+		record := &Record{TheNum: 1, TheStr: "AT"}
+		s.Require().NoError(s.im.CreateRecord(auditCTX, record), desc)
+		id = record.ID
+		return record
+	}
+
+	tests := []struct {
+		Desc      string
+		Action    func(string)
+		CheckFunc func(string)
+	}{
+		{
+			Desc: "create, update and delete",
+			Action: func(desc string) {
+				record := createRecord(desc)
+				s.Require().NoError(s.im.UpdateRecord(auditCTX, &Record{ID: record.ID, TheNum: 2, TheStr: "ATT", Version: record.Version}), desc)
+				s.Require().NoError(s.im.DeleteRecord(auditCTX, record.ID.String()), desc)
+			},
+			CheckFunc: func(desc string) {
+				revs := revisions(desc)
+				s.Require().Equal(3, len(revs), desc)
+
+				for i, action := range []string{RevisionDelete, RevisionUpdate, RevisionCreate} {
+					s.Require().Equal(action, revs[i].Action, desc)
+					s.Require().Equal(id.String(), revs[i].RecordID, desc)
+					s.Require().Equal("alice", revs[i].Actor, desc)
+					s.Require().Equal("req-1", revs[i].RequestID, desc)
+				}
+
+				s.Require().Nil(revs[2].OldValue, desc)
+				s.Require().Equal("AT", revs[2].NewValue.TheStr, desc)
+
+				s.Require().Equal("AT", revs[1].OldValue.TheStr, desc)
+				s.Require().Equal(int64(2), revs[1].NewValue.TheNum, desc)
+				s.Require().Equal("ATT", revs[1].NewValue.TheStr, desc)
+
+				s.Require().Equal("ATT", revs[0].OldValue.TheStr, desc)
+				s.Require().Nil(revs[0].NewValue, desc)
+			},
+		},
+		{
+			Desc: "failed changes have no revisions",
+			Action: func(desc string) {
+				id = uuid.New()
+				s.Require().ErrorIs(s.im.UpdateRecord(auditCTX, &Record{ID: id, TheNum: 1, TheStr: "AT", Version: 1}), ErrNotFound, desc)
+				s.Require().ErrorIs(s.im.DeleteRecord(auditCTX, id.String()), ErrNotFound, desc)
+			},
+			CheckFunc: func(desc string) {
+				s.Require().Empty(revisions(desc), desc)
+			},
+		},
+		{
+			Desc: "rolled back with the given transaction",
+			Action: func(desc string) {
+				err := s.db.Transaction(func(tx *gorm.DB) error {
+					record := &Record{TheNum: 1, TheStr: "AT"}
+					s.Require().NoError(s.im.CreateRecord(auditCTX, record, daokit.UseTx(tx)), desc)
+					id = record.ID
+					return errors.New("XD")
+				})
+				s.Require().Error(err, desc)
+			},
+			CheckFunc: func(desc string) {
+				var count int64
+				s.Require().NoError(s.db.Model(&Record{}).Count(&count).Error, desc)
+				s.Require().Equal(int64(0), count, desc)
+				s.Require().Empty(revisions(desc), desc)
+			},
+		},
+		{
+			Desc: "paging",
+			Action: func(desc string) {
+				record := createRecord(desc)
+				for i := 2; i <= 3; i++ {
+					s.Require().NoError(s.im.UpdateRecord(auditCTX, &Record{ID: record.ID, TheNum: int64(i), TheStr: "AT", Version: int64(i - 1)}), desc)
+				}
+			},
+			CheckFunc: func(desc string) {
+				first, err := s.im.ListRecordRevisions(mockCTX, id.String(), ListRevisionsOpt{Size: 2})
+				s.Require().NoError(err, desc)
+				s.Require().Equal(2, len(first), desc)
+				s.Require().Equal(int64(3), first[0].NewValue.TheNum, desc)
+				s.Require().Equal(int64(2), first[1].NewValue.TheNum, desc)
+
+				last, err := s.im.ListRecordRevisions(mockCTX, id.String(), ListRevisionsOpt{Size: 2, Before: first[1].ID})
+				s.Require().NoError(err, desc)
+				s.Require().Equal(1, len(last), desc)
+				s.Require().Equal(RevisionCreate, last[0].Action, desc)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.SetupTest()
+
+		t.Action(t.Desc)
+
+		if t.CheckFunc != nil {
+			t.CheckFunc(t.Desc)
+		}
+
+		s.TearDownTest()
+	}
+}
+
+func (s *daoSuite) TestCacheInvalidation() {
+	tests := []struct {
+		Desc      string
+		SetupTest func(string)
+		Action    func(string)
+		CheckFunc func(string)
+	}{
+		{
+			Desc: "create invalidates list pages",
+			SetupTest: func(desc string) {
+				records, err := s.im.ListRecords(mockCTX, ListRecordsOpt{Size: 10, Page: 0})
+				s.Require().NoError(err, desc)
+				s.Require().Equal(0, len(records), desc)
+			},
+			Action: func(desc string) {
+				s.Require().NoError(s.im.CreateRecord(mockCTX, &Record{TheNum: 1, TheStr: "new"}), desc)
+			},
+			CheckFunc: func(desc string) {
+				gen, err := s.ring.Get(mockCTX, keyRecordListGen).Int64()
+				s.Require().NoError(err, desc)
+				s.Require().Equal(int64(1), gen, desc)
+
+				records, err := s.im.ListRecords(mockCTX, ListRecordsOpt{Size: 10, Page: 0})
+				s.Require().NoError(err, desc)
+				s.Require().Equal(1, len(records), desc)
+			},
+		},
+		{
+			Desc: "update evicts the record",
+			SetupTest: func(desc string) {
+				rs := []Record{
+					{ID: mockUUID, CreatedAt: &mockTimeNow, UpdatedAt: &mockTimeNow, TheNum: 80, TheStr: "AT", Version: 1},
+				}
+				s.Require().NoError(s.db.Create(&rs).Error, desc)
+
+				_, err := s.im.GetRecord(mockCTX, mockUUID.String())
+				s.Require().NoError(err, desc)
+			},
+			Action: func(desc string) {
+				s.Require().NoError(s.im.UpdateRecord(mockCTX, &Record{ID: mockUUID, TheNum: 81, TheStr: "ATT", Version: 1}), desc)
+			},
+			CheckFunc: func(desc string) {
+				err := s.ring.Get(mockCTX, fmt.Sprintf("ca:records:%s", mockUUID.String())).Err()
+				s.Require().Equal(redis.Nil, err, desc)
+
+				record, err := s.im.GetRecord(mockCTX, mockUUID.String())
+				s.Require().NoError(err, desc)
+				s.Require().Equal(int64(81), record.TheNum, desc)
+				s.Require().Equal("ATT", record.TheStr, desc)
+			},
+		},
+		{
+			Desc: "delete evicts the record",
+			SetupTest: func(desc string) {
+				rs := []Record{
+					{ID: mockUUID, CreatedAt: &mockTimeNow, UpdatedAt: &mockTimeNow, TheNum: 80, TheStr: "AT"},
+				}
+				s.Require().NoError(s.db.Create(&rs).Error, desc)
+
+				_, err := s.im.GetRecord(mockCTX, mockUUID.String())
+				s.Require().NoError(err, desc)
+			},
+			Action: func(desc string) {
+				s.Require().NoError(s.im.DeleteRecord(mockCTX, mockUUID.String()), desc)
+			},
+			CheckFunc: func(desc string) {
+				_, err := s.im.GetRecord(mockCTX, mockUUID.String())
+				s.Require().ErrorIs(err, ErrNotFound, desc)
+			},
+		},
+		{
+			Desc: "eviction from other pods drops the local copy",
+			SetupTest: func(desc string) {
+				rs := []Record{
+					{ID: mockUUID, CreatedAt: &mockTimeNow, UpdatedAt: &mockTimeNow, TheNum: 80, TheStr: "AT"},
+				}
+				s.Require().NoError(s.db.Create(&rs).Error, desc)
+
+				_, err := s.im.GetRecord(mockCTX, mockUUID.String())
+				s.Require().NoError(err, desc)
+
+				// only the local copy is left
+				s.Require().NoError(s.db.Where("1 = 1").Delete(&Record{}).Error, desc)
+				s.Require().NoError(s.ring.Del(mockCTX, fmt.Sprintf("ca:records:%s", mockUUID.String())).Err(), desc)
+
+				_, err = s.im.GetRecord(mockCTX, mockUUID.String())
+				s.Require().NoError(err, desc)
+			},
+			Action: func(desc string) {
+				s.Require().NoError(s.ring.Publish(mockCTX, chRecordEviction, mockUUID.String()).Err(), desc)
+			},
+			CheckFunc: func(desc string) {
+				s.Require().Eventually(func() bool {
+					_, err := s.im.GetRecord(mockCTX, mockUUID.String())
+					return err != nil
+				}, 3*time.Second, 100*time.Millisecond, desc)
+			},
+		},
+		{
+			Desc: "eviction from other pods keeps the shared copy",
+			SetupTest: func(desc string) {
+				rs := []Record{
+					{ID: mockUUID, CreatedAt: &mockTimeNow, UpdatedAt: &mockTimeNow, TheNum: 80, TheStr: "AT"},
+				}
+				s.Require().NoError(s.db.Create(&rs).Error, desc)
+
+				_, err := s.im.GetRecord(mockCTX, mockUUID.String())
+				s.Require().NoError(err, desc)
+			},
+			Action: func(desc string) {
+				s.Require().NoError(s.ring.Publish(mockCTX, chRecordEviction, mockUUID.String()).Err(), desc)
+			},
+			CheckFunc: func(desc string) {
+				// go-cache keys both tiers the same
+				key := localCacheKey(pfxRecord, mockUUID.String())
+
+				s.Require().Eventually(func() bool {
+					vals, err := s.local.MGet(mockCTX, []string{key})
+					return err == nil && !vals[0].Valid
+				}, 3*time.Second, 100*time.Millisecond, desc)
+
+				n, err := s.ring.Exists(mockCTX, key).Result()
+				s.Require().NoError(err, desc)
+				s.Require().Equal(int64(1), n, desc)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.SetupTest()
+
+		if t.SetupTest != nil {
+			t.SetupTest(t.Desc)
+		}
+
+		t.Action(t.Desc)
+
+		if t.CheckFunc != nil {
+			t.CheckFunc(t.Desc)
+		}
+
+		s.TearDownTest()
+	}
+}
+
+// TestLocalCacheKey pins the keys of go-cache, the eviction broadcasts and the occupancy of the
+// local tier parse them without going through go-cache.
+func (s *daoSuite) TestLocalCacheKey() {
+	cache.ClearPrefix()
+	local := cachestats.NewLocalAdapter(cachekit.NewLocalCache(1024), 1024, met)
+	im := NewRecordDAO(s.db, cachekit.NewCache(cachekit.NewSharedCache(s.ring), local), s.ring, RecordDAOOpt{
+		MySql:      MySqlOpt{QueryTimeout: 5 * time.Second},
+		LocalCache: local,
+	}).(*impl)
+
+	rs := []Record{
+		{ID: mockUUID, CreatedAt: &mockTimeNow, UpdatedAt: &mockTimeNow, TheNum: 80, TheStr: "AT"},
+	}
+	s.Require().NoError(s.db.Create(&rs).Error)
+
+	_, err := im.GetRecord(mockCTX, mockUUID.String())
+	s.Require().NoError(err)
+
+	vals, err := local.MGet(mockCTX, []string{localCacheKey(pfxRecord, mockUUID.String())})
+	s.Require().NoError(err)
+	s.Require().True(vals[0].Valid)
+	s.Require().Equal(1, local.Occupancy().Prefixes[pfxRecord].Entries)
+
+	im.evictLocal(mockCTX, mockUUID.String())
+
+	vals, err = local.MGet(mockCTX, []string{localCacheKey(pfxRecord, mockUUID.String())})
+	s.Require().NoError(err)
+	s.Require().False(vals[0].Valid)
+	s.Require().Zero(local.Occupancy().Prefixes[pfxRecord].Entries)
+}
+
+func (s *daoSuite) TestFormatError() {
+	tests := []struct {
+		Desc    string
+		Err     error
+		ExpKind error
+	}{
+		{
+			Desc:    "not found",
+			Err:     gorm.ErrRecordNotFound,
+			ExpKind: ErrNotFound,
+		},
+		{
+			Desc:    "duplicate entry",
+			Err:     &mysql.MySQLError{Number: 1062, Message: "Duplicate entry"},
+			ExpKind: ErrConflict,
+		},
+		{
+			Desc:    "data too long",
+			Err:     &mysql.MySQLError{Number: 1406, Message: "Data too long"},
+			ExpKind: ErrInvalidArgument,
+		},
+		{
+			Desc:    "bad connection",
+			Err:     mysql.ErrInvalidConn,
+			ExpKind: ErrUnavailable,
+		},
+		{
+			Desc:    "deadline exceeded",
+			Err:     context.DeadlineExceeded,
+			ExpKind: ErrUnavailable,
+		},
+		{
+			Desc:    "canceled",
+			Err:     context.Canceled,
+			ExpKind: ErrCanceled,
+		},
+	}
+
+	for _, t := range tests {
+		err := formatError(t.Err)
+		s.Require().ErrorIs(err, t.ExpKind, t.Desc)
+		s.Require().ErrorIs(err, t.Err, t.Desc)
+	}
+
+	unknown := errors.New("XD")
+	s.Require().Equal(unknown, formatError(unknown))
+	s.Require().NoError(formatError(nil))
+}
+
+func (s *daoSuite) TestQueryContext() {
+	canceledCTX, cancel := context.WithCancel(mockCTX)
+	cancel()
+
+	tests := []struct {
+		Desc   string
+		Ctx    context.Context
+		Opt    MySqlOpt
+		ExpErr error
+	}{
+		{
+			Desc:   "canceled",
+			Ctx:    canceledCTX,
+			ExpErr: ErrCanceled,
+		},
+		{
+			Desc:   "query timeout",
+			Ctx:    mockCTX,
+			Opt:    MySqlOpt{QueryTimeout: time.Nanosecond},
+			ExpErr: ErrUnavailable,
+		},
+	}
+
+	for _, t := range tests {
+		s.SetupTest()
+
+		_, err := NewMySqlRecordDAO(s.db, t.Opt).GetRecord(t.Ctx, mockUUID.String())
+		s.Require().ErrorIs(err, t.ExpErr, t.Desc)
+
+		s.TearDownTest()
+	}
+}
+
+func (s *daoSuite) TestConformance() {
+	tests := []struct {
+		Desc   string
+		NewDAO func() RecordDAO
+	}{
+		{
+			Desc:   "mysql",
+			NewDAO: func() RecordDAO { return NewMySqlRecordDAO(s.db, MySqlOpt{QueryTimeout: 5 * time.Second}) },
+		},
+		{
+			Desc: "cached",
+			NewDAO: func() RecordDAO {
+				s.SetupTest()
+				return s.im
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.Desc, func() {
+			suite.Run(s.T(), &conformanceSuite{newDAO: t.NewDAO, cleanup: s.TearDownTest})
+		})
+	}
+}
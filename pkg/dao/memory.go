@@ -0,0 +1,453 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/AmazingTalker/go-rpc-kit/daokit"
+)
+
+// the limits of the columns of the records table.
+const (
+	maxTheStrLen = 255
+	minTheNum    = math.MinInt32
+	maxTheNum    = math.MaxInt32
+)
+
+// errRecordNotFound is what mysql reports for the missing records.
+var errRecordNotFound = &Error{Kind: ErrNotFound, Err: gorm.ErrRecordNotFound}
+
+// MemoryRecordDAO keeps the records in memory, it's for the tests and the local runs without mysql
+// and redis. It follows the semantics of the other RecordDAOs, see conformance_test.go, except:
+//   - the transactions given by daokit.Enrich are ignored, every change is applied at once.
+//   - the_str is compared case sensitively, the collation of mysql is case insensitive.
+//   - the timestamps keep the nanoseconds, mysql keeps the seconds.
+type MemoryRecordDAO struct {
+	mu sync.RWMutex
+
+	records   map[string]*Record
+	revisions []RecordRevision
+}
+
+func NewMemoryRecordDAO() *MemoryRecordDAO {
+	return &MemoryRecordDAO{records: map[string]*Record{}}
+}
+
+func (dao *MemoryRecordDAO) CreateRecord(ctx context.Context, record *Record, _ ...daokit.Enrich) error {
+	if err := checkRecordColumns(record); err != nil {
+		return err
+	}
+
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	if record.IdempotencyKey != nil {
+		hash := record.requestHash()
+
+		if origin := dao.recordByIdempotencyKey(*record.IdempotencyKey); origin != nil {
+			// same as the cached RecordDAO, the deleted record holds its key until it's purged.
+			if origin.RequestHash == nil || *origin.RequestHash != hash {
+				return errIdempotencyKeyReused
+			}
+			if origin.DeletedAt.Valid {
+				return &Error{Kind: ErrConflict, Err: errors.New("the idempotency key is held by a deleted record")}
+			}
+
+			*record = *cloneRecord(origin)
+			return nil
+		}
+
+		record.RequestHash = &hash
+	}
+
+	now := time.Now()
+	record.ID = uuid.New()
+	record.Version = 1
+	// the given timestamps are kept, like gorm does.
+	if record.CreatedAt == nil {
+		record.CreatedAt = &now
+	}
+	if record.UpdatedAt == nil {
+		record.UpdatedAt = &now
+	}
+
+	stored := cloneRecord(record)
+	dao.records[stored.ID.String()] = stored
+	dao.addRevision(ctx, RevisionCreate, stored.ID.String(), nil, stored)
+
+	return nil
+}
+
+func (dao *MemoryRecordDAO) GetRecord(_ context.Context, id string) (*Record, error) {
+	dao.mu.RLock()
+	defer dao.mu.RUnlock()
+
+	record, ok := dao.records[id]
+	if !ok || record.DeletedAt.Valid {
+		return nil, errRecordNotFound
+	}
+
+	return cloneRecord(record), nil
+}
+
+func (dao *MemoryRecordDAO) BatchGetRecords(_ context.Context, ids []string) ([]*Record, error) {
+	dao.mu.RLock()
+	defer dao.mu.RUnlock()
+
+	records := make([]*Record, len(ids))
+	for i, id := range ids {
+		if record, ok := dao.records[id]; ok && !record.DeletedAt.Valid {
+			records[i] = cloneRecord(record)
+		}
+	}
+
+	return records, nil
+}
+
+func (dao *MemoryRecordDAO) ListRecords(_ context.Context, opt ListRecordsOpt) ([]Record, error) {
+	order, err := recordOrder(opt.OrderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	dao.mu.RLock()
+	defer dao.mu.RUnlock()
+
+	list := dao.filterRecords(opt.Filter)
+	sort.Slice(list, func(i, j int) bool {
+		return compareCursors(order, list[i].Cursor(), list[j].Cursor()) < 0
+	})
+
+	if opt.After != nil {
+		after := sort.Search(len(list), func(i int) bool {
+			return compareCursors(order, list[i].Cursor(), *opt.After) > 0
+		})
+		list = list[after:]
+	}
+
+	if opt.Size > 0 {
+		if opt.Page > 0 && opt.After == nil {
+			offset := opt.Page * opt.Size
+			if offset > len(list) {
+				offset = len(list)
+			}
+			list = list[offset:]
+		}
+
+		if len(list) > opt.Size {
+			list = list[:opt.Size]
+		}
+	}
+
+	records := make([]Record, len(list))
+	for i, r := range list {
+		records[i] = *cloneRecord(r)
+	}
+
+	return records, nil
+}
+
+func (dao *MemoryRecordDAO) CountRecords(_ context.Context, filter RecordFilter) (int64, error) {
+	dao.mu.RLock()
+	defer dao.mu.RUnlock()
+
+	return int64(len(dao.filterRecords(filter))), nil
+}
+
+func (dao *MemoryRecordDAO) UpdateRecord(ctx context.Context, record *Record, _ ...daokit.Enrich) error {
+	if err := checkRecordColumns(record); err != nil {
+		return err
+	}
+
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	old, ok := dao.records[record.ID.String()]
+	if !ok || old.DeletedAt.Valid {
+		return errRecordNotFound
+	}
+
+	if old.Version != record.Version {
+		return &Error{Kind: ErrConflict, Err: &VersionConflictError{Expected: record.Version, Current: old.Version}}
+	}
+
+	now := time.Now()
+	updated := cloneRecord(old)
+	updated.TheNum = record.TheNum
+	updated.TheStr = record.TheStr
+	updated.UpdatedAt = &now
+	updated.Version++
+
+	dao.records[updated.ID.String()] = updated
+	dao.addRevision(ctx, RevisionUpdate, updated.ID.String(), old, updated)
+
+	*record = *cloneRecord(updated)
+
+	return nil
+}
+
+func (dao *MemoryRecordDAO) DeleteRecord(ctx context.Context, id string, _ ...daokit.Enrich) error {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	old, ok := dao.records[id]
+	if !ok || old.DeletedAt.Valid {
+		return errRecordNotFound
+	}
+
+	deleted := cloneRecord(old)
+	deleted.DeletedAt.Time = time.Now()
+	deleted.DeletedAt.Valid = true
+
+	dao.records[id] = deleted
+	dao.addRevision(ctx, RevisionDelete, id, old, nil)
+
+	return nil
+}
+
+func (dao *MemoryRecordDAO) RestoreRecord(ctx context.Context, id string, _ ...daokit.Enrich) (*Record, error) {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	old, ok := dao.records[id]
+	if !ok || !old.DeletedAt.Valid {
+		return nil, errRecordNotFound
+	}
+
+	now := time.Now()
+	restored := cloneRecord(old)
+	restored.DeletedAt.Time = time.Time{}
+	restored.DeletedAt.Valid = false
+	restored.UpdatedAt = &now
+
+	dao.records[id] = restored
+	dao.addRevision(ctx, RevisionRestore, id, old, restored)
+
+	return cloneRecord(restored), nil
+}
+
+func (dao *MemoryRecordDAO) PurgeRecords(ctx context.Context, deletedBefore time.Time, limit int) ([]string, error) {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	purged := []*Record{}
+	for _, r := range dao.records {
+		if r.DeletedAt.Valid && r.DeletedAt.Time.Before(deletedBefore) {
+			purged = append(purged, r)
+		}
+	}
+
+	sort.Slice(purged, func(i, j int) bool {
+		return purged[i].DeletedAt.Time.Before(purged[j].DeletedAt.Time)
+	})
+	if limit > 0 && len(purged) > limit {
+		purged = purged[:limit]
+	}
+
+	ids := make([]string, len(purged))
+	for i, r := range purged {
+		ids[i] = r.ID.String()
+		delete(dao.records, ids[i])
+
+		for j := range dao.revisions {
+			if dao.revisions[j].RecordID == ids[i] {
+				dao.revisions[j].OldValue, dao.revisions[j].NewValue = nil, nil
+			}
+		}
+		dao.addRevision(ctx, RevisionPurge, ids[i], nil, nil)
+	}
+
+	return ids, nil
+}
+
+func (dao *MemoryRecordDAO) ListRecordRevisions(_ context.Context, recordID string, opt ListRevisionsOpt) ([]RecordRevision, error) {
+	dao.mu.RLock()
+	defer dao.mu.RUnlock()
+
+	list := []RecordRevision{}
+	// the revisions are appended in the order of their ids
+	for i := len(dao.revisions) - 1; i >= 0; i-- {
+		rev := dao.revisions[i]
+		if rev.RecordID != recordID || (opt.Before > 0 && rev.ID >= opt.Before) {
+			continue
+		}
+
+		list = append(list, cloneRevision(rev))
+		if opt.Size > 0 && len(list) == opt.Size {
+			break
+		}
+	}
+
+	return list, nil
+}
+
+// recordByIdempotencyKey returns the record holding the key, the deleted ones included.
+func (dao *MemoryRecordDAO) recordByIdempotencyKey(key string) *Record {
+	for _, r := range dao.records {
+		if r.IdempotencyKey != nil && *r.IdempotencyKey == key {
+			return r
+		}
+	}
+
+	return nil
+}
+
+// filterRecords returns the records matching the filter in no particular order.
+func (dao *MemoryRecordDAO) filterRecords(f RecordFilter) []*Record {
+	list := []*Record{}
+	for _, r := range dao.records {
+		if matchRecord(r, f) {
+			list = append(list, r)
+		}
+	}
+
+	return list
+}
+
+func (dao *MemoryRecordDAO) addRevision(ctx context.Context, action, recordID string, oldValue, newValue *Record) {
+	now := time.Now()
+
+	rev := newRecordRevision(ctx, action, recordID, oldValue, newValue)
+	rev.ID = int64(len(dao.revisions)) + 1
+	rev.CreatedAt = &now
+
+	dao.revisions = append(dao.revisions, *rev)
+}
+
+// checkRecordColumns reports the values mysql refuses as ErrInvalidArgument.
+func checkRecordColumns(r *Record) error {
+	if r.TheNum < minTheNum || r.TheNum > maxTheNum {
+		return &Error{Kind: ErrInvalidArgument, Err: fmt.Errorf("the_num %d is out of range", r.TheNum)}
+	}
+	if utf8.RuneCountInString(r.TheStr) > maxTheStrLen {
+		return &Error{Kind: ErrInvalidArgument, Err: fmt.Errorf("the_str is longer than %d characters", maxTheStrLen)}
+	}
+
+	return nil
+}
+
+// matchRecord is filterRecords of mysql.go in go.
+func matchRecord(r *Record, f RecordFilter) bool {
+	c := r.Cursor()
+
+	switch {
+	case r.DeletedAt.Valid && !f.IncludeDeleted:
+		return false
+	case f.TheNumMin != nil && r.TheNum < *f.TheNumMin:
+		return false
+	case f.TheNumMax != nil && r.TheNum >= *f.TheNumMax:
+		return false
+	case f.TheStr != "" && r.TheStr != f.TheStr:
+		return false
+	case f.TheStrPrefix != "" && !strings.HasPrefix(r.TheStr, f.TheStrPrefix):
+		return false
+	case f.CreatedAfter != nil && c.CreatedAt.Before(*f.CreatedAfter):
+		return false
+	case f.CreatedBefore != nil && !c.CreatedAt.Before(*f.CreatedBefore):
+		return false
+	case f.UpdatedAfter != nil && c.UpdatedAt.Before(*f.UpdatedAfter):
+		return false
+	case f.UpdatedBefore != nil && !c.UpdatedAt.Before(*f.UpdatedBefore):
+		return false
+	}
+
+	return true
+}
+
+// compareCursors compares a to b in the order of recordOrder, it's negative when a comes first.
+func compareCursors(order []RecordOrder, a, b Cursor) int {
+	for _, o := range order {
+		av, bv := a.value(o.Field), b.value(o.Field)
+
+		var cmp int
+		switch av := av.(type) {
+		case int64:
+			cmp = compareInt64(av, bv.(int64))
+		case time.Time:
+			cmp = compareTime(av, bv.(time.Time))
+		case string:
+			cmp = strings.Compare(av, bv.(string))
+		}
+
+		if o.Desc {
+			cmp = -cmp
+		}
+		if cmp != 0 {
+			return cmp
+		}
+	}
+
+	return 0
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareTime(a, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// cloneRecord copies the record along with the values of its pointers, so the stored records
+// are never shared with the callers.
+func cloneRecord(r *Record) *Record {
+	c := *r
+
+	if r.CreatedAt != nil {
+		t := *r.CreatedAt
+		c.CreatedAt = &t
+	}
+	if r.UpdatedAt != nil {
+		t := *r.UpdatedAt
+		c.UpdatedAt = &t
+	}
+	if r.IdempotencyKey != nil {
+		k := *r.IdempotencyKey
+		c.IdempotencyKey = &k
+	}
+	if r.RequestHash != nil {
+		h := *r.RequestHash
+		c.RequestHash = &h
+	}
+
+	return &c
+}
+
+func cloneRevision(rev RecordRevision) RecordRevision {
+	if rev.OldValue != nil {
+		rev.OldValue = (*RecordValue)(cloneRecord((*Record)(rev.OldValue)))
+	}
+	if rev.NewValue != nil {
+		rev.NewValue = (*RecordValue)(cloneRecord((*Record)(rev.NewValue)))
+	}
+	if rev.CreatedAt != nil {
+		t := *rev.CreatedAt
+		rev.CreatedAt = &t
+	}
+
+	return rev
+}
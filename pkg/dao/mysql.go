@@ -2,33 +2,59 @@ package dao
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/AmazingTalker/go-rpc-kit/daokit"
 	"github.com/AmazingTalker/go-rpc-kit/logkit"
 )
 
+// MySqlOpt configures MySqlRecordDAO.
+type MySqlOpt struct {
+	// QueryTimeout bounds every call of the DAO along with its transaction, the earlier deadline
+	// of the context wins. The calls are only bounded by the context when it's zero.
+	QueryTimeout time.Duration
+}
+
 type MySqlRecordDAO struct {
-	db *gorm.DB
+	db           *gorm.DB
+	queryTimeout time.Duration
 }
 
-func NewMySqlRecordDAO(db *gorm.DB) MySqlRecordDAO {
-	return MySqlRecordDAO{db: db}
+func NewMySqlRecordDAO(db *gorm.DB, opt MySqlOpt) MySqlRecordDAO {
+	return MySqlRecordDAO{db: db, queryTimeout: opt.QueryTimeout}
 }
 
 func (dao MySqlRecordDAO) CreateRecord(ctx context.Context, record *Record, enrich ...daokit.Enrich) error {
 	defer met.RecordDuration([]string{"mysql", "time"}, map[string]string{}).End()
 
 	record.ID = uuid.New()
+	record.Version = 1
+	if record.IdempotencyKey != nil {
+		hash := record.requestHash()
+		record.RequestHash = &hash
+	}
 
-	db, _ := daokit.UseTxOrDB(dao.db, enrich...)
+	db, cancel := dao.session(ctx, enrich...)
+	defer cancel()
+
+	// the revision is written in the same transaction, it's a savepoint when a transaction is given.
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(record).Error; err != nil {
+			return err
+		}
 
-	err := db.Create(record).Error
+		return tx.Create(newRecordRevision(ctx, RevisionCreate, record.ID.String(), nil, record)).Error
+	})
 
 	if err != nil {
-		return err
+		return queryError(err)
 	}
 	return nil
 }
@@ -36,27 +62,99 @@ func (dao MySqlRecordDAO) CreateRecord(ctx context.Context, record *Record, enri
 func (dao MySqlRecordDAO) GetRecord(ctx context.Context, id string) (*Record, error) {
 	defer met.RecordDuration([]string{"mysql", "time"}, map[string]string{}).End()
 
+	db, cancel := dao.session(ctx)
+	defer cancel()
+
 	record := &Record{}
 
-	err := dao.db.First(record, "id = ?", id).Error
+	err := db.First(record, "id = ?", id).Error
 
 	if err != nil {
 		logkit.Debug(ctx, "get record failed", logkit.Payload{"id": id, "err": err})
-		return nil, err
+		return nil, queryError(err)
+	}
+
+	return record, nil
+}
+
+func (dao MySqlRecordDAO) GetRecordByIdempotencyKey(ctx context.Context, key string) (*Record, error) {
+	defer met.RecordDuration([]string{"mysql", "time"}, map[string]string{}).End()
+
+	db, cancel := dao.session(ctx)
+	defer cancel()
+
+	record := &Record{}
+
+	err := db.First(record, "idempotency_key = ?", key).Error
+
+	if err != nil {
+		logkit.Debug(ctx, "get record by idempotency key failed", logkit.Payload{"idempotencyKey": key, "err": err})
+		return nil, queryError(err)
 	}
 
 	return record, nil
 }
 
+func (dao MySqlRecordDAO) BatchGetRecords(ctx context.Context, ids []string) ([]*Record, error) {
+	defer met.RecordDuration([]string{"mysql", "time"}, map[string]string{}).End()
+
+	db, cancel := dao.session(ctx)
+	defer cancel()
+
+	records := make([]*Record, len(ids))
+	if len(ids) == 0 {
+		return records, nil
+	}
+
+	list := []Record{}
+	if err := db.Find(&list, "id IN ?", ids).Error; err != nil {
+		logkit.Debug(ctx, "batch get records failed", logkit.Payload{"ids": ids, "err": err})
+		return nil, queryError(err)
+	}
+
+	byID := make(map[string]*Record, len(list))
+	for i := range list {
+		byID[list[i].ID.String()] = &list[i]
+	}
+
+	for i, id := range ids {
+		records[i] = byID[id]
+	}
+
+	return records, nil
+}
+
 func (dao MySqlRecordDAO) ListRecords(ctx context.Context, opt ListRecordsOpt) ([]Record, error) {
 	defer met.RecordDuration([]string{"mysql", "time"}, map[string]string{}).End()
 
-	query := dao.db
+	db, cancel := dao.session(ctx)
+	defer cancel()
+
+	order, err := recordOrder(opt.OrderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	query := filterRecords(db, opt.Filter)
+
+	for _, o := range order {
+		// the columns are whitelisted by recordOrder, so they are safe to be put in the clause.
+		if o.Desc {
+			query = query.Order(o.Field + " DESC")
+		} else {
+			query = query.Order(o.Field)
+		}
+	}
+
+	if opt.After != nil {
+		cond, args := keysetCondition(order, opt.After)
+		query = query.Where(cond, args...)
+	}
 
 	if opt.Size > 0 {
 		query = query.Limit(opt.Size)
 
-		if opt.Page > 0 {
+		if opt.Page > 0 && opt.After == nil {
 			query = query.Offset(opt.Page * opt.Size)
 		}
 	}
@@ -64,8 +162,341 @@ func (dao MySqlRecordDAO) ListRecords(ctx context.Context, opt ListRecordsOpt) (
 	list := []Record{}
 	if err := query.Find(&list).Error; err != nil {
 		logkit.Debug(ctx, "list record failed", logkit.Payload{"options": opt, "err": err})
-		return nil, err
+		return nil, queryError(err)
 	}
 
 	return list, nil
 }
+
+func (dao MySqlRecordDAO) CountRecords(ctx context.Context, filter RecordFilter) (int64, error) {
+	defer met.RecordDuration([]string{"mysql", "time"}, map[string]string{}).End()
+
+	db, cancel := dao.session(ctx)
+	defer cancel()
+
+	var count int64
+	if err := filterRecords(db.Model(&Record{}), filter).Count(&count).Error; err != nil {
+		logkit.Debug(ctx, "count records failed", logkit.Payload{"filter": filter, "err": err})
+		return 0, queryError(err)
+	}
+
+	return count, nil
+}
+
+func (dao MySqlRecordDAO) UpdateRecord(ctx context.Context, record *Record, enrich ...daokit.Enrich) error {
+	defer met.RecordDuration([]string{"mysql", "time"}, map[string]string{}).End()
+
+	db, cancel := dao.session(ctx, enrich...)
+	defer cancel()
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		old, err := lockRecord(tx, record.ID.String())
+		if err != nil {
+			return err
+		}
+
+		// compare and set, gorm sets updated_at of the map updates as well.
+		result := tx.Model(&Record{}).Where("id = ? AND version = ?", record.ID, record.Version).Updates(map[string]interface{}{
+			"the_num": record.TheNum,
+			"the_str": record.TheStr,
+			"version": gorm.Expr("version + 1"),
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		// the record is locked, so nothing but the version can miss.
+		if result.RowsAffected == 0 {
+			return &Error{Kind: ErrConflict, Err: &VersionConflictError{Expected: record.Version, Current: old.Version}}
+		}
+
+		// reload the record for the auto update time.
+		if err := tx.First(record, "id = ?", record.ID).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(newRecordRevision(ctx, RevisionUpdate, record.ID.String(), old, record)).Error
+	})
+
+	if err != nil {
+		logkit.Debug(ctx, "update record failed", logkit.Payload{"id": record.ID, "err": err})
+		return queryError(err)
+	}
+
+	return nil
+}
+
+func (dao MySqlRecordDAO) DeleteRecord(ctx context.Context, id string, enrich ...daokit.Enrich) error {
+	defer met.RecordDuration([]string{"mysql", "time"}, map[string]string{}).End()
+
+	db, cancel := dao.session(ctx, enrich...)
+	defer cancel()
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// it also tells us whether the record exists.
+		old, err := lockRecord(tx, id)
+		if err != nil {
+			return err
+		}
+
+		// it's soft deleted by gorm, see Record.DeletedAt
+		if err := tx.Delete(&Record{}, "id = ?", id).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(newRecordRevision(ctx, RevisionDelete, old.ID.String(), old, nil)).Error
+	})
+
+	if err != nil {
+		logkit.Debug(ctx, "delete record failed", logkit.Payload{"id": id, "err": err})
+		return queryError(err)
+	}
+
+	return nil
+}
+
+func (dao MySqlRecordDAO) RestoreRecord(ctx context.Context, id string, enrich ...daokit.Enrich) (*Record, error) {
+	defer met.RecordDuration([]string{"mysql", "time"}, map[string]string{}).End()
+
+	db, cancel := dao.session(ctx, enrich...)
+	defer cancel()
+
+	record := &Record{}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		old := &Record{}
+		if err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(old, "id = ? AND deleted_at IS NOT NULL", id).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Unscoped().Model(&Record{}).Where("id = ?", id).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+
+		if err := tx.First(record, "id = ?", id).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(newRecordRevision(ctx, RevisionRestore, record.ID.String(), old, record)).Error
+	})
+
+	if err != nil {
+		logkit.Debug(ctx, "restore record failed", logkit.Payload{"id": id, "err": err})
+		return nil, queryError(err)
+	}
+
+	return record, nil
+}
+
+func (dao MySqlRecordDAO) PurgeRecords(ctx context.Context, deletedBefore time.Time, limit int) ([]string, error) {
+	defer met.RecordDuration([]string{"mysql", "time"}, map[string]string{}).End()
+
+	db, cancel := dao.session(ctx)
+	defer cancel()
+
+	ids := []string{}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		records := []Record{}
+		if err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("deleted_at < ?", deletedBefore).Order("deleted_at").Limit(limit).Find(&records).Error; err != nil {
+			return err
+		}
+
+		if len(records) == 0 {
+			return nil
+		}
+
+		revisions := make([]*RecordRevision, len(records))
+		for i := range records {
+			ids = append(ids, records[i].ID.String())
+			revisions[i] = newRecordRevision(ctx, RevisionPurge, records[i].ID.String(), nil, nil)
+		}
+
+		if err := tx.Unscoped().Delete(&Record{}, "id IN ?", ids).Error; err != nil {
+			return err
+		}
+
+		// the records can't be rebuilt from the history after they're purged
+		if err := tx.Model(&RecordRevision{}).Where("record_id IN ?", ids).
+			Updates(map[string]interface{}{"old_value": nil, "new_value": nil}).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&revisions).Error
+	})
+
+	if err != nil {
+		logkit.Debug(ctx, "purge records failed", logkit.Payload{"deletedBefore": deletedBefore, "limit": limit, "err": err})
+		return nil, queryError(err)
+	}
+
+	return ids, nil
+}
+
+func (dao MySqlRecordDAO) ListRecordRevisions(ctx context.Context, recordID string, opt ListRevisionsOpt) ([]RecordRevision, error) {
+	defer met.RecordDuration([]string{"mysql", "time"}, map[string]string{}).End()
+
+	db, cancel := dao.session(ctx)
+	defer cancel()
+
+	query := db.Where("record_id = ?", recordID).Order("id DESC")
+
+	if opt.Before > 0 {
+		query = query.Where("id < ?", opt.Before)
+	}
+	if opt.Size > 0 {
+		query = query.Limit(opt.Size)
+	}
+
+	list := []RecordRevision{}
+	if err := query.Find(&list).Error; err != nil {
+		logkit.Debug(ctx, "list record revisions failed", logkit.Payload{"recordId": recordID, "options": opt, "err": err})
+		return nil, queryError(err)
+	}
+
+	return list, nil
+}
+
+// session binds the queries to ctx bounded by the query timeout, they run in the transaction of
+// enrich when one is given.
+func (dao MySqlRecordDAO) session(ctx context.Context, enrich ...daokit.Enrich) (*gorm.DB, context.CancelFunc) {
+	cancel := func() {}
+	if dao.queryTimeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, dao.queryTimeout)
+	}
+
+	db, _ := daokit.UseTxOrDB(dao.db, enrich...)
+
+	return db.WithContext(ctx), cancel
+}
+
+// queryError counts the failed calls by their outcomes, so the cancellations of the callers and
+// the timeouts aren't mixed up with the failures of mysql. It returns the error by formatError.
+func queryError(err error) error {
+	err = formatError(err)
+
+	outcome := "error"
+	switch {
+	case errors.Is(err, ErrNotFound):
+		outcome = "not_found"
+	case errors.Is(err, ErrCanceled):
+		outcome = "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		outcome = "deadline_exceeded"
+	}
+	met.IncrCounter([]string{"mysql", "error"}, 1, map[string]string{"outcome": outcome})
+
+	return err
+}
+
+// lockRecord reads the record for update in the transaction, so the revisions of
+// the concurrent changes are in the order of the changes.
+func lockRecord(tx *gorm.DB, id string) (*Record, error) {
+	record := &Record{}
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(record, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// recordSortColumns are the columns allowed in the order by clause.
+var recordSortColumns = map[string]bool{
+	"the_num":    true,
+	"the_str":    true,
+	"created_at": true,
+	"updated_at": true,
+}
+
+// recordOrder validates the order and appends id to break the ties, so the order is stable between pages.
+func recordOrder(orderBy []RecordOrder) ([]RecordOrder, error) {
+	if len(orderBy) == 0 {
+		orderBy = []RecordOrder{{Field: "created_at"}}
+	}
+
+	order := make([]RecordOrder, 0, len(orderBy)+1)
+	for _, o := range orderBy {
+		if !recordSortColumns[o.Field] {
+			return nil, &Error{Kind: ErrInvalidArgument, Err: fmt.Errorf("unsupported order field %q", o.Field)}
+		}
+		order = append(order, o)
+	}
+
+	return append(order, RecordOrder{Field: "id"}), nil
+}
+
+func filterRecords(db *gorm.DB, f RecordFilter) *gorm.DB {
+	if f.TheNumMin != nil {
+		db = db.Where("the_num >= ?", *f.TheNumMin)
+	}
+	if f.TheNumMax != nil {
+		db = db.Where("the_num < ?", *f.TheNumMax)
+	}
+	if f.TheStr != "" {
+		db = db.Where("the_str = ?", f.TheStr)
+	}
+	if f.TheStrPrefix != "" {
+		db = db.Where("the_str LIKE ?", likeEscaper.Replace(f.TheStrPrefix)+"%")
+	}
+	if f.CreatedAfter != nil {
+		db = db.Where("created_at >= ?", *f.CreatedAfter)
+	}
+	if f.CreatedBefore != nil {
+		db = db.Where("created_at < ?", *f.CreatedBefore)
+	}
+	if f.UpdatedAfter != nil {
+		db = db.Where("updated_at >= ?", *f.UpdatedAfter)
+	}
+	if f.UpdatedBefore != nil {
+		db = db.Where("updated_at < ?", *f.UpdatedBefore)
+	}
+	if f.IncludeDeleted {
+		db = db.Unscoped()
+	}
+
+	return db
+}
+
+// likeEscaper escapes the wildcards of LIKE, backslash is the default escape character of mysql.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// keysetCondition selects the records behind the cursor in the given order, ex: for "a, b DESC, id"
+// (a > ?) OR (a = ? AND b < ?) OR (a = ? AND b = ? AND id > ?)
+func keysetCondition(order []RecordOrder, c *Cursor) (string, []interface{}) {
+	ors := make([]string, 0, len(order))
+	args := []interface{}{}
+
+	for i, o := range order {
+		ands := make([]string, 0, i+1)
+		for _, eq := range order[:i] {
+			ands = append(ands, eq.Field+" = ?")
+			args = append(args, c.value(eq.Field))
+		}
+
+		op := " > ?"
+		if o.Desc {
+			op = " < ?"
+		}
+		ands = append(ands, o.Field+op)
+		args = append(args, c.value(o.Field))
+
+		ors = append(ors, "("+strings.Join(ands, " AND ")+")")
+	}
+
+	return "(" + strings.Join(ors, " OR ") + ")", args
+}
+
+func (c *Cursor) value(field string) interface{} {
+	switch field {
+	case "the_num":
+		return c.TheNum
+	case "the_str":
+		return c.TheStr
+	case "created_at":
+		return c.CreatedAt
+	case "updated_at":
+		return c.UpdatedAt
+	default:
+		return c.ID
+	}
+}
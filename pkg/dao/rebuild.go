@@ -0,0 +1,350 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+
+	"github.com/AmazingTalker/go-rpc-kit/logkit"
+)
+
+const (
+	// pfxRebuildLock is held by the pod loading a missed key, see CacheOpt.RebuildLock.
+	pfxRebuildLock = "go-amazing:records:rebuild:"
+	// rebuildLockTTL releases the locks of the pods dying in the middle of the loads.
+	rebuildLockTTL      = 5 * time.Second
+	rebuildPollInterval = 50 * time.Millisecond
+
+	// pfxLoaded keeps how long the load of a cached key took in milliseconds, it expires
+	// along with the shared copy of the key, see CacheOpt.EarlyRefreshBeta.
+	pfxLoaded = "go-amazing:records:loaded:"
+	// loadStatRetry is how long the hits of a key without the load duration wait to look it up
+	// again, the key may be loaded with the early refreshes disabled.
+	loadStatRetry = time.Second
+)
+
+// unlockRebuild deletes the lock only when it's still held by the token, it may have
+// expired and been taken by another pod. KEYS[1]: the lock, ARGV[1]: the token.
+var unlockRebuild = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// CacheOpt configures the protection of the cached records against the stampedes and the
+// lookups of the missing ids. The concurrent misses of a key are always loaded once per pod.
+type CacheOpt struct {
+	// RebuildLock lets only one pod load a missed key, the others poll the cache for it for up to
+	// RebuildWait before they load the key themselves.
+	RebuildLock bool
+	RebuildWait time.Duration
+	// EarlyRefreshBeta refreshes the hot keys in the background before they expire, the larger
+	// it is the earlier they're refreshed. 1 is the usual choice, 0 disables the early refreshes.
+	// The load duration and the expiry of a key are looked up in redis once per pod, and kept
+	// in the pod till the key expires.
+	EarlyRefreshBeta float64
+	// NegativeTTL caches the ids not found by GetRecord, 0 disables it. It's kept short since
+	// an id looked up while its record is created may be reported missing till it expires.
+	NegativeTTL time.Duration
+}
+
+// flightGroup dedupes the concurrent loads of a key in the process, the callers waiting for
+// a load share its result.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	done chan struct{}
+	val  interface{}
+	err  error
+}
+
+// do runs fn once for the concurrent calls of the key. A caller stops waiting when its context
+// is done, and loads the key itself when the load failed by the cancellation of another caller.
+func (g *flightGroup) do(ctx context.Context, key string, fn func() (interface{}, error)) (interface{}, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = map[string]*flightCall{}
+	}
+
+	if c, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+
+		select {
+		case <-c.done:
+		case <-ctx.Done():
+			return nil, formatError(ctx.Err())
+		}
+
+		if errors.Is(c.err, ErrCanceled) {
+			return g.do(ctx, key, fn)
+		}
+		return c.val, c.err
+	}
+
+	c := &flightCall{done: make(chan struct{})}
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	c.val, c.err = fn()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+	close(c.done)
+
+	return c.val, c.err
+}
+
+// loadStat is how long the load of a key took and when its shared copy expires.
+type loadStat struct {
+	took      time.Duration
+	expiresAt time.Time
+}
+
+// loadStats keeps the loadStat of the keys hit in the pod, so the hits decide the early refreshes
+// without a round trip to redis. The expired ones are swept once per sharedTTL.
+type loadStats struct {
+	mu      sync.Mutex
+	stats   map[string]loadStat
+	sweptAt time.Time
+}
+
+// get reports false when the key isn't kept or has expired.
+func (l *loadStats) get(key string, now time.Time) (loadStat, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	st, ok := l.stats[key]
+	if !ok || !now.Before(st.expiresAt) {
+		return loadStat{}, false
+	}
+
+	return st, true
+}
+
+func (l *loadStats) set(key string, st loadStat, now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.stats == nil {
+		l.stats = map[string]loadStat{}
+	}
+
+	if now.Sub(l.sweptAt) >= sharedTTL {
+		for k, st := range l.stats {
+			if !now.Before(st.expiresAt) {
+				delete(l.stats, k)
+			}
+		}
+		l.sweptAt = now
+	}
+
+	l.stats[key] = st
+}
+
+func (l *loadStats) del(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.stats, key)
+}
+
+// rebuild loads a missed key, the rebuild lock makes the pods not holding it wait for the key
+// loaded by the holder. The negative keys are the ids cached as missing when they aren't found,
+// they're reported missing before the lock, and the pods waiting stop once the holder caches
+// them as missing.
+func (im *impl) rebuild(ctx context.Context, key string, container interface{}, negative bool, load func(context.Context) (interface{}, error)) (interface{}, error) {
+	if negative && im.isMissing(ctx, key) {
+		return nil, errMissingRecord
+	}
+
+	if im.opt.RebuildLock {
+		unlock, locked := im.lockRebuild(ctx, key)
+		if locked {
+			defer unlock()
+		} else if v, ok, err := im.waitRebuild(ctx, key, container, negative); ok {
+			return v, err
+		}
+	}
+
+	return im.load(ctx, key, load)
+}
+
+// load calls the loader and remembers how long it took for the early refreshes.
+func (im *impl) load(ctx context.Context, key string, load func(context.Context) (interface{}, error)) (interface{}, error) {
+	defer met.RecordDuration([]string{"cache", "load"}, map[string]string{"prefix": pfxRecord}).End()
+
+	start := time.Now()
+
+	v, err := load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if im.opt.EarlyRefreshBeta > 0 {
+		if err := im.ring.Set(ctx, pfxLoaded+key, time.Since(start).Milliseconds(), sharedTTL).Err(); err != nil {
+			logkit.ErrorV2(ctx, "set load duration failed", err, logkit.Payload{"key": key})
+		}
+		// the next hit looks up the new one
+		im.loadStats.del(key)
+	}
+
+	return v, nil
+}
+
+// lockRebuild reports false when another pod holds the lock of the key. The key is loaded
+// without the lock when redis fails.
+func (im *impl) lockRebuild(ctx context.Context, key string) (func(), bool) {
+	token := uuid.New().String()
+
+	ok, err := im.ring.SetNX(ctx, pfxRebuildLock+key, token, rebuildLockTTL).Result()
+	if err != nil {
+		logkit.ErrorV2(ctx, "lock rebuild failed", err, logkit.Payload{"key": key})
+		return func() {}, true
+	}
+	if !ok {
+		return nil, false
+	}
+
+	return func() {
+		if err := unlockRebuild.Run(ctx, im.ring, []string{pfxRebuildLock + key}, token).Err(); err != nil {
+			logkit.ErrorV2(ctx, "unlock rebuild failed", err, logkit.Payload{"key": key})
+		}
+	}, true
+}
+
+// waitRebuild polls the cache for the key loaded by the holder of the lock, and for the negative
+// key cached as missing by the holder. It reports false when neither is cached in RebuildWait.
+func (im *impl) waitRebuild(ctx context.Context, key string, container interface{}, negative bool) (interface{}, bool, error) {
+	// the container is filled by the cache after the loader returns
+	v := reflect.New(reflect.TypeOf(container).Elem()).Interface()
+
+	timer := time.NewTimer(im.opt.RebuildWait)
+	defer timer.Stop()
+	ticker := time.NewTicker(rebuildPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, false, nil
+		case <-timer.C:
+			met.IncrCounter([]string{"cache", "rebuild"}, 1, map[string]string{"outcome": "wait_timeout"})
+			return nil, false, nil
+		case <-ticker.C:
+			if err := im.cache.Get(ctx, pfxRecord, key, v); err == nil {
+				met.IncrCounter([]string{"cache", "rebuild"}, 1, map[string]string{"outcome": "waited"})
+				return v, true, nil
+			}
+			if negative && im.isMissing(ctx, key) {
+				met.IncrCounter([]string{"cache", "rebuild"}, 1, map[string]string{"outcome": "waited_missing"})
+				return nil, true, errMissingRecord
+			}
+		}
+	}
+}
+
+// refreshEarly refreshes a cached key in the background by the chance of shouldRefresh.
+func (im *impl) refreshEarly(ctx context.Context, key string, load func(context.Context) (interface{}, error)) {
+	if im.opt.EarlyRefreshBeta <= 0 {
+		return
+	}
+
+	now := time.Now()
+	st, ok := im.loadStats.get(key, now)
+	if !ok {
+		st = im.getLoadStat(ctx, key, now)
+		im.loadStats.set(key, st, now)
+	}
+
+	if !shouldRefresh(st.expiresAt.Sub(now), st.took, im.opt.EarlyRefreshBeta, 1-rand.Float64()) {
+		return
+	}
+
+	go im.refresh(key, load)
+}
+
+// getLoadStat looks up the load duration of the key in redis. The keys without it are never
+// refreshed early, and looked up again after loadStatRetry.
+func (im *impl) getLoadStat(ctx context.Context, key string, now time.Time) loadStat {
+	unknown := loadStat{expiresAt: now.Add(loadStatRetry)}
+
+	pipe := im.ring.Pipeline()
+	took := pipe.Get(ctx, pfxLoaded+key)
+	ttl := pipe.PTTL(ctx, pfxLoaded+key)
+	if _, err := pipe.Exec(ctx); err != nil {
+		if err != redis.Nil {
+			logkit.ErrorV2(ctx, "get load duration failed", err, logkit.Payload{"key": key})
+		}
+		return unknown
+	}
+
+	ms, err := took.Int64()
+	if err != nil || ttl.Val() <= 0 {
+		return unknown
+	}
+
+	return loadStat{took: time.Duration(ms) * time.Millisecond, expiresAt: now.Add(ttl.Val())}
+}
+
+// refresh reloads the key while the cached copy is still served, only one goroutine of the
+// pods does it. It outlives the request, so it isn't bound to the request context.
+// A write during the load may be overwritten by the reloaded copy, so the key is evicted again
+// when the list generation bumped by the writes has changed since the load.
+func (im *impl) refresh(key string, load func(context.Context) (interface{}, error)) {
+	ctx := logkit.EnrichPayload(context.Background(), logkit.Payload{"usingCachePrefix": pfxRecord, "earlyRefresh": key})
+
+	_, err := im.flights.do(ctx, "refresh:"+key, func() (interface{}, error) {
+		if im.opt.RebuildLock {
+			unlock, locked := im.lockRebuild(ctx, key)
+			if !locked {
+				return nil, nil
+			}
+			defer unlock()
+		}
+
+		gen, err := im.listGeneration(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		v, err := im.load(ctx, key, load)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := im.cache.Set(ctx, pfxRecord, key, v); err != nil {
+			return nil, err
+		}
+
+		if cur, err := im.listGeneration(ctx); err != nil || cur != gen {
+			met.IncrCounter([]string{"cache", "early_refresh"}, 1, map[string]string{"outcome": "stale"})
+			im.evict(ctx, key)
+			im.publishEvictions(ctx, key)
+			return nil, err
+		}
+
+		met.IncrCounter([]string{"cache", "early_refresh"}, 1, map[string]string{"outcome": "refreshed"})
+		return nil, nil
+	})
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		logkit.ErrorV2(ctx, "refresh early failed", err, nil)
+	}
+}
+
+// shouldRefresh is the probabilistic early expiration of "Optimal Probabilistic Cache Stampede
+// Prevention", the chance grows as the expiry gets closer and the load gets slower. r is in (0, 1].
+func shouldRefresh(ttl, took time.Duration, beta, r float64) bool {
+	return float64(took)*beta*-math.Log(r) >= float64(ttl)
+}
@@ -0,0 +1,193 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type rebuildSuite struct {
+	suite.Suite
+}
+
+func TestRebuildSuite(t *testing.T) {
+	suite.Run(t, new(rebuildSuite))
+}
+
+func (s *rebuildSuite) TestFlightGroup() {
+	g := flightGroup{}
+
+	var calls int32
+	release := make(chan struct{})
+	fn := func() (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return "AT", nil
+	}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := g.do(mockCTX, "key", fn)
+			s.Require().NoError(err)
+			s.Require().Equal("AT", v)
+		}()
+	}
+
+	s.Require().Eventually(func() bool { return atomic.LoadInt32(&calls) == 1 }, time.Second, time.Millisecond)
+	// let the others join the flight
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	s.Require().Equal(int32(1), calls)
+
+	// the key is loaded again after the flight
+	v, err := g.do(mockCTX, "key", func() (interface{}, error) { return "XD", nil })
+	s.Require().NoError(err)
+	s.Require().Equal("XD", v)
+}
+
+func (s *rebuildSuite) TestFlightGroupCanceled() {
+	tests := []struct {
+		Desc    string
+		Leader  func() (interface{}, error)
+		Ctx     func() context.Context
+		ExpVal  interface{}
+		ExpKind error
+	}{
+		{
+			Desc: "the waiting caller is canceled",
+			Leader: func() (interface{}, error) {
+				time.Sleep(100 * time.Millisecond)
+				return "AT", nil
+			},
+			Ctx: func() context.Context {
+				ctx, cancel := context.WithCancel(mockCTX)
+				cancel()
+				return ctx
+			},
+			ExpKind: ErrCanceled,
+		},
+		{
+			Desc: "the leader is canceled",
+			Leader: func() (interface{}, error) {
+				time.Sleep(50 * time.Millisecond)
+				return nil, formatError(context.Canceled)
+			},
+			Ctx:    func() context.Context { return mockCTX },
+			ExpVal: "XD",
+		},
+		{
+			Desc: "the leader fails",
+			Leader: func() (interface{}, error) {
+				time.Sleep(50 * time.Millisecond)
+				return nil, &Error{Kind: ErrUnavailable, Err: errors.New("bad connection")}
+			},
+			Ctx:     func() context.Context { return mockCTX },
+			ExpKind: ErrUnavailable,
+		},
+	}
+
+	for _, t := range tests {
+		g := flightGroup{}
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			_, _ = g.do(mockCTX, "key", t.Leader)
+		}()
+		time.Sleep(10 * time.Millisecond)
+
+		v, err := g.do(t.Ctx(), "key", func() (interface{}, error) { return "XD", nil })
+		<-done
+
+		if t.ExpKind != nil {
+			s.Require().ErrorIs(err, t.ExpKind, t.Desc)
+			continue
+		}
+		s.Require().NoError(err, t.Desc)
+		s.Require().Equal(t.ExpVal, v, t.Desc)
+	}
+}
+
+func (s *rebuildSuite) TestShouldRefresh() {
+	tests := []struct {
+		Desc string
+		TTL  time.Duration
+		Took time.Duration
+		Beta float64
+		R    float64
+		Exp  bool
+	}{
+		{
+			Desc: "far from the expiry",
+			TTL:  time.Minute,
+			Took: 10 * time.Millisecond,
+			Beta: 1,
+			R:    0.5,
+			Exp:  false,
+		},
+		{
+			Desc: "close to the expiry",
+			TTL:  5 * time.Millisecond,
+			Took: 10 * time.Millisecond,
+			Beta: 1,
+			R:    0.5,
+			Exp:  true,
+		},
+		{
+			Desc: "a larger beta refreshes earlier",
+			TTL:  50 * time.Millisecond,
+			Took: 10 * time.Millisecond,
+			Beta: 10,
+			R:    0.5,
+			Exp:  true,
+		},
+		{
+			Desc: "never refreshes by r of 1",
+			TTL:  time.Millisecond,
+			Took: time.Second,
+			Beta: 1,
+			R:    1,
+			Exp:  false,
+		},
+	}
+
+	for _, t := range tests {
+		s.Require().Equal(t.Exp, shouldRefresh(t.TTL, t.Took, t.Beta, t.R), t.Desc)
+	}
+}
+
+func (s *rebuildSuite) TestLoadStats() {
+	now := time.Now()
+	l := loadStats{}
+
+	_, ok := l.get("key", now)
+	s.Require().False(ok)
+
+	st := loadStat{took: 10 * time.Millisecond, expiresAt: now.Add(time.Second)}
+	l.set("key", st, now)
+	got, ok := l.get("key", now)
+	s.Require().True(ok)
+	s.Require().Equal(st, got)
+
+	// the expired one is looked up again
+	_, ok = l.get("key", now.Add(time.Second))
+	s.Require().False(ok)
+
+	// and swept by the later sets
+	l.set("other", st, now.Add(sharedTTL))
+	s.Require().Len(l.stats, 1)
+
+	l.del("other")
+	_, ok = l.get("other", now)
+	s.Require().False(ok)
+}
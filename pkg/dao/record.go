@@ -2,9 +2,13 @@ package dao
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 
 	"github.com/AmazingTalker/go-amazing/pkg/pb"
 	"github.com/AmazingTalker/go-rpc-kit/daokit"
@@ -13,12 +17,63 @@ import (
 type ListRecordsOpt struct {
 	Size int
 	Page int
+	// After lists the records behind the cursor, and Page is ignored.
+	After *Cursor
+
+	Filter RecordFilter
+	// OrderBy defaults to created_at, id always breaks the ties.
+	OrderBy []RecordOrder
+}
+
+// RecordFilter narrows down the list, the zero values are ignored.
+// The ranges include the min and the after, and exclude the max and the before.
+type RecordFilter struct {
+	TheNumMin     *int64
+	TheNumMax     *int64
+	TheStr        string
+	TheStrPrefix  string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	UpdatedAfter  *time.Time
+	UpdatedBefore *time.Time
+	// IncludeDeleted lists the soft deleted records as well.
+	IncludeDeleted bool
+}
+
+// RecordOrder sorts the list by Field, one of the_num, the_str, created_at and updated_at.
+type RecordOrder struct {
+	Field string
+	Desc  bool
+}
+
+// Cursor is the last record of the previous page, it carries all the sortable
+// fields so the next page can be found in whatever order the list is in.
+type Cursor struct {
+	ID        string
+	TheNum    int64
+	TheStr    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
 
 type RecordDAO interface {
 	CreateRecord(context.Context, *Record, ...daokit.Enrich) error
 	GetRecord(context.Context, string) (*Record, error)
+	// BatchGetRecords returns the records in the order of the ids, nil for the missing ones.
+	BatchGetRecords(context.Context, []string) ([]*Record, error)
 	ListRecords(context.Context, ListRecordsOpt) ([]Record, error)
+	CountRecords(context.Context, RecordFilter) (int64, error)
+	// UpdateRecord compares and sets the record by its version, it's ErrConflict with a
+	// VersionConflictError when the version isn't the current one.
+	UpdateRecord(context.Context, *Record, ...daokit.Enrich) error
+	// DeleteRecord soft deletes the record, it's restorable until purged.
+	DeleteRecord(context.Context, string, ...daokit.Enrich) error
+	// RestoreRecord undoes the deletion, it's ErrNotFound when the record isn't deleted.
+	RestoreRecord(context.Context, string, ...daokit.Enrich) (*Record, error)
+	// PurgeRecords permanently deletes at most limit records soft deleted before the time, and returns their ids.
+	PurgeRecords(context.Context, time.Time, int) ([]string, error)
+	// ListRecordRevisions lists the changes of the record from the latest one, the deleted records have them too.
+	ListRecordRevisions(context.Context, string, ListRevisionsOpt) ([]RecordRevision, error)
 }
 
 type Record struct {
@@ -27,14 +82,52 @@ type Record struct {
 	TheStr    string
 	CreatedAt *time.Time
 	UpdatedAt *time.Time
+	// DeletedAt soft deletes the record, gorm leaves the deleted ones out unless Unscoped.
+	DeletedAt gorm.DeletedAt
+	// Version increases on every update. UpdateRecord takes it as the expected version, and sets
+	// the new one back.
+	Version int64
+
+	// IdempotencyKey makes the retries of CreateRecord return the record created by the first one.
+	IdempotencyKey *string `json:"-"`
+	// RequestHash tells whether a retry carries the same payload as the first one.
+	RequestHash *string `json:"-"`
 }
 
 func (r *Record) FormatPb() *pb.Record {
-	return &pb.Record{
+	record := &pb.Record{
 		ID:        r.ID.String(),
 		TheNum:    r.TheNum,
 		TheStr:    r.TheStr,
 		CreatedAt: r.CreatedAt,
 		UpdatedAt: r.UpdatedAt,
+		Version:   r.Version,
 	}
+
+	if r.DeletedAt.Valid {
+		deletedAt := r.DeletedAt.Time
+		record.DeletedAt = &deletedAt
+	}
+
+	return record
+}
+
+// Cursor points at the record, for listing the records behind it.
+func (r *Record) Cursor() Cursor {
+	c := Cursor{ID: r.ID.String(), TheNum: r.TheNum, TheStr: r.TheStr}
+
+	if r.CreatedAt != nil {
+		c.CreatedAt = *r.CreatedAt
+	}
+	if r.UpdatedAt != nil {
+		c.UpdatedAt = *r.UpdatedAt
+	}
+
+	return c
+}
+
+// requestHash identifies the payload of CreateRecord.
+func (r *Record) requestHash() string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d\x00%s", r.TheNum, r.TheStr)))
+	return hex.EncodeToString(sum[:])
 }
@@ -0,0 +1,134 @@
+package dao
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/AmazingTalker/go-amazing/pkg/pb"
+)
+
+// The actions of the revisions.
+const (
+	RevisionCreate  = "create"
+	RevisionUpdate  = "update"
+	RevisionDelete  = "delete"
+	RevisionRestore = "restore"
+	// RevisionPurge is the permanent deletion of a soft deleted record, it erases the values of
+	// all the revisions of the record and keeps no value itself.
+	RevisionPurge = "purge"
+)
+
+// ListRevisionsOpt pages the revisions of a record from the latest one.
+type ListRevisionsOpt struct {
+	Size int
+	// Before lists the revisions older than the revision id, 0 lists from the latest one.
+	Before int64
+}
+
+// RecordRevision is a change of a record, it's written in the same transaction as the change.
+type RecordRevision struct {
+	ID        int64
+	RecordID  string
+	Action    string
+	Actor     string
+	RequestID string
+	// OldValue is nil for the creations, NewValue is nil for the deletions. Both are nil once the
+	// record is purged.
+	OldValue  *RecordValue
+	NewValue  *RecordValue
+	CreatedAt *time.Time
+}
+
+func (r *RecordRevision) FormatPb() *pb.RecordRevision {
+	rev := &pb.RecordRevision{
+		ID:        r.ID,
+		RecordID:  r.RecordID,
+		Action:    r.Action,
+		Actor:     r.Actor,
+		RequestID: r.RequestID,
+		CreatedAt: r.CreatedAt,
+	}
+
+	if r.OldValue != nil {
+		rev.OldValue = (*Record)(r.OldValue).FormatPb()
+	}
+	if r.NewValue != nil {
+		rev.NewValue = (*Record)(r.NewValue).FormatPb()
+	}
+
+	return rev
+}
+
+// RecordValue is a record stored as json in the revisions, without the idempotency key.
+type RecordValue Record
+
+func newRecordValue(r *Record) *RecordValue {
+	v := RecordValue(*r)
+	v.IdempotencyKey = nil
+	v.RequestHash = nil
+	return &v
+}
+
+// Value is a string, mysql refuses the binary strings for the json columns.
+func (v RecordValue) Value() (driver.Value, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(b), nil
+}
+
+func (v *RecordValue) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		return json.Unmarshal(s, v)
+	case string:
+		return json.Unmarshal([]byte(s), v)
+	default:
+		return fmt.Errorf("unsupported type %T of a record value", src)
+	}
+}
+
+// Audit tells who makes the changes, it's written into the revisions.
+type Audit struct {
+	// Actor is the subject of the caller.
+	Actor     string
+	RequestID string
+}
+
+type auditKey struct{}
+
+// WithAudit puts the audit into the context of the changes.
+func WithAudit(ctx context.Context, a Audit) context.Context {
+	return context.WithValue(ctx, auditKey{}, a)
+}
+
+// AuditFromContext returns the audit of the context, it's empty when none is given.
+func AuditFromContext(ctx context.Context) Audit {
+	a, _ := ctx.Value(auditKey{}).(Audit)
+	return a
+}
+
+func newRecordRevision(ctx context.Context, action, recordID string, oldValue, newValue *Record) *RecordRevision {
+	a := AuditFromContext(ctx)
+
+	rev := &RecordRevision{
+		RecordID:  recordID,
+		Action:    action,
+		Actor:     a.Actor,
+		RequestID: a.RequestID,
+	}
+
+	if oldValue != nil {
+		rev.OldValue = newRecordValue(oldValue)
+	}
+	if newValue != nil {
+		rev.NewValue = newRecordValue(newValue)
+	}
+
+	return rev
+}
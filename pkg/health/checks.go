@@ -0,0 +1,91 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/go-redis/redis/v8"
+	etcd "go.etcd.io/etcd/client/v3"
+	"gorm.io/gorm"
+)
+
+var (
+	errShardDown   = errors.New("the shard is marked down by the ring")
+	errNoEtcd      = errors.New("the etcd client is not initialized")
+	errNoEndpoints = errors.New("the etcd client has no endpoints")
+)
+
+// MySQLCheck pings the database behind db.
+func MySQLCheck(db *gorm.DB) Check {
+	return Check{
+		Name: "mysql",
+		Ping: func(ctx context.Context) error {
+			sqlDB, err := db.DB()
+			if err != nil {
+				return err
+			}
+			return sqlDB.PingContext(ctx)
+		},
+	}
+}
+
+// RedisShardChecks pings every shard of the ring, addrs is the map of name => host:port the ring is built with.
+func RedisShardChecks(ring *redis.Ring, addrs map[string]string) []Check {
+	names := make([]string, 0, len(addrs))
+	for name := range addrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	checks := make([]Check, 0, len(names))
+	for _, name := range names {
+		addr := addrs[name]
+		checks = append(checks, Check{
+			Name: fmt.Sprintf("redis:%s", name),
+			Ping: func(ctx context.Context) error {
+				found := false
+				// ForEachShard skips the shards marked down
+				err := ring.ForEachShard(ctx, func(ctx context.Context, client *redis.Client) error {
+					if client.Options().Addr != addr {
+						return nil
+					}
+					found = true
+					return client.Ping(ctx).Err()
+				})
+				if err != nil {
+					return err
+				}
+				if !found {
+					return errShardDown
+				}
+				return nil
+			},
+		})
+	}
+
+	return checks
+}
+
+// EtcdCheck asks the status of the first endpoint. It's optional, the dynamic configs keep the
+// last values when etcd is gone.
+func EtcdCheck(cli *etcd.Client) Check {
+	return Check{
+		Name:     "etcd",
+		Optional: true,
+		Ping: func(ctx context.Context) error {
+			if cli == nil {
+				return errNoEtcd
+			}
+
+			endpoints := cli.Endpoints()
+			if len(endpoints) == 0 {
+				return errNoEndpoints
+			}
+
+			_, err := cli.Status(ctx, endpoints[0])
+			return err
+		},
+	}
+}
@@ -0,0 +1,77 @@
+package health
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// GrpcServer serves grpc.health.v1.Health with the readiness of the checker, for grpc_health_probe
+// and the service meshes.
+type GrpcServer struct {
+	*grpchealth.Server
+
+	checker  *Checker
+	services []string
+}
+
+// NewGrpcServer reports the readiness as the status of the server (the empty service name) and
+// each of the services. The statuses are UNKNOWN until Update or Run.
+func NewGrpcServer(c *Checker, services ...string) *GrpcServer {
+	return &GrpcServer{
+		Server:   grpchealth.NewServer(),
+		checker:  c,
+		services: append([]string{""}, services...),
+	}
+}
+
+// RegisterGrpcServer registers the GrpcServer reporting all the services registered on s, so
+// it has to be called after them. The server reflection is registered only when reflect is set,
+// it's off by default for exposing the whole api.
+func RegisterGrpcServer(s *grpc.Server, c *Checker, reflect bool) *GrpcServer {
+	services := []string{}
+	for name := range s.GetServiceInfo() {
+		services = append(services, name)
+	}
+
+	srv := NewGrpcServer(c, services...)
+	healthpb.RegisterHealthServer(s, srv)
+
+	if reflect {
+		reflection.Register(s)
+	}
+
+	return srv
+}
+
+// Update sets the statuses by the readiness, they are NOT_SERVING once the checker is draining.
+func (s *GrpcServer) Update(ctx context.Context) {
+	status := healthpb.HealthCheckResponse_SERVING
+	if !s.checker.Ready(ctx) {
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+
+	for _, service := range s.services {
+		s.SetServingStatus(service, status)
+	}
+}
+
+// Run updates the statuses every TTL of the checker until ctx is done.
+func (s *GrpcServer) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.checker.ttl)
+	defer ticker.Stop()
+
+	for {
+		s.Update(ctx)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultTimeout = time.Second
+	defaultTTL     = 2 * time.Second
+)
+
+// Check pings a dependency.
+type Check struct {
+	Name string
+	Ping func(ctx context.Context) error
+	// Optional dependencies are reported, but don't fail the readiness.
+	Optional bool
+}
+
+// Status is the result of a check.
+type Status struct {
+	Name      string  `json:"name"`
+	OK        bool    `json:"ok"`
+	Optional  bool    `json:"optional,omitempty"`
+	LatencyMs float64 `json:"latencyMs"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Report is the results of all the checks, OK is false when any required dependency fails.
+type Report struct {
+	OK        bool      `json:"ok"`
+	Draining  bool      `json:"draining,omitempty"`
+	Deps      []Status  `json:"deps"`
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+type CheckerOpt struct {
+	Checks []Check
+	// Timeout bounds every ping, default 1s.
+	Timeout time.Duration
+	// TTL caches the report, so the probes of all the replicas don't hammer the dependencies. default 2s.
+	TTL time.Duration
+}
+
+// Checker runs the checks for the probes, and tells whether the server is shutting down.
+type Checker struct {
+	checks  []Check
+	timeout time.Duration
+	ttl     time.Duration
+
+	mu     sync.Mutex
+	report *Report
+
+	draining int32
+}
+
+func NewChecker(opt CheckerOpt) *Checker {
+	c := &Checker{checks: opt.Checks, timeout: opt.Timeout, ttl: opt.TTL}
+
+	if c.timeout <= 0 {
+		c.timeout = defaultTimeout
+	}
+	if c.ttl <= 0 {
+		c.ttl = defaultTTL
+	}
+
+	return c
+}
+
+// Drain fails the readiness, so the load balancers stop sending requests before the servers stop.
+func (c *Checker) Drain() {
+	atomic.StoreInt32(&c.draining, 1)
+}
+
+func (c *Checker) Draining() bool {
+	return atomic.LoadInt32(&c.draining) == 1
+}
+
+// Ready tells whether the server should take requests.
+func (c *Checker) Ready(ctx context.Context) bool {
+	return c.Report(ctx).OK
+}
+
+// Report runs all the checks concurrently, or returns the cached report within the TTL.
+func (c *Checker) Report(ctx context.Context) Report {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.report == nil || time.Since(c.report.CheckedAt) >= c.ttl {
+		c.report = c.run(ctx)
+	}
+
+	report := *c.report
+	if c.Draining() {
+		report.OK = false
+		report.Draining = true
+	}
+
+	return report
+}
+
+func (c *Checker) run(ctx context.Context) *Report {
+	report := &Report{OK: true, Deps: make([]Status, len(c.checks)), CheckedAt: time.Now()}
+
+	var wg sync.WaitGroup
+	for i := range c.checks {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			report.Deps[i] = c.ping(ctx, c.checks[i])
+		}(i)
+	}
+	wg.Wait()
+
+	for _, s := range report.Deps {
+		if !s.OK && !s.Optional {
+			report.OK = false
+		}
+	}
+
+	return report
+}
+
+func (c *Checker) ping(ctx context.Context, check Check) Status {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.Ping(ctx)
+
+	s := Status{
+		Name:      check.Name,
+		OK:        err == nil,
+		Optional:  check.Optional,
+		LatencyMs: float64(time.Since(start)) / float64(time.Millisecond),
+	}
+	if err != nil {
+		s.Error = err.Error()
+	}
+
+	return s
+}
@@ -0,0 +1,232 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	grpcCodes "google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	mockCTX = context.Background()
+	mockErr = errors.New("mock error")
+)
+
+type healthSuite struct {
+	suite.Suite
+}
+
+func TestHealthSuite(t *testing.T) {
+	suite.Run(t, new(healthSuite))
+}
+
+func okCheck(name string) Check {
+	return Check{Name: name, Ping: func(context.Context) error { return nil }}
+}
+
+func failCheck(name string, optional bool) Check {
+	return Check{Name: name, Optional: optional, Ping: func(context.Context) error { return mockErr }}
+}
+
+func (s *healthSuite) TestReport() {
+	tests := []struct {
+		Desc    string
+		Checks  []Check
+		Drain   bool
+		ExpOK   bool
+		ExpDeps []Status
+	}{
+		{
+			Desc:    "no checks",
+			ExpOK:   true,
+			ExpDeps: []Status{},
+		},
+		{
+			Desc:   "all ok",
+			Checks: []Check{okCheck("mysql"), okCheck("redis:shard1")},
+			ExpOK:  true,
+			ExpDeps: []Status{
+				{Name: "mysql", OK: true},
+				{Name: "redis:shard1", OK: true},
+			},
+		},
+		{
+			Desc:   "required dependency fails",
+			Checks: []Check{okCheck("mysql"), failCheck("redis:shard1", false)},
+			ExpOK:  false,
+			ExpDeps: []Status{
+				{Name: "mysql", OK: true},
+				{Name: "redis:shard1", OK: false, Error: mockErr.Error()},
+			},
+		},
+		{
+			Desc:   "optional dependency fails",
+			Checks: []Check{okCheck("mysql"), failCheck("etcd", true)},
+			ExpOK:  true,
+			ExpDeps: []Status{
+				{Name: "mysql", OK: true},
+				{Name: "etcd", OK: false, Optional: true, Error: mockErr.Error()},
+			},
+		},
+		{
+			Desc:   "timeout",
+			Checks: []Check{{Name: "mysql", Ping: func(ctx context.Context) error { <-ctx.Done(); return ctx.Err() }}},
+			ExpOK:  false,
+			ExpDeps: []Status{
+				{Name: "mysql", OK: false, Error: context.DeadlineExceeded.Error()},
+			},
+		},
+		{
+			Desc:   "draining",
+			Checks: []Check{okCheck("mysql")},
+			Drain:  true,
+			ExpOK:  false,
+			ExpDeps: []Status{
+				{Name: "mysql", OK: true},
+			},
+		},
+	}
+
+	for _, t := range tests {
+		c := NewChecker(CheckerOpt{Checks: t.Checks, Timeout: 10 * time.Millisecond})
+		if t.Drain {
+			c.Drain()
+		}
+
+		report := c.Report(mockCTX)
+		s.Require().Equal(t.ExpOK, report.OK, t.Desc)
+		s.Require().Equal(t.Drain, report.Draining, t.Desc)
+		s.Require().Equal(t.ExpOK, c.Ready(mockCTX), t.Desc)
+
+		// latency varies
+		for i := range report.Deps {
+			s.Require().True(report.Deps[i].LatencyMs >= 0, t.Desc)
+			report.Deps[i].LatencyMs = 0
+		}
+		s.Require().Equal(t.ExpDeps, report.Deps, t.Desc)
+	}
+}
+
+func (s *healthSuite) TestReportCache() {
+	var calls int32
+	check := Check{Name: "mysql", Ping: func(context.Context) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	}}
+
+	c := NewChecker(CheckerOpt{Checks: []Check{check}, TTL: 50 * time.Millisecond})
+
+	first := c.Report(mockCTX)
+	second := c.Report(mockCTX)
+	s.Require().Equal(int32(1), atomic.LoadInt32(&calls), "cached within the ttl")
+	s.Require().Equal(first.CheckedAt, second.CheckedAt, "cached within the ttl")
+
+	time.Sleep(60 * time.Millisecond)
+
+	third := c.Report(mockCTX)
+	s.Require().Equal(int32(2), atomic.LoadInt32(&calls), "checked again after the ttl")
+	s.Require().True(third.CheckedAt.After(first.CheckedAt), "checked again after the ttl")
+
+	// draining doesn't wait for the ttl
+	c.Drain()
+	s.Require().False(c.Ready(mockCTX))
+	s.Require().Equal(int32(2), atomic.LoadInt32(&calls), "draining uses the cached report")
+}
+
+func (s *healthSuite) TestGrpcServer() {
+	tests := []struct {
+		Desc      string
+		Checks    []Check
+		Drain     bool
+		Service   string
+		ExpStatus healthpb.HealthCheckResponse_ServingStatus
+		ExpCode   grpcCodes.Code
+	}{
+		{
+			Desc:      "server serving",
+			Checks:    []Check{okCheck("mysql")},
+			Service:   "",
+			ExpStatus: healthpb.HealthCheckResponse_SERVING,
+		},
+		{
+			Desc:      "service serving",
+			Checks:    []Check{okCheck("mysql")},
+			Service:   "pb.GoAmazing",
+			ExpStatus: healthpb.HealthCheckResponse_SERVING,
+		},
+		{
+			Desc:      "dependency fails",
+			Checks:    []Check{failCheck("mysql", false)},
+			Service:   "pb.GoAmazing",
+			ExpStatus: healthpb.HealthCheckResponse_NOT_SERVING,
+		},
+		{
+			Desc:      "draining",
+			Checks:    []Check{okCheck("mysql")},
+			Drain:     true,
+			Service:   "",
+			ExpStatus: healthpb.HealthCheckResponse_NOT_SERVING,
+		},
+		{
+			Desc:    "unknown service",
+			Checks:  []Check{okCheck("mysql")},
+			Service: "pb.Unknown",
+			ExpCode: grpcCodes.NotFound,
+		},
+	}
+
+	for _, t := range tests {
+		c := NewChecker(CheckerOpt{Checks: t.Checks})
+		if t.Drain {
+			c.Drain()
+		}
+
+		srv := NewGrpcServer(c, "pb.GoAmazing")
+		srv.Update(mockCTX)
+
+		resp, err := srv.Check(mockCTX, &healthpb.HealthCheckRequest{Service: t.Service})
+		if t.ExpCode != grpcCodes.OK {
+			s.Require().Equal(t.ExpCode, status.Code(err), t.Desc)
+			continue
+		}
+
+		s.Require().NoError(err, t.Desc)
+		s.Require().Equal(t.ExpStatus, resp.Status, t.Desc)
+	}
+}
+
+func (s *healthSuite) TestRegisterGrpcServer() {
+	tests := []struct {
+		Desc        string
+		Reflect     bool
+		ExpServices []string
+	}{
+		{
+			Desc:        "no reflection by default",
+			ExpServices: []string{"grpc.health.v1.Health"},
+		},
+		{
+			Desc:        "reflection",
+			Reflect:     true,
+			ExpServices: []string{"grpc.health.v1.Health", "grpc.reflection.v1alpha.ServerReflection"},
+		},
+	}
+
+	for _, t := range tests {
+		server := grpc.NewServer()
+		RegisterGrpcServer(server, NewChecker(CheckerOpt{}), t.Reflect)
+
+		services := []string{}
+		for name := range server.GetServiceInfo() {
+			services = append(services, name)
+		}
+		s.Require().ElementsMatch(t.ExpServices, services, t.Desc)
+	}
+}
@@ -0,0 +1,38 @@
+package health
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EnrichGinRouter adds the probes next to the /health of the service.
+//   - /health/live: the process is up, it doesn't check the dependencies, or their outages restart all the pods.
+//   - /health/ready: the required dependencies are fine, and the server is not shutting down.
+//   - /health/deps: the report of all the dependencies, it needs the credentials unlike the probes.
+func EnrichGinRouter(e *gin.Engine, c *Checker) {
+	e.Handle(http.MethodGet, "/health/live", c.LivenessHandler)
+	e.Handle(http.MethodGet, "/health/ready", c.ReadinessHandler)
+	e.Handle(http.MethodGet, "/health/deps", c.DepsHandler)
+}
+
+func (c *Checker) LivenessHandler(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, gin.H{"ok": true})
+}
+
+func (c *Checker) ReadinessHandler(ctx *gin.Context) {
+	report := c.Report(ctx.Request.Context())
+	ctx.JSON(reportStatus(report), gin.H{"ok": report.OK})
+}
+
+func (c *Checker) DepsHandler(ctx *gin.Context) {
+	report := c.Report(ctx.Request.Context())
+	ctx.JSON(reportStatus(report), report)
+}
+
+func reportStatus(report Report) int {
+	if !report.OK {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusOK
+}
@@ -0,0 +1,79 @@
+package interceptor
+
+import (
+	"context"
+	"net/http"
+
+	"google.golang.org/grpc"
+	grpcCodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/AmazingTalker/go-rpc-kit/errorkit"
+)
+
+// statusClientClosedRequest is the status of nginx for the requests canceled by the clients.
+const statusClientClosedRequest = 499
+
+var (
+	// grpcCodeByHttpStatus maps the HTTP status of an AT error to the gRPC code.
+	grpcCodeByHttpStatus = map[int]grpcCodes.Code{
+		http.StatusBadRequest:          grpcCodes.InvalidArgument,
+		http.StatusUnauthorized:        grpcCodes.Unauthenticated,
+		http.StatusForbidden:           grpcCodes.PermissionDenied,
+		http.StatusNotFound:            grpcCodes.NotFound,
+		http.StatusConflict:            grpcCodes.Aborted,
+		http.StatusPreconditionFailed:  grpcCodes.FailedPrecondition,
+		http.StatusTooManyRequests:     grpcCodes.ResourceExhausted,
+		http.StatusInternalServerError: grpcCodes.Internal,
+		http.StatusNotImplemented:      grpcCodes.Unimplemented,
+		http.StatusServiceUnavailable:  grpcCodes.Unavailable,
+		http.StatusGatewayTimeout:      grpcCodes.DeadlineExceeded,
+		statusClientClosedRequest:      grpcCodes.Canceled,
+	}
+)
+
+// grpcCode returns the code of the errors carrying a status, or the gRPC code matching the HTTP status of the error.
+func grpcCode(err error) grpcCodes.Code {
+	if err == nil {
+		return grpcCodes.OK
+	}
+
+	if s, ok := status.FromError(err); ok {
+		return s.Code()
+	}
+
+	if code, ok := grpcCodeByHttpStatus[errorkit.FormatError(err).HttpStatus()]; ok {
+		return code
+	}
+
+	return grpcCodes.Unknown
+}
+
+func toStatusError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	// keep the errors carrying a status already
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	return status.Error(grpcCode(err), err.Error())
+}
+
+// UnaryErrorInterceptor converts the errors returned by the services into
+// gRPC statuses, so gRPC clients get the code matching the HTTP status.
+func UnaryErrorInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		return resp, toStatusError(err)
+	}
+}
+
+// StreamErrorInterceptor is UnaryErrorInterceptor for the streams.
+func StreamErrorInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return toStatusError(handler(srv, ss))
+	}
+}
@@ -0,0 +1,64 @@
+// Package interceptor provides the gRPC interceptors shared by the services built from this template,
+// the gRPC calls get the recovery, logging and metrics like the gin middlewares. The request id is
+// given to the http requests by a gin middleware as well.
+package interceptor
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/AmazingTalker/go-rpc-kit/metrickit"
+)
+
+type Opt struct {
+	Metric metrickit.Metric
+	// Unary and Stream are the interceptors of the service, ex: the rate limit and the
+	// authentication. They are chained inside the recovery in order.
+	Unary  []grpc.UnaryServerInterceptor
+	Stream []grpc.StreamServerInterceptor
+}
+
+// ServerOptions chains all the interceptors. From the outermost:
+//   - RequestID: every log below has the request id.
+//   - Error: the errors are converted into statuses after they are logged and counted.
+//   - Logging and Metrics: they see the AT errors and the recovered panics.
+//   - Recovery
+//   - Unary and Stream of Opt
+//
+// The requests are validated by the services, the http adapter doesn't go through the interceptors.
+func ServerOptions(opt Opt) []grpc.ServerOption {
+	unary := []grpc.UnaryServerInterceptor{
+		UnaryRequestIDInterceptor(),
+		UnaryErrorInterceptor(),
+		UnaryLoggingInterceptor(),
+		UnaryMetricsInterceptor(opt.Metric),
+		UnaryRecoveryInterceptor(),
+	}
+	stream := []grpc.StreamServerInterceptor{
+		StreamRequestIDInterceptor(),
+		StreamErrorInterceptor(),
+		StreamLoggingInterceptor(),
+		StreamMetricsInterceptor(opt.Metric),
+		StreamRecoveryInterceptor(),
+	}
+
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(append(unary, opt.Unary...)...),
+		grpc.ChainStreamInterceptor(append(stream, opt.Stream...)...),
+	}
+}
+
+// serverStream overrides the context of a grpc.ServerStream.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func withContext(ss grpc.ServerStream, ctx context.Context) grpc.ServerStream {
+	return &serverStream{ServerStream: ss, ctx: ctx}
+}
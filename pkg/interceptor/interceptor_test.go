@@ -0,0 +1,223 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	grpcCodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	errCodes "github.com/AmazingTalker/at-error-code"
+	"github.com/AmazingTalker/go-rpc-kit/errorkit"
+	"github.com/AmazingTalker/go-rpc-kit/logkit"
+	"github.com/AmazingTalker/go-rpc-kit/metrickit"
+)
+
+var (
+	mockCTX  = context.Background()
+	mockInfo = &grpc.UnaryServerInfo{FullMethod: "/pb.GoAmazing/GetRecord"}
+	mockReq  = "req"
+	mockResp = "resp"
+	mockErr  = errorkit.NewFromError(errCodes.ErrInvalidArgument, errors.New("XD"), errorkit.WithHttpStatusCode(http.StatusBadRequest))
+)
+
+// fakeMetric keeps the labels of the counters, the methods not used by the interceptors are left nil.
+type fakeMetric struct {
+	metrickit.Metric
+	counters map[string][]map[string]string
+}
+
+func (m *fakeMetric) MeasureSince(_ []string, _ time.Time, _ map[string]string) {}
+
+func (m *fakeMetric) IncrCounter(keys []string, _ float64, labels map[string]string) {
+	m.counters[keys[0]] = append(m.counters[keys[0]], labels)
+}
+
+type interceptorSuite struct {
+	suite.Suite
+}
+
+func (s *interceptorSuite) SetupSuite() {
+	logkit.RegisterAmazingLogger(&logkit.Config{
+		Logger:              logkit.LoggerZap,
+		Development:         true,
+		IntegrationAirbrake: &logkit.IntegrationAirbrake{},
+	})
+}
+
+func (s *interceptorSuite) TearDownSuite() {
+	logkit.Flush()
+}
+
+func TestInterceptorSuite(t *testing.T) {
+	suite.Run(t, new(interceptorSuite))
+}
+
+func (s *interceptorSuite) TestUnaryErrorInterceptor() {
+	tests := []struct {
+		Desc    string
+		Err     error
+		ExpCode grpcCodes.Code
+	}{
+		{
+			Desc:    "no error",
+			Err:     nil,
+			ExpCode: grpcCodes.OK,
+		},
+		{
+			Desc:    "not found",
+			Err:     errorkit.NewFromError(errCodes.ErrNotFound, errors.New("record not found"), errorkit.WithHttpStatusCode(http.StatusNotFound)),
+			ExpCode: grpcCodes.NotFound,
+		},
+		{
+			Desc:    "invalid argument",
+			Err:     mockErr,
+			ExpCode: grpcCodes.InvalidArgument,
+		},
+		{
+			Desc:    "canceled",
+			Err:     errorkit.NewFromError(errCodes.ErrServiceUnavailable, context.Canceled, errorkit.WithHttpStatusCode(statusClientClosedRequest)),
+			ExpCode: grpcCodes.Canceled,
+		},
+		{
+			Desc:    "keep status",
+			Err:     status.Error(grpcCodes.Canceled, "XD"),
+			ExpCode: grpcCodes.Canceled,
+		},
+	}
+
+	interceptor := UnaryErrorInterceptor()
+
+	for _, t := range tests {
+		_, err := interceptor(mockCTX, nil, mockInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, t.Err
+		})
+
+		s.Require().Equal(t.ExpCode, status.Code(err), t.Desc)
+	}
+}
+
+func (s *interceptorSuite) TestUnaryRecoveryInterceptor() {
+	tests := []struct {
+		Desc    string
+		Handler grpc.UnaryHandler
+		ExpResp interface{}
+		ExpCode grpcCodes.Code
+	}{
+		{
+			Desc: "normal case",
+			Handler: func(ctx context.Context, req interface{}) (interface{}, error) {
+				return mockResp, nil
+			},
+			ExpResp: mockResp,
+			ExpCode: grpcCodes.OK,
+		},
+		{
+			Desc: "error",
+			Handler: func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, mockErr
+			},
+			ExpResp: nil,
+			ExpCode: grpcCodes.InvalidArgument,
+		},
+		{
+			Desc: "panic",
+			Handler: func(ctx context.Context, req interface{}) (interface{}, error) {
+				panic("XD")
+			},
+			ExpResp: nil,
+			ExpCode: grpcCodes.Internal,
+		},
+	}
+
+	recovery := UnaryRecoveryInterceptor()
+
+	for _, t := range tests {
+		var resp interface{}
+		var err error
+		s.Require().NotPanics(func() {
+			resp, err = recovery(mockCTX, mockReq, mockInfo, t.Handler)
+		}, t.Desc)
+
+		s.Require().Equal(t.ExpResp, resp, t.Desc)
+		s.Require().Equal(t.ExpCode, grpcCode(err), t.Desc)
+	}
+}
+
+func (s *interceptorSuite) TestUnaryRequestIDInterceptor() {
+	tests := []struct {
+		Desc  string
+		Ctx   context.Context
+		ExpID string
+	}{
+		{
+			Desc:  "from metadata",
+			Ctx:   metadata.NewIncomingContext(mockCTX, metadata.Pairs(MDRequestID, "request-id")),
+			ExpID: "request-id",
+		},
+		{
+			Desc:  "generated",
+			Ctx:   mockCTX,
+			ExpID: "",
+		},
+	}
+
+	interceptor := UnaryRequestIDInterceptor()
+
+	for _, t := range tests {
+		id := ""
+		_, err := interceptor(t.Ctx, mockReq, mockInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+			id = RequestID(ctx)
+			return mockResp, nil
+		})
+		s.Require().NoError(err, t.Desc)
+		s.Require().NotEmpty(id, t.Desc)
+
+		if t.ExpID != "" {
+			s.Require().Equal(t.ExpID, id, t.Desc)
+		}
+	}
+
+	s.Require().Empty(RequestID(mockCTX), "outside the interceptor")
+}
+
+func (s *interceptorSuite) TestUnaryMetricsInterceptor() {
+	tests := []struct {
+		Desc        string
+		Err         error
+		ExpCode     string
+		ExpErrCount int
+	}{
+		{
+			Desc:        "normal case",
+			Err:         nil,
+			ExpCode:     grpcCodes.OK.String(),
+			ExpErrCount: 0,
+		},
+		{
+			Desc:        "error",
+			Err:         mockErr,
+			ExpCode:     grpcCodes.InvalidArgument.String(),
+			ExpErrCount: 1,
+		},
+	}
+
+	for _, t := range tests {
+		met := &fakeMetric{counters: map[string][]map[string]string{}}
+		interceptor := UnaryMetricsInterceptor(met)
+
+		_, err := interceptor(mockCTX, mockReq, mockInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, t.Err
+		})
+		s.Require().Equal(t.Err, err, t.Desc)
+
+		s.Require().Equal([]map[string]string{{"method": mockInfo.FullMethod, "code": t.ExpCode}}, met.counters["count"], t.Desc)
+		s.Require().Len(met.counters["error"], t.ExpErrCount, t.Desc)
+	}
+}
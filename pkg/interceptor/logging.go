@@ -0,0 +1,54 @@
+package interceptor
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	grpcCodes "google.golang.org/grpc/codes"
+
+	"github.com/AmazingTalker/go-rpc-kit/logkit"
+)
+
+func logCall(ctx context.Context, start time.Time, err error) {
+	payload := logkit.Payload{
+		"code":       grpcCode(err).String(),
+		"durationMs": float64(time.Since(start)) / float64(time.Millisecond),
+	}
+
+	// the server errors are logged by the services with the details already
+	switch grpcCode(err) {
+	case grpcCodes.OK:
+		logkit.Info(ctx, "grpc call", payload)
+	case grpcCodes.Internal, grpcCodes.Unknown, grpcCodes.Unavailable, grpcCodes.DeadlineExceeded:
+		logkit.ErrorV2(ctx, "grpc call failed", err, payload)
+	default:
+		logkit.WarnV2(ctx, "grpc call failed", err, payload)
+	}
+}
+
+// UnaryLoggingInterceptor logs every call like logkit.Middleware, the method is enriched into the logs of the call.
+func UnaryLoggingInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		ctx = logkit.EnrichPayload(ctx, logkit.Payload{"method": info.FullMethod})
+
+		resp, err := handler(ctx, req)
+		logCall(ctx, start, err)
+
+		return resp, err
+	}
+}
+
+// StreamLoggingInterceptor is UnaryLoggingInterceptor for the streams.
+func StreamLoggingInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx := logkit.EnrichPayload(ss.Context(), logkit.Payload{"method": info.FullMethod})
+
+		err := handler(srv, withContext(ss, ctx))
+		logCall(ctx, start, err)
+
+		return err
+	}
+}
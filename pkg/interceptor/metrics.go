@@ -0,0 +1,44 @@
+package interceptor
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/AmazingTalker/go-rpc-kit/metrickit"
+)
+
+func recordCall(met metrickit.Metric, method string, start time.Time, err error) {
+	labels := map[string]string{"method": method, "code": grpcCode(err).String()}
+
+	met.MeasureSince([]string{"time"}, start, labels)
+	met.IncrCounter([]string{"count"}, 1, labels)
+	if err != nil {
+		met.IncrCounter([]string{"error"}, 1, labels)
+	}
+}
+
+// UnaryMetricsInterceptor records the latency and the errors per method like metrickit.Middleware.
+func UnaryMetricsInterceptor(met metrickit.Metric) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+		recordCall(met, info.FullMethod, start, err)
+
+		return resp, err
+	}
+}
+
+// StreamMetricsInterceptor is UnaryMetricsInterceptor for the streams, the latency is the lifetime of the stream.
+func StreamMetricsInterceptor(met metrickit.Metric) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+
+		err := handler(srv, ss)
+		recordCall(met, info.FullMethod, start, err)
+
+		return err
+	}
+}
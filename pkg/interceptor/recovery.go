@@ -0,0 +1,48 @@
+package interceptor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"google.golang.org/grpc"
+
+	errCodes "github.com/AmazingTalker/at-error-code"
+	"github.com/AmazingTalker/go-rpc-kit/errorkit"
+	"github.com/AmazingTalker/go-rpc-kit/logkit"
+)
+
+// recoverError logs the panic with the stack, and turns it into an internal AT error.
+func recoverError(ctx context.Context, method string, p interface{}) error {
+	err := fmt.Errorf("panic: %v", p)
+	logkit.ErrorV2(ctx, "recovered from panic", err, logkit.Payload{"method": method, "stack": string(debug.Stack())})
+
+	return errorkit.NewFromError(errCodes.ErrInternal, err, errorkit.WithHttpStatusCode(http.StatusInternalServerError))
+}
+
+// UnaryRecoveryInterceptor turns the panics into errors like gin.Recovery, the server keeps running.
+func UnaryRecoveryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				resp, err = nil, recoverError(ctx, info.FullMethod, p)
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamRecoveryInterceptor is UnaryRecoveryInterceptor for the streams.
+func StreamRecoveryInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = recoverError(ss.Context(), info.FullMethod, p)
+			}
+		}()
+
+		return handler(srv, ss)
+	}
+}
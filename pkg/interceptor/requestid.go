@@ -0,0 +1,76 @@
+package interceptor
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/AmazingTalker/go-rpc-kit/logkit"
+)
+
+// MDRequestID is the metadata key of the request id, the same as the X-Request-Id header.
+const MDRequestID = "x-request-id"
+
+type requestIDKey struct{}
+
+// RequestID returns the request id of the call, it's empty outside the interceptors.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// withRequestID takes the request id from the metadata or makes a new one, and sends it back in the header.
+func withRequestID(ctx context.Context) context.Context {
+	id := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(MDRequestID); len(values) > 0 {
+			id = values[0]
+		}
+	}
+
+	if id == "" {
+		id = uuid.New().String()
+	}
+
+	// it fails only when the header is sent already, the call goes on without it.
+	_ = grpc.SetHeader(ctx, metadata.Pairs(MDRequestID, id))
+
+	return contextWithRequestID(ctx, id)
+}
+
+func contextWithRequestID(ctx context.Context, id string) context.Context {
+	ctx = context.WithValue(ctx, requestIDKey{}, id)
+	return logkit.EnrichPayload(ctx, logkit.Payload{"requestId": id})
+}
+
+// UnaryRequestIDInterceptor propagates the request id into the context and the logs.
+func UnaryRequestIDInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(withRequestID(ctx), req)
+	}
+}
+
+// StreamRequestIDInterceptor is UnaryRequestIDInterceptor for the streams.
+func StreamRequestIDInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, withContext(ss, withRequestID(ss.Context())))
+	}
+}
+
+// GinRequestIDMiddleware is the request id of the http requests, it's taken from the X-Request-Id header
+// or made, and sent back in the same header. It has to be used before the routes are registered.
+func GinRequestIDMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id := ctx.GetHeader(MDRequestID)
+		if id == "" {
+			id = uuid.New().String()
+		}
+
+		ctx.Header(MDRequestID, id)
+		ctx.Request = ctx.Request.WithContext(contextWithRequestID(ctx.Request.Context(), id))
+		ctx.Next()
+	}
+}
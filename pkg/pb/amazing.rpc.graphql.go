@@ -43,6 +43,23 @@ var RecordObject = graphql.NewObject(graphql.ObjectConfig{
 		"the_str":    &graphql.Field{Type: graphql.String},
 		"created_at": &graphql.Field{Type: graphql.String},
 		"updated_at": &graphql.Field{Type: graphql.String},
+		"deleted_at": &graphql.Field{Type: graphql.String},
+		"version":    &graphql.Field{Type: graphql.Int},
+	},
+	Description: "",
+})
+
+var RecordRevisionObject = graphql.NewObject(graphql.ObjectConfig{
+	Name: "RecordRevisionObject",
+	Fields: graphql.Fields{
+		"id":         &graphql.Field{Type: graphql.Int},
+		"record_id":  &graphql.Field{Type: graphql.String},
+		"action":     &graphql.Field{Type: graphql.String},
+		"actor":      &graphql.Field{Type: graphql.String},
+		"request_id": &graphql.Field{Type: graphql.String},
+		"old_value":  &graphql.Field{Type: RecordObject},
+		"new_value":  &graphql.Field{Type: RecordObject},
+		"created_at": &graphql.Field{Type: graphql.String},
 	},
 	Description: "",
 })
@@ -72,8 +89,19 @@ var GetRecordResObject = graphql.NewObject(graphql.ObjectConfig{
 var ListRecordReqObject = graphql.NewObject(graphql.ObjectConfig{
 	Name: "ListRecordReqObject",
 	Fields: graphql.Fields{
-		"size": &graphql.Field{Type: graphql.String},
-		"page": &graphql.Field{Type: graphql.String},
+		"size":            &graphql.Field{Type: graphql.String},
+		"page":            &graphql.Field{Type: graphql.String},
+		"page_token":      &graphql.Field{Type: graphql.String},
+		"order_by":        &graphql.Field{Type: graphql.String},
+		"the_num_min":     &graphql.Field{Type: graphql.String},
+		"the_num_max":     &graphql.Field{Type: graphql.String},
+		"the_str":         &graphql.Field{Type: graphql.String},
+		"the_str_prefix":  &graphql.Field{Type: graphql.String},
+		"created_after":   &graphql.Field{Type: graphql.String},
+		"created_before":  &graphql.Field{Type: graphql.String},
+		"updated_after":   &graphql.Field{Type: graphql.String},
+		"updated_before":  &graphql.Field{Type: graphql.String},
+		"include_deleted": &graphql.Field{Type: graphql.String},
 	},
 	Description: "",
 })
@@ -97,9 +125,10 @@ var ConfigResObject = graphql.NewObject(graphql.ObjectConfig{
 var CreateRecordReqObject = graphql.NewObject(graphql.ObjectConfig{
 	Name: "CreateRecordReqObject",
 	Fields: graphql.Fields{
-		"the_num":    &graphql.Field{Type: graphql.Int},
-		"the_str":    &graphql.Field{Type: graphql.String},
-		"created_at": &graphql.Field{Type: graphql.String},
+		"the_num":         &graphql.Field{Type: graphql.Int},
+		"the_str":         &graphql.Field{Type: graphql.String},
+		"created_at":      &graphql.Field{Type: graphql.String},
+		"idempotency_key": &graphql.Field{Type: graphql.String},
 	},
 	Description: "",
 })
@@ -123,7 +152,108 @@ var GetRecordReqObject = graphql.NewObject(graphql.ObjectConfig{
 var ListRecordResObject = graphql.NewObject(graphql.ObjectConfig{
 	Name: "ListRecordResObject",
 	Fields: graphql.Fields{
-		"records": &graphql.Field{Type: graphql.NewList(RecordObject)},
+		"records":         &graphql.Field{Type: graphql.NewList(RecordObject)},
+		"next_page_token": &graphql.Field{Type: graphql.String},
+		"total_count":     &graphql.Field{Type: graphql.Int},
+		"page":            &graphql.Field{Type: graphql.Int},
+		"page_size":       &graphql.Field{Type: graphql.Int},
+		"has_more":        &graphql.Field{Type: graphql.Boolean},
+	},
+	Description: "",
+})
+
+var UpdateRecordReqObject = graphql.NewObject(graphql.ObjectConfig{
+	Name: "UpdateRecordReqObject",
+	Fields: graphql.Fields{
+		"id":      &graphql.Field{Type: graphql.String},
+		"the_num": &graphql.Field{Type: graphql.Int},
+		"the_str": &graphql.Field{Type: graphql.String},
+		"version": &graphql.Field{Type: graphql.Int},
+	},
+	Description: "",
+})
+
+var UpdateRecordResObject = graphql.NewObject(graphql.ObjectConfig{
+	Name: "UpdateRecordResObject",
+	Fields: graphql.Fields{
+		"record": &graphql.Field{Type: RecordObject},
+	},
+	Description: "",
+})
+
+var DeleteRecordReqObject = graphql.NewObject(graphql.ObjectConfig{
+	Name: "DeleteRecordReqObject",
+	Fields: graphql.Fields{
+		"id": &graphql.Field{Type: graphql.String},
+	},
+	Description: "",
+})
+
+var DeleteRecordResObject = graphql.NewObject(graphql.ObjectConfig{
+	Name: "DeleteRecordResObject",
+	Fields: graphql.Fields{
+		"id": &graphql.Field{Type: graphql.String},
+	},
+	Description: "",
+})
+
+var RestoreRecordReqObject = graphql.NewObject(graphql.ObjectConfig{
+	Name: "RestoreRecordReqObject",
+	Fields: graphql.Fields{
+		"id": &graphql.Field{Type: graphql.String},
+	},
+	Description: "",
+})
+
+var RestoreRecordResObject = graphql.NewObject(graphql.ObjectConfig{
+	Name: "RestoreRecordResObject",
+	Fields: graphql.Fields{
+		"record": &graphql.Field{Type: RecordObject},
+	},
+	Description: "",
+})
+
+var BatchGetRecordsReqObject = graphql.NewObject(graphql.ObjectConfig{
+	Name: "BatchGetRecordsReqObject",
+	Fields: graphql.Fields{
+		"ids": &graphql.Field{Type: graphql.NewList(graphql.String)},
+	},
+	Description: "",
+})
+
+var BatchGetRecordsResObject = graphql.NewObject(graphql.ObjectConfig{
+	Name: "BatchGetRecordsResObject",
+	Fields: graphql.Fields{
+		"results": &graphql.Field{Type: graphql.NewList(BatchGetRecordsResultObject)},
+	},
+	Description: "",
+})
+
+var BatchGetRecordsResultObject = graphql.NewObject(graphql.ObjectConfig{
+	Name: "BatchGetRecordsResultObject",
+	Fields: graphql.Fields{
+		"id":        &graphql.Field{Type: graphql.String},
+		"record":    &graphql.Field{Type: RecordObject},
+		"not_found": &graphql.Field{Type: graphql.Boolean},
+	},
+	Description: "",
+})
+
+var ListRecordRevisionsReqObject = graphql.NewObject(graphql.ObjectConfig{
+	Name: "ListRecordRevisionsReqObject",
+	Fields: graphql.Fields{
+		"id":         &graphql.Field{Type: graphql.String},
+		"size":       &graphql.Field{Type: graphql.String},
+		"page_token": &graphql.Field{Type: graphql.String},
+	},
+	Description: "",
+})
+
+var ListRecordRevisionsResObject = graphql.NewObject(graphql.ObjectConfig{
+	Name: "ListRecordRevisionsResObject",
+	Fields: graphql.Fields{
+		"revisions":       &graphql.Field{Type: graphql.NewList(RecordRevisionObject)},
+		"next_page_token": &graphql.Field{Type: graphql.String},
 	},
 	Description: "",
 })
@@ -227,9 +357,10 @@ func GoAmazingConfigResolver(p graphql.ResolveParams) (interface{}, error) {
 }
 
 var CreateRecordArguments = graphql.FieldConfigArgument{
-	"the_num":    &graphql.ArgumentConfig{Type: graphql.Int},
-	"the_str":    &graphql.ArgumentConfig{Type: graphql.String},
-	"created_at": &graphql.ArgumentConfig{Type: graphql.String},
+	"the_num":         &graphql.ArgumentConfig{Type: graphql.Int},
+	"the_str":         &graphql.ArgumentConfig{Type: graphql.String},
+	"created_at":      &graphql.ArgumentConfig{Type: graphql.String},
+	"idempotency_key": &graphql.ArgumentConfig{Type: graphql.String},
 }
 
 var CreateRecordQueryType = graphql.NewObject(graphql.ObjectConfig{
@@ -329,14 +460,30 @@ func GoAmazingGetRecordResolver(p graphql.ResolveParams) (interface{}, error) {
 }
 
 var ListRecordArguments = graphql.FieldConfigArgument{
-	"size": &graphql.ArgumentConfig{Type: graphql.String},
-	"page": &graphql.ArgumentConfig{Type: graphql.String},
+	"size":            &graphql.ArgumentConfig{Type: graphql.String},
+	"page":            &graphql.ArgumentConfig{Type: graphql.String},
+	"page_token":      &graphql.ArgumentConfig{Type: graphql.String},
+	"order_by":        &graphql.ArgumentConfig{Type: graphql.String},
+	"the_num_min":     &graphql.ArgumentConfig{Type: graphql.String},
+	"the_num_max":     &graphql.ArgumentConfig{Type: graphql.String},
+	"the_str":         &graphql.ArgumentConfig{Type: graphql.String},
+	"the_str_prefix":  &graphql.ArgumentConfig{Type: graphql.String},
+	"created_after":   &graphql.ArgumentConfig{Type: graphql.String},
+	"created_before":  &graphql.ArgumentConfig{Type: graphql.String},
+	"updated_after":   &graphql.ArgumentConfig{Type: graphql.String},
+	"updated_before":  &graphql.ArgumentConfig{Type: graphql.String},
+	"include_deleted": &graphql.ArgumentConfig{Type: graphql.String},
 }
 
 var ListRecordQueryType = graphql.NewObject(graphql.ObjectConfig{
 	Name: "ListRecordQueryType",
 	Fields: graphql.Fields{
-		"records": &graphql.Field{Type: graphql.NewList(RecordObject)},
+		"records":         &graphql.Field{Type: graphql.NewList(RecordObject)},
+		"next_page_token": &graphql.Field{Type: graphql.String},
+		"total_count":     &graphql.Field{Type: graphql.Int},
+		"page":            &graphql.Field{Type: graphql.Int},
+		"page_size":       &graphql.Field{Type: graphql.Int},
+		"has_more":        &graphql.Field{Type: graphql.Boolean},
 	},
 	Description: "",
 })
@@ -379,6 +526,262 @@ func GoAmazingListRecordResolver(p graphql.ResolveParams) (interface{}, error) {
 	}, nil
 }
 
+var UpdateRecordArguments = graphql.FieldConfigArgument{
+	"id":      &graphql.ArgumentConfig{Type: graphql.String},
+	"the_num": &graphql.ArgumentConfig{Type: graphql.Int},
+	"the_str": &graphql.ArgumentConfig{Type: graphql.String},
+	"version": &graphql.ArgumentConfig{Type: graphql.Int},
+}
+
+var UpdateRecordQueryType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "UpdateRecordQueryType",
+	Fields: graphql.Fields{
+		"record": &graphql.Field{Type: RecordObject},
+	},
+	Description: "",
+})
+
+func GoAmazingUpdateRecordResolver(p graphql.ResolveParams) (interface{}, error) {
+	type result struct {
+		data interface{}
+		err  error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		defer close(ch)
+
+		client, err := RefiningGoAmazingGrpcClientFromContext(p.Context)
+		if err != nil {
+			ch <- result{data: nil, err: err}
+			return
+		}
+
+		ctx, _ := context.WithTimeout(context.Background(), time.Second*30)
+		req := UpdateRecordReq{}
+		if len(p.Args) != 0 {
+			err = ms.Decode(p.Args, &req)
+			if err != nil {
+				ch <- result{data: nil, err: err}
+				return
+			}
+		}
+
+		res, err := (*client).UpdateRecord(ctx, &req)
+		if err != nil {
+			ch <- result{data: nil, err: err}
+			return
+		}
+		ch <- result{data: res, err: nil}
+	}()
+	return func() (interface{}, error) {
+		r := <-ch
+		return r.data, r.err
+	}, nil
+}
+
+var DeleteRecordArguments = graphql.FieldConfigArgument{
+	"id": &graphql.ArgumentConfig{Type: graphql.String},
+}
+
+var DeleteRecordQueryType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "DeleteRecordQueryType",
+	Fields: graphql.Fields{
+		"id": &graphql.Field{Type: graphql.String},
+	},
+	Description: "",
+})
+
+func GoAmazingDeleteRecordResolver(p graphql.ResolveParams) (interface{}, error) {
+	type result struct {
+		data interface{}
+		err  error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		defer close(ch)
+
+		client, err := RefiningGoAmazingGrpcClientFromContext(p.Context)
+		if err != nil {
+			ch <- result{data: nil, err: err}
+			return
+		}
+
+		ctx, _ := context.WithTimeout(context.Background(), time.Second*30)
+		req := DeleteRecordReq{}
+		if len(p.Args) != 0 {
+			err = ms.Decode(p.Args, &req)
+			if err != nil {
+				ch <- result{data: nil, err: err}
+				return
+			}
+		}
+
+		res, err := (*client).DeleteRecord(ctx, &req)
+		if err != nil {
+			ch <- result{data: nil, err: err}
+			return
+		}
+		ch <- result{data: res, err: nil}
+	}()
+	return func() (interface{}, error) {
+		r := <-ch
+		return r.data, r.err
+	}, nil
+}
+
+var RestoreRecordArguments = graphql.FieldConfigArgument{
+	"id": &graphql.ArgumentConfig{Type: graphql.String},
+}
+
+var RestoreRecordQueryType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "RestoreRecordQueryType",
+	Fields: graphql.Fields{
+		"record": &graphql.Field{Type: RecordObject},
+	},
+	Description: "",
+})
+
+func GoAmazingRestoreRecordResolver(p graphql.ResolveParams) (interface{}, error) {
+	type result struct {
+		data interface{}
+		err  error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		defer close(ch)
+
+		client, err := RefiningGoAmazingGrpcClientFromContext(p.Context)
+		if err != nil {
+			ch <- result{data: nil, err: err}
+			return
+		}
+
+		ctx, _ := context.WithTimeout(context.Background(), time.Second*30)
+		req := RestoreRecordReq{}
+		if len(p.Args) != 0 {
+			err = ms.Decode(p.Args, &req)
+			if err != nil {
+				ch <- result{data: nil, err: err}
+				return
+			}
+		}
+
+		res, err := (*client).RestoreRecord(ctx, &req)
+		if err != nil {
+			ch <- result{data: nil, err: err}
+			return
+		}
+		ch <- result{data: res, err: nil}
+	}()
+	return func() (interface{}, error) {
+		r := <-ch
+		return r.data, r.err
+	}, nil
+}
+
+var BatchGetRecordsArguments = graphql.FieldConfigArgument{
+	"ids": &graphql.ArgumentConfig{Type: graphql.NewList(graphql.String)},
+}
+
+var BatchGetRecordsQueryType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "BatchGetRecordsQueryType",
+	Fields: graphql.Fields{
+		"results": &graphql.Field{Type: graphql.NewList(BatchGetRecordsResultObject)},
+	},
+	Description: "",
+})
+
+func GoAmazingBatchGetRecordsResolver(p graphql.ResolveParams) (interface{}, error) {
+	type result struct {
+		data interface{}
+		err  error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		defer close(ch)
+
+		client, err := RefiningGoAmazingGrpcClientFromContext(p.Context)
+		if err != nil {
+			ch <- result{data: nil, err: err}
+			return
+		}
+
+		ctx, _ := context.WithTimeout(context.Background(), time.Second*30)
+		req := BatchGetRecordsReq{}
+		if len(p.Args) != 0 {
+			err = ms.Decode(p.Args, &req)
+			if err != nil {
+				ch <- result{data: nil, err: err}
+				return
+			}
+		}
+
+		res, err := (*client).BatchGetRecords(ctx, &req)
+		if err != nil {
+			ch <- result{data: nil, err: err}
+			return
+		}
+		ch <- result{data: res, err: nil}
+	}()
+	return func() (interface{}, error) {
+		r := <-ch
+		return r.data, r.err
+	}, nil
+}
+
+var ListRecordRevisionsArguments = graphql.FieldConfigArgument{
+	"id":         &graphql.ArgumentConfig{Type: graphql.String},
+	"size":       &graphql.ArgumentConfig{Type: graphql.String},
+	"page_token": &graphql.ArgumentConfig{Type: graphql.String},
+}
+
+var ListRecordRevisionsQueryType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "ListRecordRevisionsQueryType",
+	Fields: graphql.Fields{
+		"revisions":       &graphql.Field{Type: graphql.NewList(RecordRevisionObject)},
+		"next_page_token": &graphql.Field{Type: graphql.String},
+	},
+	Description: "",
+})
+
+func GoAmazingListRecordRevisionsResolver(p graphql.ResolveParams) (interface{}, error) {
+	type result struct {
+		data interface{}
+		err  error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		defer close(ch)
+
+		client, err := RefiningGoAmazingGrpcClientFromContext(p.Context)
+		if err != nil {
+			ch <- result{data: nil, err: err}
+			return
+		}
+
+		ctx, _ := context.WithTimeout(context.Background(), time.Second*30)
+		req := ListRecordRevisionsReq{}
+		if len(p.Args) != 0 {
+			err = ms.Decode(p.Args, &req)
+			if err != nil {
+				ch <- result{data: nil, err: err}
+				return
+			}
+		}
+
+		res, err := (*client).ListRecordRevisions(ctx, &req)
+		if err != nil {
+			ch <- result{data: nil, err: err}
+			return
+		}
+		ch <- result{data: res, err: nil}
+	}()
+	return func() (interface{}, error) {
+		r := <-ch
+		return r.data, r.err
+	}, nil
+}
+
 var internalGoAmazingRootQuery = graphql.NewObject(graphql.ObjectConfig{
 	Name: "GoAmazingQuery",
 	Fields: graphql.Fields{
@@ -406,6 +809,18 @@ var internalGoAmazingRootQuery = graphql.NewObject(graphql.ObjectConfig{
 			Args:    ListRecordArguments,
 			Resolve: GoAmazingListRecordResolver,
 		},
+		"BatchGetRecords": &graphql.Field{
+			Name:    "BatchGetRecords",
+			Type:    BatchGetRecordsQueryType,
+			Args:    BatchGetRecordsArguments,
+			Resolve: GoAmazingBatchGetRecordsResolver,
+		},
+		"ListRecordRevisions": &graphql.Field{
+			Name:    "ListRecordRevisions",
+			Type:    ListRecordRevisionsQueryType,
+			Args:    ListRecordRevisionsArguments,
+			Resolve: GoAmazingListRecordRevisionsResolver,
+		},
 	},
 })
 
@@ -428,6 +843,24 @@ var internalGoAmazingRootMutation = graphql.NewObject(graphql.ObjectConfig{
 			Args:    CreateRecordArguments,
 			Resolve: GoAmazingCreateRecordResolver,
 		},
+		"UpdateRecord": &graphql.Field{
+			Name:    "UpdateRecord",
+			Type:    UpdateRecordQueryType,
+			Args:    UpdateRecordArguments,
+			Resolve: GoAmazingUpdateRecordResolver,
+		},
+		"DeleteRecord": &graphql.Field{
+			Name:    "DeleteRecord",
+			Type:    DeleteRecordQueryType,
+			Args:    DeleteRecordArguments,
+			Resolve: GoAmazingDeleteRecordResolver,
+		},
+		"RestoreRecord": &graphql.Field{
+			Name:    "RestoreRecord",
+			Type:    RestoreRecordQueryType,
+			Args:    RestoreRecordArguments,
+			Resolve: GoAmazingRestoreRecordResolver,
+		},
 	},
 })
 
@@ -3,7 +3,6 @@
 package pb
 
 import (
-	"bytes"
 	"io"
 	"net/http"
 
@@ -63,6 +62,16 @@ func EnrichGinRouter(e *gin.Engine, adapter *AmazingGinHttpAdapter) {
 
 	e.Handle(http.MethodGet, "/api/records", adapter.ListRecordHandler)
 
+	e.Handle(http.MethodPut, "/api/records/:id", adapter.UpdateRecordHandler)
+
+	e.Handle(http.MethodDelete, "/api/records/:id", adapter.DeleteRecordHandler)
+
+	e.Handle(http.MethodPost, "/api/records/:id/restore", adapter.RestoreRecordHandler)
+
+	e.Handle(http.MethodPost, "/api/records/batchGet", adapter.BatchGetRecordsHandler)
+
+	e.Handle(http.MethodGet, "/api/records/:id/revisions", adapter.ListRecordRevisionsHandler)
+
 }
 
 func (a *AmazingGinHttpAdapter) HealthHandler(ctx *gin.Context) {
@@ -251,6 +260,39 @@ func (a *AmazingGinHttpAdapter) ListRecordHandler(ctx *gin.Context) {
 	v_Page, _ := ctx.GetQuery("page")
 	req.Page = v_Page
 
+	v_PageToken, _ := ctx.GetQuery("page_token")
+	req.PageToken = v_PageToken
+
+	v_OrderBy, _ := ctx.GetQuery("order_by")
+	req.OrderBy = v_OrderBy
+
+	v_TheNumMin, _ := ctx.GetQuery("the_num_min")
+	req.TheNumMin = v_TheNumMin
+
+	v_TheNumMax, _ := ctx.GetQuery("the_num_max")
+	req.TheNumMax = v_TheNumMax
+
+	v_TheStr, _ := ctx.GetQuery("the_str")
+	req.TheStr = v_TheStr
+
+	v_TheStrPrefix, _ := ctx.GetQuery("the_str_prefix")
+	req.TheStrPrefix = v_TheStrPrefix
+
+	v_CreatedAfter, _ := ctx.GetQuery("created_after")
+	req.CreatedAfter = v_CreatedAfter
+
+	v_CreatedBefore, _ := ctx.GetQuery("created_before")
+	req.CreatedBefore = v_CreatedBefore
+
+	v_UpdatedAfter, _ := ctx.GetQuery("updated_after")
+	req.UpdatedAfter = v_UpdatedAfter
+
+	v_UpdatedBefore, _ := ctx.GetQuery("updated_before")
+	req.UpdatedBefore = v_UpdatedBefore
+
+	v_IncludeDeleted, _ := ctx.GetQuery("include_deleted")
+	req.IncludeDeleted = v_IncludeDeleted
+
 	ctx = logkit.EnrichRequestPayload(ctx, req)
 
 	resp, err := a.server.ListRecord(contextkit.ParseGinContext(ctx), req)
@@ -268,20 +310,230 @@ func (a *AmazingGinHttpAdapter) ListRecordHandler(ctx *gin.Context) {
 		return
 	}
 
-	buf := make([]bytes.Buffer, len(resp.Records))
-	for i, m := range resp.Records {
-		m := m
-		var out bytes.Buffer
-		if err := jsonpbkit.Marshal(&out, m); err != nil {
-			logkit.Errorf(ctx, "marshal response failed", logkit.Payload{"err": err})
-			e := errorkit.NewFromError(errCodes.ErrMarshalResponseFailed, err, errorkit.WithHttpStatusCode(http.StatusInternalServerError))
-			ctx.JSON(e.HttpStatus(), e.GinHashMap())
-			return
-		}
-		buf[i] = out
+	output, err := jsonpbkit.MarshalToString(resp)
+
+	if err != nil {
+		e := errorkit.FormatError(err)
+		ctx.JSON(e.HttpStatus(), e.GinHashMap())
+		return
+	}
+
+	ctx.String(200, output)
+}
+
+func (a *AmazingGinHttpAdapter) UpdateRecordHandler(ctx *gin.Context) {
+
+	req := &UpdateRecordReq{}
+
+	err := jsonpbkit.Unmarshal(ctx.Request.Body, req)
+
+	if err != nil && err != io.EOF {
+		logkit.Errorf(ctx, "unmarshal body failed", logkit.Payload{"err": err})
+		e := errorkit.NewFromError(errCodes.ErrUnmarshalBodyFailed, err, errorkit.WithHttpStatusCode(http.StatusBadRequest))
+		ctx.JSON(e.HttpStatus(), e.GinHashMap())
+		return
+	}
+
+	v_ID := ctx.Param("id")
+	req.ID = v_ID
+
+	ctx = logkit.EnrichRequestPayload(ctx, req)
+
+	resp, err := a.server.UpdateRecord(contextkit.ParseGinContext(ctx), req)
+
+	if err != nil {
+		e := errorkit.FormatError(err)
+		ctx.JSON(e.HttpStatus(), e.GinHashMap())
+		return
+	}
+
+	ctx.Header("content-type", "application/json")
+
+	if resp == nil {
+		ctx.String(204, "")
+		return
 	}
 
-	output, err := jsonpbkit.MarshalJsonBuffersToString(buf)
+	output, err := jsonpbkit.MarshalToString(resp.Record)
+
+	if err != nil {
+		e := errorkit.FormatError(err)
+		ctx.JSON(e.HttpStatus(), e.GinHashMap())
+		return
+	}
+
+	ctx.String(200, output)
+}
+
+func (a *AmazingGinHttpAdapter) DeleteRecordHandler(ctx *gin.Context) {
+
+	req := &DeleteRecordReq{}
+
+	err := jsonpbkit.Unmarshal(ctx.Request.Body, req)
+
+	if err != nil && err != io.EOF {
+		logkit.Errorf(ctx, "unmarshal body failed", logkit.Payload{"err": err})
+		e := errorkit.NewFromError(errCodes.ErrUnmarshalBodyFailed, err, errorkit.WithHttpStatusCode(http.StatusBadRequest))
+		ctx.JSON(e.HttpStatus(), e.GinHashMap())
+		return
+	}
+
+	v_ID := ctx.Param("id")
+	req.ID = v_ID
+
+	ctx = logkit.EnrichRequestPayload(ctx, req)
+
+	resp, err := a.server.DeleteRecord(contextkit.ParseGinContext(ctx), req)
+
+	if err != nil {
+		e := errorkit.FormatError(err)
+		ctx.JSON(e.HttpStatus(), e.GinHashMap())
+		return
+	}
+
+	ctx.Header("content-type", "application/json")
+
+	if resp == nil {
+		ctx.String(204, "")
+		return
+	}
+
+	output, err := jsonpbkit.MarshalToString(resp)
+
+	if err != nil {
+		e := errorkit.FormatError(err)
+		ctx.JSON(e.HttpStatus(), e.GinHashMap())
+		return
+	}
+
+	ctx.String(200, output)
+}
+
+func (a *AmazingGinHttpAdapter) RestoreRecordHandler(ctx *gin.Context) {
+
+	req := &RestoreRecordReq{}
+
+	err := jsonpbkit.Unmarshal(ctx.Request.Body, req)
+
+	if err != nil && err != io.EOF {
+		logkit.Errorf(ctx, "unmarshal body failed", logkit.Payload{"err": err})
+		e := errorkit.NewFromError(errCodes.ErrUnmarshalBodyFailed, err, errorkit.WithHttpStatusCode(http.StatusBadRequest))
+		ctx.JSON(e.HttpStatus(), e.GinHashMap())
+		return
+	}
+
+	v_ID := ctx.Param("id")
+	req.ID = v_ID
+
+	ctx = logkit.EnrichRequestPayload(ctx, req)
+
+	resp, err := a.server.RestoreRecord(contextkit.ParseGinContext(ctx), req)
+
+	if err != nil {
+		e := errorkit.FormatError(err)
+		ctx.JSON(e.HttpStatus(), e.GinHashMap())
+		return
+	}
+
+	ctx.Header("content-type", "application/json")
+
+	if resp == nil {
+		ctx.String(204, "")
+		return
+	}
+
+	output, err := jsonpbkit.MarshalToString(resp)
+
+	if err != nil {
+		e := errorkit.FormatError(err)
+		ctx.JSON(e.HttpStatus(), e.GinHashMap())
+		return
+	}
+
+	ctx.String(200, output)
+}
+
+func (a *AmazingGinHttpAdapter) BatchGetRecordsHandler(ctx *gin.Context) {
+
+	req := &BatchGetRecordsReq{}
+
+	err := jsonpbkit.Unmarshal(ctx.Request.Body, req)
+
+	if err != nil && err != io.EOF {
+		logkit.Errorf(ctx, "unmarshal body failed", logkit.Payload{"err": err})
+		e := errorkit.NewFromError(errCodes.ErrUnmarshalBodyFailed, err, errorkit.WithHttpStatusCode(http.StatusBadRequest))
+		ctx.JSON(e.HttpStatus(), e.GinHashMap())
+		return
+	}
+
+	ctx = logkit.EnrichRequestPayload(ctx, req)
+
+	resp, err := a.server.BatchGetRecords(contextkit.ParseGinContext(ctx), req)
+
+	if err != nil {
+		e := errorkit.FormatError(err)
+		ctx.JSON(e.HttpStatus(), e.GinHashMap())
+		return
+	}
+
+	ctx.Header("content-type", "application/json")
+
+	if resp == nil {
+		ctx.String(204, "")
+		return
+	}
+
+	output, err := jsonpbkit.MarshalToString(resp)
+
+	if err != nil {
+		e := errorkit.FormatError(err)
+		ctx.JSON(e.HttpStatus(), e.GinHashMap())
+		return
+	}
+
+	ctx.String(200, output)
+}
+
+func (a *AmazingGinHttpAdapter) ListRecordRevisionsHandler(ctx *gin.Context) {
+
+	req := &ListRecordRevisionsReq{}
+
+	err := jsonpbkit.Unmarshal(ctx.Request.Body, req)
+
+	if err != nil && err != io.EOF {
+		logkit.Errorf(ctx, "unmarshal body failed", logkit.Payload{"err": err})
+		e := errorkit.NewFromError(errCodes.ErrUnmarshalBodyFailed, err, errorkit.WithHttpStatusCode(http.StatusBadRequest))
+		ctx.JSON(e.HttpStatus(), e.GinHashMap())
+		return
+	}
+
+	v_ID := ctx.Param("id")
+	req.ID = v_ID
+
+	v_PageSize, _ := ctx.GetQuery("size")
+	req.PageSize = v_PageSize
+
+	v_PageToken, _ := ctx.GetQuery("page_token")
+	req.PageToken = v_PageToken
+
+	ctx = logkit.EnrichRequestPayload(ctx, req)
+
+	resp, err := a.server.ListRecordRevisions(contextkit.ParseGinContext(ctx), req)
+
+	if err != nil {
+		e := errorkit.FormatError(err)
+		ctx.JSON(e.HttpStatus(), e.GinHashMap())
+		return
+	}
+
+	ctx.Header("content-type", "application/json")
+
+	if resp == nil {
+		ctx.String(204, "")
+		return
+	}
+
+	output, err := jsonpbkit.MarshalToString(resp)
 
 	if err != nil {
 		e := errorkit.FormatError(err)
@@ -43,6 +43,10 @@ type Record struct {
 	TheStr    string     `protobuf:"bytes,3,opt,name=the_str,json=theStr,proto3" json:"theStr"`
 	CreatedAt *time.Time `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3,stdtime,wktptr" json:"createdAt"`
 	UpdatedAt *time.Time `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3,stdtime,wktptr" json:"updatedAt"`
+	// deleted_at is empty unless the record is deleted, see ListRecordReq.include_deleted.
+	DeletedAt *time.Time `protobuf:"bytes,6,opt,name=deleted_at,json=deletedAt,proto3,stdtime,wktptr" json:"deletedAt"`
+	// version starts from 1 and increases on every update, it's the ETag of the record on HTTP.
+	Version int64 `protobuf:"varint,7,opt,name=version,proto3" json:"version"`
 }
 
 func (m *Record) Reset()      { *m = Record{} }
@@ -112,13 +116,129 @@ func (m *Record) GetUpdatedAt() *time.Time {
 	return nil
 }
 
+func (m *Record) GetDeletedAt() *time.Time {
+	if m != nil {
+		return m.DeletedAt
+	}
+	return nil
+}
+
+func (m *Record) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type RecordRevision struct {
+	ID       int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id"`
+	RecordID string `protobuf:"bytes,2,opt,name=record_id,json=recordId,proto3" json:"recordId"`
+	// action is one of create, update, delete, restore and purge.
+	Action string `protobuf:"bytes,3,opt,name=action,proto3" json:"action"`
+	// actor is the subject of the caller, ex: the sub claim of the jwt or the name of the api key.
+	Actor     string `protobuf:"bytes,4,opt,name=actor,proto3" json:"actor"`
+	RequestID string `protobuf:"bytes,5,opt,name=request_id,json=requestId,proto3" json:"requestId"`
+	// old_value is empty for create, new_value is empty for delete. Both are empty once the record is purged.
+	OldValue  *Record    `protobuf:"bytes,6,opt,name=old_value,json=oldValue,proto3" json:"oldValue"`
+	NewValue  *Record    `protobuf:"bytes,7,opt,name=new_value,json=newValue,proto3" json:"newValue"`
+	CreatedAt *time.Time `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3,stdtime,wktptr" json:"createdAt"`
+}
+
+func (m *RecordRevision) Reset()      { *m = RecordRevision{} }
+func (*RecordRevision) ProtoMessage() {}
+func (*RecordRevision) Descriptor() ([]byte, []int) {
+	return fileDescriptor_db28b008f832a8c4, []int{1}
+}
+func (m *RecordRevision) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RecordRevision) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RecordRevision.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RecordRevision) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecordRevision.Merge(m, src)
+}
+func (m *RecordRevision) XXX_Size() int {
+	return m.Size()
+}
+func (m *RecordRevision) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecordRevision.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecordRevision proto.InternalMessageInfo
+
+func (m *RecordRevision) GetID() int64 {
+	if m != nil {
+		return m.ID
+	}
+	return 0
+}
+
+func (m *RecordRevision) GetRecordID() string {
+	if m != nil {
+		return m.RecordID
+	}
+	return ""
+}
+
+func (m *RecordRevision) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+func (m *RecordRevision) GetActor() string {
+	if m != nil {
+		return m.Actor
+	}
+	return ""
+}
+
+func (m *RecordRevision) GetRequestID() string {
+	if m != nil {
+		return m.RequestID
+	}
+	return ""
+}
+
+func (m *RecordRevision) GetOldValue() *Record {
+	if m != nil {
+		return m.OldValue
+	}
+	return nil
+}
+
+func (m *RecordRevision) GetNewValue() *Record {
+	if m != nil {
+		return m.NewValue
+	}
+	return nil
+}
+
+func (m *RecordRevision) GetCreatedAt() *time.Time {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
 type HealthReq struct {
 }
 
 func (m *HealthReq) Reset()      { *m = HealthReq{} }
 func (*HealthReq) ProtoMessage() {}
 func (*HealthReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_db28b008f832a8c4, []int{1}
+	return fileDescriptor_db28b008f832a8c4, []int{2}
 }
 func (m *HealthReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -154,7 +274,7 @@ type HealthRes struct {
 func (m *HealthRes) Reset()      { *m = HealthRes{} }
 func (*HealthRes) ProtoMessage() {}
 func (*HealthRes) Descriptor() ([]byte, []int) {
-	return fileDescriptor_db28b008f832a8c4, []int{2}
+	return fileDescriptor_db28b008f832a8c4, []int{3}
 }
 func (m *HealthRes) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -196,7 +316,7 @@ type ConfigReq struct {
 func (m *ConfigReq) Reset()      { *m = ConfigReq{} }
 func (*ConfigReq) ProtoMessage() {}
 func (*ConfigReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_db28b008f832a8c4, []int{3}
+	return fileDescriptor_db28b008f832a8c4, []int{4}
 }
 func (m *ConfigReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -234,7 +354,7 @@ type ConfigRes struct {
 func (m *ConfigRes) Reset()      { *m = ConfigRes{} }
 func (*ConfigRes) ProtoMessage() {}
 func (*ConfigRes) Descriptor() ([]byte, []int) {
-	return fileDescriptor_db28b008f832a8c4, []int{4}
+	return fileDescriptor_db28b008f832a8c4, []int{5}
 }
 func (m *ConfigRes) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -285,15 +405,20 @@ func (m *ConfigRes) GetStr() string {
 }
 
 type CreateRecordReq struct {
-	TheNum    int64      `protobuf:"varint,1,opt,name=the_num,json=theNum,proto3" json:"theNum"`
-	TheStr    string     `protobuf:"bytes,2,opt,name=the_str,json=theStr,proto3" json:"theStr"`
+	// the_num is an INTEGER column.
+	TheNum int64 `protobuf:"varint,1,opt,name=the_num,json=theNum,proto3" json:"theNum" validate:"gte=-2147483648,lte=2147483647"`
+	// the_str is a varchar(255) column.
+	TheStr    string     `protobuf:"bytes,2,opt,name=the_str,json=theStr,proto3" json:"theStr" validate:"required,max=255"`
 	CreatedAt *time.Time `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3,stdtime,wktptr" json:"createdAt"`
+	// idempotency_key makes the retries return the record created by the first request, at most 255 characters.
+	// It can be given by the Idempotency-Key header or the idempotency-key grpc metadata as well.
+	IdempotencyKey string `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotencyKey" validate:"max=255"`
 }
 
 func (m *CreateRecordReq) Reset()      { *m = CreateRecordReq{} }
 func (*CreateRecordReq) ProtoMessage() {}
 func (*CreateRecordReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_db28b008f832a8c4, []int{5}
+	return fileDescriptor_db28b008f832a8c4, []int{6}
 }
 func (m *CreateRecordReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -343,6 +468,13 @@ func (m *CreateRecordReq) GetCreatedAt() *time.Time {
 	return nil
 }
 
+func (m *CreateRecordReq) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
 type CreateRecordRes struct {
 	Record *Record `protobuf:"bytes,1,opt,name=record,proto3" json:"record,omitempty"`
 }
@@ -350,7 +482,7 @@ type CreateRecordRes struct {
 func (m *CreateRecordRes) Reset()      { *m = CreateRecordRes{} }
 func (*CreateRecordRes) ProtoMessage() {}
 func (*CreateRecordRes) Descriptor() ([]byte, []int) {
-	return fileDescriptor_db28b008f832a8c4, []int{6}
+	return fileDescriptor_db28b008f832a8c4, []int{7}
 }
 func (m *CreateRecordRes) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -387,13 +519,13 @@ func (m *CreateRecordRes) GetRecord() *Record {
 }
 
 type GetRecordReq struct {
-	ID string `protobuf:"bytes,1,opt,name=id,proto3" json:"id"`
+	ID string `protobuf:"bytes,1,opt,name=id,proto3" json:"id" validate:"required,uuid"`
 }
 
 func (m *GetRecordReq) Reset()      { *m = GetRecordReq{} }
 func (*GetRecordReq) ProtoMessage() {}
 func (*GetRecordReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_db28b008f832a8c4, []int{7}
+	return fileDescriptor_db28b008f832a8c4, []int{8}
 }
 func (m *GetRecordReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -436,7 +568,7 @@ type GetRecordRes struct {
 func (m *GetRecordRes) Reset()      { *m = GetRecordRes{} }
 func (*GetRecordRes) ProtoMessage() {}
 func (*GetRecordRes) Descriptor() ([]byte, []int) {
-	return fileDescriptor_db28b008f832a8c4, []int{8}
+	return fileDescriptor_db28b008f832a8c4, []int{9}
 }
 func (m *GetRecordRes) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -474,14 +606,32 @@ func (m *GetRecordRes) GetRecord() *Record {
 
 type ListRecordReq struct {
 	// keys from url queryString or url params is always type of string.
-	PageSize string `protobuf:"bytes,1,opt,name=size,proto3" json:"size" validate:"required"`
-	Page     string `protobuf:"bytes,2,opt,name=page,proto3" json:"page" validate:"required"`
+	PageSize string `protobuf:"bytes,1,opt,name=size,proto3" json:"size" validate:"omitempty,numeric"`
+	// page is kept for the old clients, the results are paged by offset when it's given.
+	Page string `protobuf:"bytes,2,opt,name=page,proto3" json:"page" validate:"omitempty,numeric"`
+	// page_token is the next_page_token of the previous page, leave it empty to get the first page.
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"pageToken"`
+	// order_by is a comma separated list of "field [asc|desc]", the fields are the_num, the_str, created_at and updated_at.
+	// ex: "the_num desc,created_at". The default is "created_at".
+	OrderBy string `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"orderBy"`
+	// filters, the ranges include the min and the after, and exclude the max and the before.
+	TheNumMin    string `protobuf:"bytes,5,opt,name=the_num_min,json=theNumMin,proto3" json:"theNumMin" validate:"omitempty,numeric"`
+	TheNumMax    string `protobuf:"bytes,6,opt,name=the_num_max,json=theNumMax,proto3" json:"theNumMax" validate:"omitempty,numeric"`
+	TheStr       string `protobuf:"bytes,7,opt,name=the_str,json=theStr,proto3" json:"theStr" validate:"max=255"`
+	TheStrPrefix string `protobuf:"bytes,8,opt,name=the_str_prefix,json=theStrPrefix,proto3" json:"theStrPrefix" validate:"max=255"`
+	// times are in RFC 3339, ex: 2021-08-20T07:20:06Z
+	CreatedAfter  string `protobuf:"bytes,9,opt,name=created_after,json=createdAfter,proto3" json:"createdAfter"`
+	CreatedBefore string `protobuf:"bytes,10,opt,name=created_before,json=createdBefore,proto3" json:"createdBefore"`
+	UpdatedAfter  string `protobuf:"bytes,11,opt,name=updated_after,json=updatedAfter,proto3" json:"updatedAfter"`
+	UpdatedBefore string `protobuf:"bytes,12,opt,name=updated_before,json=updatedBefore,proto3" json:"updatedBefore"`
+	// include_deleted lists the deleted records as well, "true" or "false".
+	IncludeDeleted string `protobuf:"bytes,13,opt,name=include_deleted,json=includeDeleted,proto3" json:"includeDeleted" validate:"omitempty,oneof=true false"`
 }
 
 func (m *ListRecordReq) Reset()      { *m = ListRecordReq{} }
 func (*ListRecordReq) ProtoMessage() {}
 func (*ListRecordReq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_db28b008f832a8c4, []int{9}
+	return fileDescriptor_db28b008f832a8c4, []int{10}
 }
 func (m *ListRecordReq) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -524,14 +674,100 @@ func (m *ListRecordReq) GetPage() string {
 	return ""
 }
 
+func (m *ListRecordReq) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *ListRecordReq) GetOrderBy() string {
+	if m != nil {
+		return m.OrderBy
+	}
+	return ""
+}
+
+func (m *ListRecordReq) GetTheNumMin() string {
+	if m != nil {
+		return m.TheNumMin
+	}
+	return ""
+}
+
+func (m *ListRecordReq) GetTheNumMax() string {
+	if m != nil {
+		return m.TheNumMax
+	}
+	return ""
+}
+
+func (m *ListRecordReq) GetTheStr() string {
+	if m != nil {
+		return m.TheStr
+	}
+	return ""
+}
+
+func (m *ListRecordReq) GetTheStrPrefix() string {
+	if m != nil {
+		return m.TheStrPrefix
+	}
+	return ""
+}
+
+func (m *ListRecordReq) GetCreatedAfter() string {
+	if m != nil {
+		return m.CreatedAfter
+	}
+	return ""
+}
+
+func (m *ListRecordReq) GetCreatedBefore() string {
+	if m != nil {
+		return m.CreatedBefore
+	}
+	return ""
+}
+
+func (m *ListRecordReq) GetUpdatedAfter() string {
+	if m != nil {
+		return m.UpdatedAfter
+	}
+	return ""
+}
+
+func (m *ListRecordReq) GetUpdatedBefore() string {
+	if m != nil {
+		return m.UpdatedBefore
+	}
+	return ""
+}
+
+func (m *ListRecordReq) GetIncludeDeleted() string {
+	if m != nil {
+		return m.IncludeDeleted
+	}
+	return ""
+}
+
 type ListRecordRes struct {
 	Records []*Record `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	// next_page_token is empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"nextPageToken"`
+	// total_count is the number of the records matching the filters, paging by token counts it on the first page,
+	// and the later pages keep it.
+	TotalCount int64 `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"totalCount"`
+	// page is zero-based like the page of ListRecordReq, it is counted by the page tokens too.
+	Page     int32 `protobuf:"varint,4,opt,name=page,proto3" json:"page"`
+	PageSize int32 `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"pageSize"`
+	HasMore  bool  `protobuf:"varint,6,opt,name=has_more,json=hasMore,proto3" json:"hasMore"`
 }
 
 func (m *ListRecordRes) Reset()      { *m = ListRecordRes{} }
 func (*ListRecordRes) ProtoMessage() {}
 func (*ListRecordRes) Descriptor() ([]byte, []int) {
-	return fileDescriptor_db28b008f832a8c4, []int{10}
+	return fileDescriptor_db28b008f832a8c4, []int{11}
 }
 func (m *ListRecordRes) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -567,284 +803,751 @@ func (m *ListRecordRes) GetRecords() []*Record {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*Record)(nil), "pb.Record")
-	proto.RegisterType((*HealthReq)(nil), "pb.HealthReq")
-	proto.RegisterType((*HealthRes)(nil), "pb.HealthRes")
-	proto.RegisterType((*ConfigReq)(nil), "pb.ConfigReq")
-	proto.RegisterType((*ConfigRes)(nil), "pb.ConfigRes")
-	proto.RegisterType((*CreateRecordReq)(nil), "pb.CreateRecordReq")
-	proto.RegisterType((*CreateRecordRes)(nil), "pb.CreateRecordRes")
-	proto.RegisterType((*GetRecordReq)(nil), "pb.GetRecordReq")
-	proto.RegisterType((*GetRecordRes)(nil), "pb.GetRecordRes")
-	proto.RegisterType((*ListRecordReq)(nil), "pb.ListRecordReq")
-	proto.RegisterType((*ListRecordRes)(nil), "pb.ListRecordRes")
+func (m *ListRecordRes) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
 }
 
-func init() { proto.RegisterFile("pkg/pb/rpc.proto", fileDescriptor_db28b008f832a8c4) }
-
-var fileDescriptor_db28b008f832a8c4 = []byte{
-	// 825 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x95, 0xcf, 0x8b, 0x1c, 0x45,
-	0x14, 0xc7, 0xa7, 0x7a, 0xc6, 0xde, 0x9d, 0x9a, 0x8d, 0x59, 0x2b, 0x26, 0x0e, 0x1d, 0xec, 0x1e,
-	0x0a, 0x8c, 0x8b, 0x90, 0xee, 0xcd, 0xaa, 0x04, 0xf6, 0x96, 0x49, 0x60, 0x57, 0x0c, 0xa2, 0xbd,
-	0x9b, 0x8b, 0x20, 0x6b, 0xcd, 0x74, 0xa5, 0xa7, 0xd8, 0x99, 0xe9, 0xde, 0xae, 0x6a, 0xc1, 0x3d,
-	0x89, 0x47, 0x21, 0x10, 0xf4, 0x0f, 0xd0, 0xa3, 0xf8, 0x37, 0xf8, 0x07, 0x78, 0x92, 0x05, 0x2f,
-	0x39, 0x75, 0xdc, 0x5e, 0x0f, 0x32, 0xa7, 0xe0, 0x45, 0x10, 0x04, 0xa9, 0x1f, 0x3d, 0xd3, 0xb3,
-	0x0a, 0xba, 0x92, 0xd3, 0xd4, 0x7b, 0xfd, 0x79, 0xdf, 0x79, 0xf5, 0x7e, 0x74, 0xc3, 0xf5, 0xf4,
-	0x30, 0x0e, 0xd2, 0x41, 0x90, 0xa5, 0x43, 0x3f, 0xcd, 0x12, 0x91, 0x20, 0x2b, 0x1d, 0x38, 0x1b,
-	0x62, 0xc4, 0xb2, 0xe8, 0x20, 0x25, 0x99, 0xf8, 0x34, 0x88, 0x93, 0x24, 0x1e, 0xd3, 0x80, 0xa4,
-	0x2c, 0x20, 0xd3, 0x69, 0x22, 0x88, 0x60, 0xc9, 0x94, 0x6b, 0xda, 0xe9, 0x2d, 0x93, 0x71, 0xa2,
-	0xdc, 0xea, 0x64, 0x88, 0xd7, 0xeb, 0x04, 0x99, 0x90, 0x63, 0x36, 0x8d, 0x05, 0x19, 0x1f, 0xd2,
-	0x2c, 0x20, 0x42, 0x21, 0x06, 0xf4, 0xcc, 0x1f, 0x29, 0x6b, 0x90, 0x3f, 0x0c, 0x04, 0x9b, 0x50,
-	0x2e, 0xc8, 0x24, 0xd5, 0x00, 0xfe, 0xde, 0x82, 0x76, 0x48, 0x87, 0x49, 0x16, 0xa1, 0x6b, 0xd0,
-	0x62, 0x51, 0x17, 0xf4, 0xc0, 0x46, 0xbb, 0x6f, 0x97, 0x85, 0x67, 0xbd, 0x73, 0x2f, 0xb4, 0x58,
-	0x84, 0x6e, 0xc2, 0x15, 0x31, 0xa2, 0x07, 0xd3, 0x7c, 0xd2, 0xb5, 0x7a, 0x60, 0xa3, 0xd9, 0x7f,
-	0xb9, 0x2c, 0x3c, 0x7b, 0x7f, 0x44, 0xdf, 0xcb, 0x27, 0xb3, 0xc2, 0xb3, 0x85, 0x3a, 0x85, 0xe6,
-	0xb7, 0xc2, 0xb9, 0xc8, 0xba, 0x4d, 0xa5, 0x55, 0xe1, 0x7b, 0x22, 0x33, 0xf8, 0x9e, 0xc8, 0x42,
-	0xf3, 0x8b, 0x3e, 0x82, 0x70, 0x98, 0x51, 0x22, 0x68, 0x74, 0x40, 0x44, 0xb7, 0xd5, 0x03, 0x1b,
-	0x9d, 0x2d, 0xc7, 0xd7, 0x69, 0xfb, 0x55, 0xda, 0xfe, 0x7e, 0x95, 0x76, 0x1f, 0x97, 0x85, 0xd7,
-	0xbe, 0xab, 0x23, 0xee, 0x88, 0x59, 0xe1, 0xb5, 0x87, 0x95, 0xf1, 0xf8, 0xa9, 0x07, 0xbe, 0x79,
-	0xea, 0x81, 0x70, 0xe1, 0x92, 0xf2, 0x79, 0x1a, 0x55, 0xf2, 0x2f, 0xfc, 0x37, 0xf9, 0x07, 0x3a,
-	0x42, 0xcb, 0xe7, 0x95, 0xb1, 0x90, 0x9f, 0xbb, 0x70, 0x07, 0xb6, 0x77, 0x29, 0x19, 0x8b, 0x51,
-	0x48, 0x8f, 0xf0, 0xf5, 0x85, 0xc1, 0xd1, 0x8b, 0xd0, 0x4a, 0x0e, 0x55, 0x35, 0x57, 0x43, 0x2b,
-	0x39, 0x94, 0xe4, 0xdd, 0x64, 0xfa, 0x90, 0xc5, 0x92, 0xdc, 0x59, 0x18, 0x1c, 0x5d, 0x83, 0x36,
-	0x9d, 0x92, 0xc1, 0x98, 0x1a, 0xda, 0x58, 0x68, 0x1d, 0x36, 0xe7, 0x35, 0x0f, 0xe5, 0x51, 0x7a,
-	0xe6, 0x65, 0x0d, 0xe5, 0x11, 0xff, 0x08, 0xe0, 0x65, 0x5d, 0x0c, 0xdd, 0xc4, 0x90, 0x1e, 0xd5,
-	0xfb, 0x05, 0x2e, 0xd6, 0x2f, 0xeb, 0xc2, 0xfd, 0x6a, 0x3e, 0xe7, 0x7e, 0xe1, 0x77, 0xcf, 0xdf,
-	0x87, 0x23, 0x1f, 0xda, 0x99, 0x32, 0xd4, 0x75, 0x3a, 0x5b, 0xd0, 0x4f, 0x07, 0xbe, 0x7e, 0xdc,
-	0x87, 0x32, 0x57, 0x83, 0x1a, 0x6a, 0x7b, 0xf5, 0xbb, 0x3f, 0x1f, 0xdd, 0x68, 0x6e, 0x6d, 0xde,
-	0xc2, 0x6f, 0xc3, 0xb5, 0x1d, 0x2a, 0x16, 0x95, 0x79, 0xad, 0x36, 0xe1, 0x57, 0xf5, 0x84, 0xcf,
-	0x0a, 0xcf, 0x62, 0xd1, 0x97, 0xbf, 0x3f, 0xba, 0xd1, 0x12, 0x59, 0x4e, 0xe5, 0xc0, 0xe3, 0xdd,
-	0xa5, 0xb0, 0xff, 0x9f, 0xc0, 0x26, 0xfe, 0x1a, 0xc0, 0x4b, 0xf7, 0x19, 0xaf, 0xa5, 0xb0, 0x0b,
-	0x5b, 0x9c, 0x1d, 0x53, 0x93, 0xc4, 0x5b, 0x65, 0xe1, 0xad, 0xbe, 0x4f, 0x62, 0xba, 0xc7, 0x8e,
-	0xe9, 0xac, 0xf0, 0xd4, 0xb3, 0xdf, 0x0a, 0xef, 0xca, 0x27, 0x64, 0xcc, 0xe4, 0x8c, 0x6d, 0xe3,
-	0x8c, 0x1e, 0xe5, 0x2c, 0xa3, 0x11, 0xfe, 0x62, 0x9e, 0xa3, 0xa2, 0xd0, 0x3d, 0xd8, 0x4a, 0x49,
-	0x4c, 0x4d, 0xd3, 0x36, 0xcb, 0xc2, 0x6b, 0x49, 0x25, 0xa9, 0x22, 0xfd, 0xff, 0xae, 0x22, 0x29,
-	0x7c, 0x7f, 0x39, 0x41, 0x8e, 0x6e, 0xc1, 0x15, 0x7d, 0x0d, 0xde, 0x05, 0xbd, 0xe6, 0xb9, 0xdb,
-	0x76, 0xca, 0xc2, 0x5b, 0xd1, 0x67, 0x1e, 0x56, 0xdc, 0xe2, 0xbe, 0x5b, 0x7f, 0x58, 0xb0, 0xbd,
-	0x93, 0xdc, 0xd1, 0x2f, 0x24, 0x74, 0x1b, 0xda, 0x7a, 0x1f, 0xd0, 0x25, 0xa9, 0x31, 0x5f, 0x14,
-	0x67, 0xc9, 0xe4, 0xf8, 0xf2, 0xe7, 0x3f, 0xfd, 0xf2, 0x95, 0xd5, 0x46, 0x2b, 0xc1, 0x48, 0xe3,
-	0xb7, 0xa1, 0xad, 0xd7, 0x43, 0x07, 0xce, 0xf7, 0xc6, 0x59, 0x32, 0xeb, 0x81, 0x43, 0x8d, 0x3f,
-	0x80, 0x6b, 0xf5, 0xe9, 0x41, 0x57, 0x14, 0xbf, 0xbc, 0x1f, 0xce, 0x3f, 0x38, 0x39, 0xbe, 0xae,
-	0xa4, 0xae, 0xe2, 0x8e, 0x7a, 0x27, 0x9b, 0x46, 0x9a, 0x86, 0xa2, 0x0f, 0x60, 0x7b, 0x3e, 0x10,
-	0x68, 0x5d, 0x86, 0xd7, 0xc7, 0xca, 0x39, 0xef, 0xe1, 0xb8, 0xa7, 0xd4, 0x1c, 0xb4, 0x5e, 0x53,
-	0xe3, 0xc1, 0x36, 0xab, 0x4b, 0xc2, 0x45, 0xdd, 0xd1, 0x4b, 0x52, 0x61, 0x69, 0x50, 0x9c, 0xbf,
-	0xb9, 0x38, 0x7e, 0x55, 0xa9, 0xbe, 0x82, 0xd6, 0xea, 0xaa, 0xdb, 0x55, 0x1b, 0xfa, 0x1f, 0x9f,
-	0x9c, 0xba, 0x8d, 0x27, 0xa7, 0x6e, 0xe3, 0xd9, 0xa9, 0x0b, 0x3e, 0x2b, 0x5d, 0xf0, 0x6d, 0xe9,
-	0x82, 0x1f, 0x4a, 0x17, 0x9c, 0x94, 0x2e, 0xf8, 0xb9, 0x74, 0xc1, 0xaf, 0xa5, 0xdb, 0x78, 0x56,
-	0xba, 0xe0, 0xf1, 0x99, 0xdb, 0x38, 0x39, 0x73, 0x1b, 0x4f, 0xce, 0xdc, 0xc6, 0x87, 0x6f, 0xc4,
-	0x4c, 0x8c, 0xf2, 0x81, 0x3f, 0x4c, 0x26, 0x81, 0x69, 0xdc, 0xbe, 0xfe, 0x92, 0xc4, 0xc9, 0x4d,
-	0xf3, 0x69, 0x09, 0xf4, 0x07, 0x6d, 0x60, 0xab, 0xfd, 0x7e, 0xf3, 0xaf, 0x00, 0x00, 0x00, 0xff,
-	0xff, 0xa1, 0x31, 0x5c, 0x68, 0xe1, 0x06, 0x00, 0x00,
+func (m *ListRecordRes) GetTotalCount() int64 {
+	if m != nil {
+		return m.TotalCount
+	}
+	return 0
 }
 
-func (this *Record) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+func (m *ListRecordRes) GetPage() int32 {
+	if m != nil {
+		return m.Page
 	}
+	return 0
+}
 
-	that1, ok := that.(*Record)
-	if !ok {
-		that2, ok := that.(Record)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.ID != that1.ID {
-		return false
-	}
-	if this.TheNum != that1.TheNum {
-		return false
+func (m *ListRecordRes) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
 	}
-	if this.TheStr != that1.TheStr {
-		return false
+	return 0
+}
+
+func (m *ListRecordRes) GetHasMore() bool {
+	if m != nil {
+		return m.HasMore
 	}
-	if that1.CreatedAt == nil {
-		if this.CreatedAt != nil {
-			return false
+	return false
+}
+
+type UpdateRecordReq struct {
+	ID string `protobuf:"bytes,1,opt,name=id,proto3" json:"id" validate:"required,uuid"`
+	// the rules are the same as CreateRecordReq, the record is replaced as a whole.
+	TheNum int64  `protobuf:"varint,2,opt,name=the_num,json=theNum,proto3" json:"theNum" validate:"gte=-2147483648,lte=2147483647"`
+	TheStr string `protobuf:"bytes,3,opt,name=the_str,json=theStr,proto3" json:"theStr" validate:"required,max=255"`
+	// version is the current version of the record, the update is a conflict if it's changed since.
+	// It's required unless the If-Match header is given on HTTP, ex: If-Match: "3"
+	// "*" matches any version, and a list of ETags matches any of them. The header must match the version when both are given.
+	Version int64 `protobuf:"varint,4,opt,name=version,proto3" json:"version" validate:"omitempty,gte=1"`
+}
+
+func (m *UpdateRecordReq) Reset()      { *m = UpdateRecordReq{} }
+func (*UpdateRecordReq) ProtoMessage() {}
+func (*UpdateRecordReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_db28b008f832a8c4, []int{12}
+}
+func (m *UpdateRecordReq) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *UpdateRecordReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_UpdateRecordReq.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-	} else if !this.CreatedAt.Equal(*that1.CreatedAt) {
-		return false
+		return b[:n], nil
 	}
-	if that1.UpdatedAt == nil {
-		if this.UpdatedAt != nil {
-			return false
-		}
-	} else if !this.UpdatedAt.Equal(*that1.UpdatedAt) {
-		return false
+}
+func (m *UpdateRecordReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateRecordReq.Merge(m, src)
+}
+func (m *UpdateRecordReq) XXX_Size() int {
+	return m.Size()
+}
+func (m *UpdateRecordReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateRecordReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateRecordReq proto.InternalMessageInfo
+
+func (m *UpdateRecordReq) GetID() string {
+	if m != nil {
+		return m.ID
 	}
-	return true
+	return ""
 }
-func (this *HealthReq) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *UpdateRecordReq) GetTheNum() int64 {
+	if m != nil {
+		return m.TheNum
 	}
+	return 0
+}
 
-	that1, ok := that.(*HealthReq)
-	if !ok {
-		that2, ok := that.(HealthReq)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
+func (m *UpdateRecordReq) GetTheStr() string {
+	if m != nil {
+		return m.TheStr
 	}
-	return true
+	return ""
 }
-func (this *HealthRes) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *UpdateRecordReq) GetVersion() int64 {
+	if m != nil {
+		return m.Version
 	}
+	return 0
+}
 
-	that1, ok := that.(*HealthRes)
-	if !ok {
-		that2, ok := that.(HealthRes)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type UpdateRecordRes struct {
+	Record *Record `protobuf:"bytes,1,opt,name=record,proto3" json:"record,omitempty"`
+}
+
+func (m *UpdateRecordRes) Reset()      { *m = UpdateRecordRes{} }
+func (*UpdateRecordRes) ProtoMessage() {}
+func (*UpdateRecordRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_db28b008f832a8c4, []int{13}
+}
+func (m *UpdateRecordRes) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *UpdateRecordRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_UpdateRecordRes.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.Ok != that1.Ok {
-		return false
-	}
-	return true
 }
-func (this *ConfigReq) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+func (m *UpdateRecordRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateRecordRes.Merge(m, src)
+}
+func (m *UpdateRecordRes) XXX_Size() int {
+	return m.Size()
+}
+func (m *UpdateRecordRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateRecordRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateRecordRes proto.InternalMessageInfo
+
+func (m *UpdateRecordRes) GetRecord() *Record {
+	if m != nil {
+		return m.Record
 	}
+	return nil
+}
 
-	that1, ok := that.(*ConfigReq)
-	if !ok {
-		that2, ok := that.(ConfigReq)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type DeleteRecordReq struct {
+	ID string `protobuf:"bytes,1,opt,name=id,proto3" json:"id" validate:"required,uuid"`
+}
+
+func (m *DeleteRecordReq) Reset()      { *m = DeleteRecordReq{} }
+func (*DeleteRecordReq) ProtoMessage() {}
+func (*DeleteRecordReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_db28b008f832a8c4, []int{14}
+}
+func (m *DeleteRecordReq) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DeleteRecordReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DeleteRecordReq.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	return true
 }
-func (this *ConfigRes) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+func (m *DeleteRecordReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteRecordReq.Merge(m, src)
+}
+func (m *DeleteRecordReq) XXX_Size() int {
+	return m.Size()
+}
+func (m *DeleteRecordReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteRecordReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteRecordReq proto.InternalMessageInfo
+
+func (m *DeleteRecordReq) GetID() string {
+	if m != nil {
+		return m.ID
 	}
+	return ""
+}
 
-	that1, ok := that.(*ConfigRes)
-	if !ok {
-		that2, ok := that.(ConfigRes)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type DeleteRecordRes struct {
+	ID string `protobuf:"bytes,1,opt,name=id,proto3" json:"id"`
+}
+
+func (m *DeleteRecordRes) Reset()      { *m = DeleteRecordRes{} }
+func (*DeleteRecordRes) ProtoMessage() {}
+func (*DeleteRecordRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_db28b008f832a8c4, []int{15}
+}
+func (m *DeleteRecordRes) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DeleteRecordRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DeleteRecordRes.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.Enable != that1.Enable {
-		return false
-	}
-	if this.Num != that1.Num {
-		return false
-	}
-	if this.Str != that1.Str {
-		return false
-	}
-	return true
 }
-func (this *CreateRecordReq) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+func (m *DeleteRecordRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteRecordRes.Merge(m, src)
+}
+func (m *DeleteRecordRes) XXX_Size() int {
+	return m.Size()
+}
+func (m *DeleteRecordRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteRecordRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteRecordRes proto.InternalMessageInfo
+
+func (m *DeleteRecordRes) GetID() string {
+	if m != nil {
+		return m.ID
 	}
+	return ""
+}
 
-	that1, ok := that.(*CreateRecordReq)
-	if !ok {
-		that2, ok := that.(CreateRecordReq)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type RestoreRecordReq struct {
+	ID string `protobuf:"bytes,1,opt,name=id,proto3" json:"id" validate:"required,uuid"`
+}
+
+func (m *RestoreRecordReq) Reset()      { *m = RestoreRecordReq{} }
+func (*RestoreRecordReq) ProtoMessage() {}
+func (*RestoreRecordReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_db28b008f832a8c4, []int{16}
+}
+func (m *RestoreRecordReq) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RestoreRecordReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RestoreRecordReq.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.TheNum != that1.TheNum {
-		return false
-	}
-	if this.TheStr != that1.TheStr {
-		return false
+}
+func (m *RestoreRecordReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreRecordReq.Merge(m, src)
+}
+func (m *RestoreRecordReq) XXX_Size() int {
+	return m.Size()
+}
+func (m *RestoreRecordReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreRecordReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreRecordReq proto.InternalMessageInfo
+
+func (m *RestoreRecordReq) GetID() string {
+	if m != nil {
+		return m.ID
 	}
-	if that1.CreatedAt == nil {
-		if this.CreatedAt != nil {
-			return false
+	return ""
+}
+
+type RestoreRecordRes struct {
+	Record *Record `protobuf:"bytes,1,opt,name=record,proto3" json:"record,omitempty"`
+}
+
+func (m *RestoreRecordRes) Reset()      { *m = RestoreRecordRes{} }
+func (*RestoreRecordRes) ProtoMessage() {}
+func (*RestoreRecordRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_db28b008f832a8c4, []int{17}
+}
+func (m *RestoreRecordRes) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RestoreRecordRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RestoreRecordRes.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-	} else if !this.CreatedAt.Equal(*that1.CreatedAt) {
-		return false
+		return b[:n], nil
 	}
-	return true
 }
-func (this *CreateRecordRes) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+func (m *RestoreRecordRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreRecordRes.Merge(m, src)
+}
+func (m *RestoreRecordRes) XXX_Size() int {
+	return m.Size()
+}
+func (m *RestoreRecordRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreRecordRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreRecordRes proto.InternalMessageInfo
+
+func (m *RestoreRecordRes) GetRecord() *Record {
+	if m != nil {
+		return m.Record
 	}
+	return nil
+}
 
-	that1, ok := that.(*CreateRecordRes)
-	if !ok {
-		that2, ok := that.(CreateRecordRes)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type BatchGetRecordsReq struct {
+	// at most 100 ids, the duplicated ids are fetched once.
+	IDs []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids"`
+}
+
+func (m *BatchGetRecordsReq) Reset()      { *m = BatchGetRecordsReq{} }
+func (*BatchGetRecordsReq) ProtoMessage() {}
+func (*BatchGetRecordsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_db28b008f832a8c4, []int{18}
+}
+func (m *BatchGetRecordsReq) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BatchGetRecordsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BatchGetRecordsReq.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if !this.Record.Equal(that1.Record) {
-		return false
-	}
-	return true
 }
-func (this *GetRecordReq) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
+func (m *BatchGetRecordsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchGetRecordsReq.Merge(m, src)
+}
+func (m *BatchGetRecordsReq) XXX_Size() int {
+	return m.Size()
+}
+func (m *BatchGetRecordsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchGetRecordsReq.DiscardUnknown(m)
+}
 
-	that1, ok := that.(*GetRecordReq)
+var xxx_messageInfo_BatchGetRecordsReq proto.InternalMessageInfo
+
+func (m *BatchGetRecordsReq) GetIDs() []string {
+	if m != nil {
+		return m.IDs
+	}
+	return nil
+}
+
+type BatchGetRecordsRes struct {
+	// results are in the order of the ids of the request.
+	Results []*BatchGetRecordsResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results"`
+}
+
+func (m *BatchGetRecordsRes) Reset()      { *m = BatchGetRecordsRes{} }
+func (*BatchGetRecordsRes) ProtoMessage() {}
+func (*BatchGetRecordsRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_db28b008f832a8c4, []int{19}
+}
+func (m *BatchGetRecordsRes) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BatchGetRecordsRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BatchGetRecordsRes.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BatchGetRecordsRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchGetRecordsRes.Merge(m, src)
+}
+func (m *BatchGetRecordsRes) XXX_Size() int {
+	return m.Size()
+}
+func (m *BatchGetRecordsRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchGetRecordsRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchGetRecordsRes proto.InternalMessageInfo
+
+func (m *BatchGetRecordsRes) GetResults() []*BatchGetRecordsResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type BatchGetRecordsResult struct {
+	ID string `protobuf:"bytes,1,opt,name=id,proto3" json:"id"`
+	// record is empty when not_found.
+	Record   *Record `protobuf:"bytes,2,opt,name=record,proto3" json:"record"`
+	NotFound bool    `protobuf:"varint,3,opt,name=not_found,json=notFound,proto3" json:"notFound"`
+}
+
+func (m *BatchGetRecordsResult) Reset()      { *m = BatchGetRecordsResult{} }
+func (*BatchGetRecordsResult) ProtoMessage() {}
+func (*BatchGetRecordsResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_db28b008f832a8c4, []int{20}
+}
+func (m *BatchGetRecordsResult) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BatchGetRecordsResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BatchGetRecordsResult.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BatchGetRecordsResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchGetRecordsResult.Merge(m, src)
+}
+func (m *BatchGetRecordsResult) XXX_Size() int {
+	return m.Size()
+}
+func (m *BatchGetRecordsResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchGetRecordsResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchGetRecordsResult proto.InternalMessageInfo
+
+func (m *BatchGetRecordsResult) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *BatchGetRecordsResult) GetRecord() *Record {
+	if m != nil {
+		return m.Record
+	}
+	return nil
+}
+
+func (m *BatchGetRecordsResult) GetNotFound() bool {
+	if m != nil {
+		return m.NotFound
+	}
+	return false
+}
+
+type ListRecordRevisionsReq struct {
+	ID       string `protobuf:"bytes,1,opt,name=id,proto3" json:"id" validate:"required,uuid"`
+	PageSize string `protobuf:"bytes,2,opt,name=size,proto3" json:"size" validate:"omitempty,numeric"`
+	// page_token is the next_page_token of the previous page, leave it empty to get the latest revisions.
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"pageToken"`
+}
+
+func (m *ListRecordRevisionsReq) Reset()      { *m = ListRecordRevisionsReq{} }
+func (*ListRecordRevisionsReq) ProtoMessage() {}
+func (*ListRecordRevisionsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_db28b008f832a8c4, []int{21}
+}
+func (m *ListRecordRevisionsReq) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListRecordRevisionsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListRecordRevisionsReq.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ListRecordRevisionsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRecordRevisionsReq.Merge(m, src)
+}
+func (m *ListRecordRevisionsReq) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListRecordRevisionsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRecordRevisionsReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRecordRevisionsReq proto.InternalMessageInfo
+
+func (m *ListRecordRevisionsReq) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *ListRecordRevisionsReq) GetPageSize() string {
+	if m != nil {
+		return m.PageSize
+	}
+	return ""
+}
+
+func (m *ListRecordRevisionsReq) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type ListRecordRevisionsRes struct {
+	// revisions are from the latest change.
+	Revisions []*RecordRevision `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions"`
+	// next_page_token is empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"nextPageToken"`
+}
+
+func (m *ListRecordRevisionsRes) Reset()      { *m = ListRecordRevisionsRes{} }
+func (*ListRecordRevisionsRes) ProtoMessage() {}
+func (*ListRecordRevisionsRes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_db28b008f832a8c4, []int{22}
+}
+func (m *ListRecordRevisionsRes) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListRecordRevisionsRes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListRecordRevisionsRes.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ListRecordRevisionsRes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRecordRevisionsRes.Merge(m, src)
+}
+func (m *ListRecordRevisionsRes) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListRecordRevisionsRes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRecordRevisionsRes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRecordRevisionsRes proto.InternalMessageInfo
+
+func (m *ListRecordRevisionsRes) GetRevisions() []*RecordRevision {
+	if m != nil {
+		return m.Revisions
+	}
+	return nil
+}
+
+func (m *ListRecordRevisionsRes) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*Record)(nil), "pb.Record")
+	proto.RegisterType((*RecordRevision)(nil), "pb.RecordRevision")
+	proto.RegisterType((*HealthReq)(nil), "pb.HealthReq")
+	proto.RegisterType((*HealthRes)(nil), "pb.HealthRes")
+	proto.RegisterType((*ConfigReq)(nil), "pb.ConfigReq")
+	proto.RegisterType((*ConfigRes)(nil), "pb.ConfigRes")
+	proto.RegisterType((*CreateRecordReq)(nil), "pb.CreateRecordReq")
+	proto.RegisterType((*CreateRecordRes)(nil), "pb.CreateRecordRes")
+	proto.RegisterType((*GetRecordReq)(nil), "pb.GetRecordReq")
+	proto.RegisterType((*GetRecordRes)(nil), "pb.GetRecordRes")
+	proto.RegisterType((*ListRecordReq)(nil), "pb.ListRecordReq")
+	proto.RegisterType((*ListRecordRes)(nil), "pb.ListRecordRes")
+	proto.RegisterType((*UpdateRecordReq)(nil), "pb.UpdateRecordReq")
+	proto.RegisterType((*UpdateRecordRes)(nil), "pb.UpdateRecordRes")
+	proto.RegisterType((*DeleteRecordReq)(nil), "pb.DeleteRecordReq")
+	proto.RegisterType((*DeleteRecordRes)(nil), "pb.DeleteRecordRes")
+	proto.RegisterType((*RestoreRecordReq)(nil), "pb.RestoreRecordReq")
+	proto.RegisterType((*RestoreRecordRes)(nil), "pb.RestoreRecordRes")
+	proto.RegisterType((*BatchGetRecordsReq)(nil), "pb.BatchGetRecordsReq")
+	proto.RegisterType((*BatchGetRecordsRes)(nil), "pb.BatchGetRecordsRes")
+	proto.RegisterType((*BatchGetRecordsResult)(nil), "pb.BatchGetRecordsResult")
+	proto.RegisterType((*ListRecordRevisionsReq)(nil), "pb.ListRecordRevisionsReq")
+	proto.RegisterType((*ListRecordRevisionsRes)(nil), "pb.ListRecordRevisionsRes")
+}
+
+func init() { proto.RegisterFile("pkg/pb/rpc.proto", fileDescriptor_db28b008f832a8c4) }
+
+var fileDescriptor_db28b008f832a8c4 = []byte{
+	// 1958 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0x4f, 0x6f, 0xdb, 0xc8,
+	0x15, 0x37, 0x25, 0x5b, 0x7f, 0xc6, 0x96, 0xed, 0xce, 0x26, 0xae, 0xca, 0xa4, 0x1a, 0x83, 0x6d,
+	0xb7, 0xde, 0x34, 0xb1, 0x62, 0x27, 0xd9, 0x24, 0x06, 0x8c, 0xc2, 0x4a, 0xd0, 0xc4, 0x4d, 0xe2,
+	0xdd, 0x30, 0x4e, 0x0e, 0x0b, 0x2c, 0xb4, 0x94, 0x38, 0x96, 0x08, 0x4b, 0x24, 0x4d, 0x0e, 0xb3,
+	0x76, 0x2e, 0x2d, 0x7a, 0x2c, 0xb0, 0xc0, 0xa2, 0xfd, 0x02, 0x3d, 0x15, 0x45, 0x3f, 0x43, 0x3f,
+	0x40, 0x2f, 0x2d, 0x02, 0xf4, 0xb2, 0x97, 0x72, 0x1b, 0xa6, 0x40, 0x0b, 0x9d, 0x16, 0xbe, 0x14,
+	0x3d, 0x14, 0x2d, 0xe6, 0x0f, 0xc9, 0xa1, 0xc4, 0x6c, 0x93, 0x46, 0xbd, 0x48, 0x6f, 0x7e, 0x7c,
+	0xf3, 0x9b, 0x37, 0x33, 0xef, 0xfd, 0x38, 0x43, 0xb0, 0xec, 0x1e, 0xf6, 0x9a, 0x6e, 0xa7, 0xe9,
+	0xb9, 0xdd, 0x75, 0xd7, 0x73, 0x88, 0x03, 0x0b, 0x6e, 0x47, 0x5d, 0x23, 0x7d, 0xcb, 0x33, 0xdb,
+	0xae, 0xe1, 0x91, 0x93, 0x66, 0xcf, 0x71, 0x7a, 0x03, 0xdc, 0x34, 0x5c, 0xab, 0x69, 0xd8, 0xb6,
+	0x43, 0x0c, 0x62, 0x39, 0xb6, 0xcf, 0xbd, 0xd5, 0xd5, 0xac, 0x67, 0xcf, 0x61, 0x30, 0xb3, 0x84,
+	0xc7, 0xf7, 0x65, 0x0f, 0x63, 0x68, 0x3c, 0xb3, 0xec, 0x1e, 0x31, 0x06, 0x87, 0xd8, 0x6b, 0x1a,
+	0x84, 0xb9, 0x08, 0x47, 0x24, 0x06, 0x62, 0xad, 0x4e, 0x70, 0xd0, 0x24, 0xd6, 0x10, 0xfb, 0xc4,
+	0x18, 0xba, 0xdc, 0x41, 0xfb, 0x43, 0x11, 0x94, 0x74, 0xdc, 0x75, 0x3c, 0x13, 0xae, 0x80, 0x82,
+	0x65, 0xd6, 0x95, 0x55, 0x65, 0xad, 0xda, 0x2a, 0x45, 0x21, 0x2a, 0xec, 0xde, 0xd6, 0x0b, 0x96,
+	0x09, 0x2f, 0x81, 0x32, 0xe9, 0xe3, 0xb6, 0x1d, 0x0c, 0xeb, 0x85, 0x55, 0x65, 0xad, 0xd8, 0x3a,
+	0x13, 0x85, 0xa8, 0xb4, 0xdf, 0xc7, 0x7b, 0xc1, 0x70, 0x14, 0xa2, 0x12, 0x61, 0x96, 0x2e, 0xfe,
+	0x63, 0x77, 0x9f, 0x78, 0xf5, 0x22, 0xe3, 0x8a, 0xdd, 0x1f, 0x11, 0x4f, 0xb8, 0x3f, 0x22, 0x9e,
+	0x2e, 0xfe, 0xe1, 0xc7, 0x00, 0x74, 0x3d, 0x6c, 0x10, 0x6c, 0xb6, 0x0d, 0x52, 0x9f, 0x5d, 0x55,
+	0xd6, 0xe6, 0x37, 0xd5, 0x75, 0x1e, 0xf6, 0x7a, 0x1c, 0xf6, 0xfa, 0x7e, 0x1c, 0x76, 0x4b, 0x8b,
+	0x42, 0x54, 0xbd, 0xc5, 0x7b, 0xec, 0x90, 0x51, 0x88, 0xaa, 0xdd, 0xb8, 0xf1, 0xf9, 0x97, 0x48,
+	0xf9, 0xd5, 0x97, 0x48, 0xd1, 0x53, 0x88, 0xd2, 0x07, 0xae, 0x19, 0xd3, 0xcf, 0xbd, 0x1e, 0xfd,
+	0x63, 0xd7, 0x4c, 0xe9, 0x03, 0xd7, 0x1c, 0xa7, 0x4f, 0x20, 0x4a, 0x6f, 0xe2, 0x01, 0x16, 0xf4,
+	0xa5, 0xd7, 0xa3, 0xbf, 0xcd, 0x7b, 0x70, 0x7a, 0x33, 0x6e, 0xa4, 0xf4, 0x09, 0x04, 0x2f, 0x83,
+	0xf2, 0x53, 0xec, 0xf9, 0x96, 0x63, 0xd7, 0xcb, 0x6c, 0xe9, 0x57, 0xa2, 0x10, 0x95, 0x9f, 0x70,
+	0x68, 0x14, 0xa2, 0xf8, 0xa9, 0x1e, 0x1b, 0xda, 0x9f, 0x8b, 0x60, 0x91, 0xef, 0xa7, 0x8e, 0x9f,
+	0x5a, 0x14, 0x82, 0xe7, 0x93, 0x7d, 0x2d, 0xb6, 0x16, 0xf8, 0xbe, 0x8e, 0x42, 0x54, 0xb0, 0x4c,
+	0xb6, 0xbb, 0xd7, 0x40, 0xd5, 0x63, 0xfe, 0x6d, 0xcb, 0x64, 0xfb, 0x5b, 0x6d, 0xd5, 0xa3, 0x10,
+	0x55, 0x38, 0x09, 0x73, 0xad, 0x70, 0x87, 0x5d, 0x53, 0x4f, 0x2c, 0x78, 0x11, 0x94, 0x8c, 0x2e,
+	0x4d, 0x5a, 0x79, 0x93, 0x77, 0x18, 0x42, 0x37, 0x99, 0x3f, 0xd3, 0xc5, 0x3f, 0x5c, 0x03, 0x73,
+	0x46, 0x97, 0x38, 0x1e, 0xdb, 0xdf, 0x6a, 0x0b, 0x46, 0x21, 0x9a, 0xdb, 0xa1, 0xc0, 0x28, 0x44,
+	0xfc, 0x89, 0xce, 0xff, 0xe0, 0x4d, 0x00, 0x3c, 0x7c, 0x14, 0x60, 0x9f, 0xd0, 0x78, 0xe6, 0x98,
+	0xbb, 0x4a, 0x17, 0x4d, 0xe7, 0x28, 0x0b, 0xa8, 0x2a, 0x5c, 0x76, 0x4d, 0x3d, 0x35, 0xe1, 0x0e,
+	0xa8, 0x3a, 0x03, 0xb3, 0xfd, 0xd4, 0x18, 0x04, 0x58, 0x6c, 0x05, 0x58, 0x77, 0x3b, 0xeb, 0x7c,
+	0x26, 0x7c, 0x56, 0x1f, 0x0c, 0xcc, 0x27, 0xf4, 0x39, 0x9d, 0x95, 0x23, 0x6c, 0x3d, 0xb1, 0x28,
+	0x85, 0x8d, 0x3f, 0x15, 0x14, 0xe5, 0x7c, 0x8a, 0x3d, 0xfc, 0x69, 0x42, 0x61, 0x0b, 0x5b, 0x4f,
+	0xac, 0xb1, 0x7c, 0xae, 0x4c, 0x39, 0x9f, 0xb5, 0x79, 0x50, 0xbd, 0x8b, 0x8d, 0x01, 0xe9, 0xeb,
+	0xf8, 0x48, 0x3b, 0x97, 0x36, 0x7c, 0xb8, 0x08, 0x0a, 0xce, 0x21, 0xdb, 0xe6, 0x8a, 0x5e, 0x70,
+	0x0e, 0xa9, 0xe7, 0x2d, 0xc7, 0x3e, 0xb0, 0x7a, 0xd4, 0xf3, 0x4e, 0xda, 0xf0, 0xe1, 0x0a, 0x28,
+	0x61, 0xdb, 0xe8, 0x0c, 0xb0, 0xf0, 0x16, 0x2d, 0xb8, 0x0c, 0x8a, 0x49, 0x91, 0xeb, 0xd4, 0xa4,
+	0x48, 0x52, 0xc7, 0x3a, 0x35, 0xb5, 0x7f, 0x17, 0xc0, 0x12, 0x8f, 0x36, 0xce, 0xb2, 0x23, 0xf8,
+	0x51, 0x2a, 0x10, 0x3c, 0xcb, 0x76, 0xf2, 0x04, 0xe2, 0x34, 0x44, 0xef, 0x3d, 0x35, 0x06, 0x16,
+	0x2d, 0x9d, 0x2d, 0xad, 0x47, 0xf0, 0xf6, 0xa5, 0xcd, 0x8d, 0xab, 0xd7, 0xaf, 0xde, 0xb8, 0xf2,
+	0xfe, 0xd5, 0x1b, 0x17, 0x07, 0x04, 0x6f, 0x27, 0xcd, 0xeb, 0x5a, 0xa2, 0x26, 0xf7, 0x53, 0x35,
+	0xe1, 0xc9, 0x79, 0x25, 0x4f, 0x4d, 0x4e, 0x43, 0x74, 0x2e, 0xe5, 0xa6, 0x29, 0x61, 0x79, 0xd8,
+	0xbc, 0x38, 0x34, 0x8e, 0xb7, 0x37, 0xaf, 0x5d, 0xd3, 0x5e, 0x21, 0x36, 0xc5, 0x69, 0x8b, 0x4d,
+	0x17, 0x2c, 0x59, 0x26, 0x1e, 0xba, 0x0e, 0xc1, 0x76, 0xf7, 0xa4, 0x7d, 0x88, 0x4f, 0x44, 0xc2,
+	0x6f, 0x45, 0x21, 0x5a, 0xdc, 0x4d, 0x1f, 0xdd, 0xc3, 0x27, 0xa3, 0x10, 0x2d, 0x5a, 0x19, 0xe4,
+	0x34, 0x44, 0x30, 0x9d, 0x44, 0x12, 0xfb, 0x98, 0x97, 0x76, 0x6f, 0x7c, 0x03, 0x7c, 0xb8, 0x0e,
+	0x4a, 0xbc, 0x30, 0xeb, 0xca, 0x44, 0xce, 0x02, 0xba, 0x5e, 0xc2, 0x55, 0x78, 0x6d, 0x55, 0x7e,
+	0xfb, 0xaf, 0xcf, 0xde, 0x2d, 0x6e, 0x5e, 0xde, 0xd0, 0x1e, 0x82, 0x85, 0x3b, 0x98, 0xa4, 0x5b,
+	0xb9, 0x23, 0xbd, 0x03, 0x36, 0x64, 0xad, 0x38, 0x0d, 0x51, 0x3d, 0x67, 0x85, 0x83, 0xc0, 0x32,
+	0xb5, 0x5f, 0xfc, 0xe3, 0xb3, 0x77, 0x67, 0x89, 0x17, 0x60, 0x2a, 0x28, 0xda, 0xdd, 0x0c, 0xe5,
+	0xff, 0x1e, 0xdc, 0x65, 0xed, 0x9f, 0x15, 0x50, 0xbb, 0x6f, 0xf9, 0x52, 0x78, 0x0f, 0xc1, 0xac,
+	0x6f, 0x3d, 0xc3, 0x22, 0xc0, 0x6d, 0x5a, 0x8e, 0x1f, 0x1a, 0x3d, 0xfc, 0xc8, 0x7a, 0x46, 0xcb,
+	0x91, 0x3d, 0x3b, 0x0d, 0xd1, 0xf9, 0x34, 0x50, 0x67, 0x68, 0x11, 0x3c, 0x74, 0xc9, 0xc9, 0x45,
+	0x3b, 0x18, 0x62, 0xcf, 0xea, 0x6a, 0x3f, 0x4f, 0x82, 0x65, 0xee, 0xf0, 0x01, 0x98, 0x75, 0x8d,
+	0x1e, 0x16, 0xd9, 0x75, 0x33, 0x0a, 0xd1, 0x2c, 0xa5, 0xa4, 0x74, 0x14, 0x7f, 0x03, 0x3a, 0xea,
+	0x0e, 0x5b, 0x00, 0xd0, 0xff, 0x36, 0x71, 0x0e, 0x71, 0xac, 0x8d, 0xdf, 0xa1, 0x59, 0x44, 0x49,
+	0xf7, 0x29, 0x48, 0xb3, 0xc8, 0x8d, 0x1b, 0x69, 0xf7, 0x14, 0x83, 0x5b, 0xa0, 0xe2, 0x78, 0x26,
+	0xf6, 0xda, 0x9d, 0x38, 0x7f, 0x10, 0x95, 0xfd, 0x0f, 0x28, 0xd6, 0xa2, 0x89, 0x53, 0x76, 0xb8,
+	0x99, 0xf6, 0x8e, 0x11, 0xd8, 0x05, 0xf3, 0xa2, 0x16, 0xdb, 0x43, 0xcb, 0x16, 0x02, 0x7a, 0x8b,
+	0x06, 0xc0, 0xeb, 0xf1, 0x81, 0xc5, 0x02, 0x20, 0x71, 0xe3, 0xf5, 0xe7, 0x97, 0xf6, 0xc9, 0x0c,
+	0x62, 0x1c, 0xd7, 0x4b, 0x13, 0x83, 0x18, 0xc7, 0xd2, 0x20, 0xc6, 0xf1, 0x9b, 0x0f, 0x62, 0x1c,
+	0xcb, 0x95, 0x5f, 0xfe, 0xda, 0xca, 0xcf, 0x29, 0x9a, 0x94, 0x30, 0xae, 0x7c, 0x0c, 0x16, 0x05,
+	0x5b, 0xdb, 0xf5, 0xf0, 0x81, 0x75, 0xcc, 0xa4, 0xb9, 0xda, 0xfa, 0x61, 0x14, 0xa2, 0x05, 0x4e,
+	0xfa, 0x21, 0xc3, 0x47, 0x21, 0x5a, 0x20, 0x52, 0xfb, 0xbf, 0x0d, 0x90, 0x71, 0x86, 0x7b, 0xa0,
+	0x96, 0x08, 0xcc, 0x01, 0xc1, 0x5e, 0xbd, 0xca, 0x46, 0x79, 0x8f, 0x8e, 0x12, 0xeb, 0x08, 0xc5,
+	0xe9, 0x28, 0x5d, 0xa9, 0x2d, 0xf1, 0xc9, 0x30, 0xd4, 0xc1, 0x62, 0xcc, 0xd7, 0xc1, 0x07, 0x8e,
+	0x87, 0xeb, 0x80, 0x11, 0xfe, 0x20, 0x0a, 0x51, 0x4d, 0x10, 0xb6, 0xd8, 0x83, 0x51, 0x88, 0x6a,
+	0x5d, 0x19, 0x48, 0x29, 0xb3, 0x38, 0x8d, 0x31, 0x39, 0x12, 0xb1, 0x18, 0xe7, 0xd3, 0x18, 0xe3,
+	0x93, 0x4f, 0x1c, 0x63, 0x20, 0xb5, 0xa5, 0x18, 0x65, 0x98, 0xc6, 0x18, 0xf3, 0x89, 0x18, 0x17,
+	0xd2, 0x18, 0x05, 0x61, 0x1a, 0x63, 0x20, 0x03, 0x52, 0x8c, 0x19, 0x1c, 0xfe, 0x04, 0x2c, 0x59,
+	0x76, 0x77, 0x10, 0x98, 0xb8, 0x2d, 0x4e, 0x43, 0xf5, 0x1a, 0x23, 0x7d, 0xc2, 0x94, 0x94, 0x3f,
+	0x12, 0xe7, 0x28, 0xa6, 0xa4, 0x19, 0xe4, 0x34, 0x44, 0xdf, 0xcb, 0xcb, 0x37, 0xc7, 0xc6, 0xce,
+	0xc1, 0x36, 0x1d, 0x6f, 0xf5, 0xc0, 0x18, 0xf8, 0x58, 0xda, 0xc6, 0x31, 0x06, 0xed, 0x6f, 0x85,
+	0xac, 0xf6, 0xf8, 0x70, 0x03, 0x94, 0xb9, 0x42, 0xf9, 0x75, 0x65, 0xb5, 0x38, 0x26, 0x64, 0xf3,
+	0xb4, 0x40, 0xb9, 0xed, 0xeb, 0xb1, 0x1f, 0xfc, 0x31, 0x58, 0xb2, 0xf1, 0x31, 0x69, 0x4b, 0x8a,
+	0xc0, 0x65, 0x86, 0xbe, 0x57, 0x6a, 0x7b, 0xf8, 0x98, 0xc8, 0xaa, 0x50, 0xb3, 0x65, 0x40, 0xcf,
+	0x36, 0xe1, 0x36, 0x98, 0x27, 0x0e, 0x31, 0x06, 0xed, 0xae, 0x13, 0xd8, 0xfc, 0xdd, 0x55, 0x6c,
+	0x9d, 0x8f, 0x42, 0x04, 0xf6, 0x29, 0x7c, 0x8b, 0xa2, 0xa3, 0x10, 0x01, 0x92, 0xb4, 0x74, 0xc9,
+	0x86, 0xdf, 0x15, 0x32, 0x47, 0xf5, 0x64, 0xae, 0xb5, 0x3c, 0x2e, 0x73, 0x42, 0xbd, 0xae, 0x01,
+	0x26, 0x43, 0x6d, 0x26, 0xb2, 0x73, 0xcc, 0xb5, 0x3e, 0x26, 0xb2, 0x15, 0x57, 0xd8, 0x7a, 0x62,
+	0xc1, 0x0d, 0x50, 0xe9, 0x1b, 0x7e, 0x7b, 0x48, 0xf7, 0x9e, 0x8a, 0x41, 0x85, 0x9f, 0x53, 0xef,
+	0x1a, 0xfe, 0x03, 0xbe, 0xeb, 0xe5, 0x3e, 0x37, 0xf5, 0xd8, 0x90, 0x54, 0xfe, 0x8f, 0x05, 0xb0,
+	0xc4, 0xb3, 0x64, 0x9a, 0xaf, 0x21, 0xf9, 0x50, 0x52, 0xf8, 0x3f, 0x1e, 0x4a, 0x8a, 0x6f, 0x7f,
+	0x28, 0xd9, 0x4b, 0x0f, 0xf9, 0xb3, 0x2c, 0xd2, 0xab, 0xf9, 0x87, 0xfc, 0xd3, 0x10, 0xa9, 0x79,
+	0x59, 0x4d, 0xa3, 0xde, 0xd0, 0xd2, 0x2b, 0xc0, 0xbd, 0xf1, 0xf5, 0x7c, 0x9b, 0x77, 0xf0, 0x3e,
+	0x58, 0xe2, 0x25, 0x31, 0xd5, 0x33, 0xc2, 0xcd, 0x71, 0x56, 0x5f, 0xba, 0xa5, 0x54, 0x27, 0x6f,
+	0x29, 0x52, 0x40, 0x8f, 0xc1, 0xb2, 0x8e, 0x7d, 0xe2, 0x78, 0x49, 0xdf, 0xa9, 0x44, 0x74, 0x7f,
+	0x82, 0xf6, 0x6d, 0x56, 0xed, 0x7d, 0x00, 0x5b, 0x06, 0xe9, 0xf6, 0x93, 0x83, 0x90, 0x4f, 0xc3,
+	0x5c, 0x05, 0x45, 0x4b, 0xa8, 0x47, 0xb5, 0xb5, 0x18, 0x85, 0xa8, 0xb8, 0x7b, 0xdb, 0x1f, 0x85,
+	0x88, 0xa2, 0x3a, 0xfd, 0xd1, 0x0e, 0x73, 0xfa, 0xf9, 0xf0, 0x1e, 0x55, 0x1e, 0x3f, 0x18, 0x90,
+	0x58, 0x79, 0xbe, 0x45, 0x03, 0x99, 0x74, 0x0c, 0x06, 0x84, 0x17, 0x1e, 0xb7, 0x29, 0x75, 0xdc,
+	0x51, 0x8f, 0x0d, 0x29, 0xc8, 0x5f, 0x2b, 0xe0, 0x6c, 0x2e, 0xc9, 0xd7, 0xef, 0x05, 0xbc, 0x91,
+	0x2c, 0x4b, 0x61, 0x62, 0x59, 0xce, 0xa4, 0xcb, 0x42, 0x0b, 0xc1, 0xcb, 0x2c, 0x10, 0x95, 0x17,
+	0xdb, 0x21, 0xed, 0x03, 0x27, 0xb0, 0x4d, 0x56, 0x39, 0x15, 0x71, 0xa5, 0x72, 0xc8, 0x8f, 0x28,
+	0xc6, 0xae, 0x54, 0xc2, 0xd6, 0x13, 0x4b, 0x3b, 0x55, 0xc0, 0x8a, 0xac, 0xc5, 0xfc, 0x5e, 0xeb,
+	0x4f, 0x49, 0x28, 0xe2, 0x33, 0x65, 0x61, 0x7a, 0x67, 0xca, 0x29, 0x1c, 0x02, 0xb5, 0xdf, 0xbd,
+	0x6a, 0xd2, 0x3e, 0xbc, 0x4f, 0xaf, 0xec, 0xa2, 0x2d, 0x32, 0x02, 0xa6, 0x7b, 0x10, 0xbb, 0xc6,
+	0xd7, 0x66, 0xe1, 0xc8, 0xaf, 0xcd, 0x31, 0x4b, 0x6a, 0x4e, 0xf3, 0x25, 0x95, 0x26, 0xd7, 0xe6,
+	0xf3, 0x12, 0xa8, 0xde, 0x71, 0x76, 0xf8, 0xa7, 0x29, 0x78, 0x1d, 0x94, 0xf8, 0x45, 0x15, 0xd6,
+	0x68, 0xa0, 0xc9, 0x0d, 0x56, 0xcd, 0x34, 0x7d, 0x6d, 0xe9, 0x67, 0x7f, 0xfa, 0xeb, 0x2f, 0x0b,
+	0x55, 0x58, 0x6e, 0xf6, 0xb9, 0xfb, 0x75, 0x50, 0xe2, 0xf7, 0x56, 0xde, 0x31, 0xb9, 0xd0, 0xaa,
+	0x99, 0xa6, 0xdc, 0xb1, 0xcb, 0xdd, 0x1f, 0x83, 0x05, 0xf9, 0x96, 0x04, 0xdf, 0x61, 0xfe, 0xd9,
+	0x8b, 0xab, 0x9a, 0x03, 0xfa, 0xda, 0x39, 0x46, 0x75, 0x56, 0x9b, 0x67, 0x5f, 0xe7, 0x44, 0x69,
+	0xc7, 0x19, 0xfc, 0x10, 0x54, 0x93, 0x6a, 0x81, 0xcb, 0xb4, 0xbb, 0x7c, 0x7d, 0x52, 0xc7, 0x11,
+	0x5f, 0x5b, 0x65, 0x6c, 0x2a, 0x5c, 0x96, 0xd8, 0xfc, 0xe6, 0x96, 0x95, 0x52, 0xde, 0x05, 0x20,
+	0xdd, 0x67, 0xf8, 0x0d, 0xca, 0x90, 0xb9, 0xf4, 0xa8, 0x13, 0x90, 0xaf, 0x9d, 0x61, 0xac, 0x8b,
+	0x70, 0x41, 0x66, 0xa5, 0x73, 0x96, 0x85, 0x9f, 0xcf, 0x79, 0xec, 0xd5, 0xaa, 0xe6, 0x80, 0xc9,
+	0x9c, 0xd5, 0xc9, 0x28, 0x95, 0x0b, 0x50, 0x07, 0x0b, 0xb2, 0x58, 0x73, 0xda, 0xb1, 0x97, 0x82,
+	0x9a, 0x03, 0xfa, 0x5a, 0x9d, 0xd1, 0xc2, 0x0b, 0x13, 0xb4, 0xf0, 0x63, 0x50, 0xcb, 0xc8, 0x2d,
+	0x3c, 0xc3, 0x13, 0x38, 0x2b, 0xec, 0x6a, 0x1e, 0x9a, 0xac, 0xa9, 0x56, 0x1f, 0xa7, 0x6d, 0x7a,
+	0xdc, 0x15, 0x7e, 0x02, 0x96, 0xc6, 0x94, 0x0d, 0xae, 0xe4, 0x6a, 0xe6, 0x91, 0x9a, 0x8f, 0xfb,
+	0xda, 0xb7, 0xd9, 0x20, 0xdf, 0xd4, 0xce, 0x66, 0x06, 0xe9, 0x08, 0x47, 0x78, 0x04, 0xde, 0xc9,
+	0xa9, 0x4e, 0xa8, 0x8e, 0xef, 0x55, 0xaa, 0x55, 0xea, 0xab, 0x9f, 0xf9, 0x9a, 0xc6, 0x46, 0x3b,
+	0x0f, 0xd5, 0x9c, 0x29, 0x09, 0xb7, 0xd6, 0x27, 0xcf, 0x5f, 0x34, 0x66, 0xbe, 0x78, 0xd1, 0x98,
+	0xf9, 0xea, 0x45, 0x43, 0xf9, 0x69, 0xd4, 0x50, 0x7e, 0x13, 0x35, 0x94, 0xdf, 0x47, 0x0d, 0xe5,
+	0x79, 0xd4, 0x50, 0xfe, 0x12, 0x35, 0x94, 0xbf, 0x47, 0x8d, 0x99, 0xaf, 0xa2, 0x86, 0xf2, 0xf9,
+	0xcb, 0xc6, 0xcc, 0xf3, 0x97, 0x8d, 0x99, 0x2f, 0x5e, 0x36, 0x66, 0x3e, 0xba, 0xd0, 0xb3, 0x48,
+	0x3f, 0xe8, 0xac, 0x77, 0x9d, 0x61, 0x53, 0x94, 0xe3, 0x3e, 0xff, 0x52, 0xdc, 0x73, 0x2e, 0x89,
+	0x4f, 0xc7, 0x4d, 0xfe, 0xc1, 0xba, 0x53, 0x62, 0x9f, 0x40, 0xae, 0xfc, 0x67, 0x00, 0xdb, 0x06,
+	0x0f, 0x5d, 0xc1, 0x16, 0x00, 0x00,
+}
+
+func (this *Record) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*Record)
 	if !ok {
-		that2, ok := that.(GetRecordReq)
+		that2, ok := that.(Record)
 		if ok {
 			that1 = &that2
 		} else {
@@ -859,16 +1562,46 @@ func (this *GetRecordReq) Equal(that interface{}) bool {
 	if this.ID != that1.ID {
 		return false
 	}
+	if this.TheNum != that1.TheNum {
+		return false
+	}
+	if this.TheStr != that1.TheStr {
+		return false
+	}
+	if that1.CreatedAt == nil {
+		if this.CreatedAt != nil {
+			return false
+		}
+	} else if !this.CreatedAt.Equal(*that1.CreatedAt) {
+		return false
+	}
+	if that1.UpdatedAt == nil {
+		if this.UpdatedAt != nil {
+			return false
+		}
+	} else if !this.UpdatedAt.Equal(*that1.UpdatedAt) {
+		return false
+	}
+	if that1.DeletedAt == nil {
+		if this.DeletedAt != nil {
+			return false
+		}
+	} else if !this.DeletedAt.Equal(*that1.DeletedAt) {
+		return false
+	}
+	if this.Version != that1.Version {
+		return false
+	}
 	return true
 }
-func (this *GetRecordRes) Equal(that interface{}) bool {
+func (this *RecordRevision) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*GetRecordRes)
+	that1, ok := that.(*RecordRevision)
 	if !ok {
-		that2, ok := that.(GetRecordRes)
+		that2, ok := that.(RecordRevision)
 		if ok {
 			that1 = &that2
 		} else {
@@ -880,19 +1613,44 @@ func (this *GetRecordRes) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !this.Record.Equal(that1.Record) {
+	if this.ID != that1.ID {
+		return false
+	}
+	if this.RecordID != that1.RecordID {
+		return false
+	}
+	if this.Action != that1.Action {
+		return false
+	}
+	if this.Actor != that1.Actor {
+		return false
+	}
+	if this.RequestID != that1.RequestID {
+		return false
+	}
+	if !this.OldValue.Equal(that1.OldValue) {
+		return false
+	}
+	if !this.NewValue.Equal(that1.NewValue) {
+		return false
+	}
+	if that1.CreatedAt == nil {
+		if this.CreatedAt != nil {
+			return false
+		}
+	} else if !this.CreatedAt.Equal(*that1.CreatedAt) {
 		return false
 	}
 	return true
 }
-func (this *ListRecordReq) Equal(that interface{}) bool {
+func (this *HealthReq) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*ListRecordReq)
+	that1, ok := that.(*HealthReq)
 	if !ok {
-		that2, ok := that.(ListRecordReq)
+		that2, ok := that.(HealthReq)
 		if ok {
 			that1 = &that2
 		} else {
@@ -904,22 +1662,16 @@ func (this *ListRecordReq) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.PageSize != that1.PageSize {
-		return false
-	}
-	if this.Page != that1.Page {
-		return false
-	}
 	return true
 }
-func (this *ListRecordRes) Equal(that interface{}) bool {
+func (this *HealthRes) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*ListRecordRes)
+	that1, ok := that.(*HealthRes)
 	if !ok {
-		that2, ok := that.(ListRecordRes)
+		that2, ok := that.(HealthRes)
 		if ok {
 			that1 = &that2
 		} else {
@@ -931,1095 +1683,5035 @@ func (this *ListRecordRes) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if len(this.Records) != len(that1.Records) {
+	if this.Ok != that1.Ok {
 		return false
 	}
-	for i := range this.Records {
-		if !this.Records[i].Equal(that1.Records[i]) {
-			return false
-		}
-	}
 	return true
 }
-func (this *Record) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *ConfigReq) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 9)
-	s = append(s, "&pb.Record{")
-	s = append(s, "ID: "+fmt.Sprintf("%#v", this.ID)+",\n")
-	s = append(s, "TheNum: "+fmt.Sprintf("%#v", this.TheNum)+",\n")
-	s = append(s, "TheStr: "+fmt.Sprintf("%#v", this.TheStr)+",\n")
-	s = append(s, "CreatedAt: "+fmt.Sprintf("%#v", this.CreatedAt)+",\n")
-	s = append(s, "UpdatedAt: "+fmt.Sprintf("%#v", this.UpdatedAt)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *HealthReq) GoString() string {
-	if this == nil {
-		return "nil"
+
+	that1, ok := that.(*ConfigReq)
+	if !ok {
+		that2, ok := that.(ConfigReq)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s := make([]string, 0, 4)
-	s = append(s, "&pb.HealthReq{")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *HealthRes) GoString() string {
-	if this == nil {
-		return "nil"
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&pb.HealthRes{")
-	s = append(s, "Ok: "+fmt.Sprintf("%#v", this.Ok)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *ConfigReq) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *ConfigRes) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 4)
-	s = append(s, "&pb.ConfigReq{")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *ConfigRes) GoString() string {
-	if this == nil {
-		return "nil"
+
+	that1, ok := that.(*ConfigRes)
+	if !ok {
+		that2, ok := that.(ConfigRes)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s := make([]string, 0, 7)
-	s = append(s, "&pb.ConfigRes{")
-	s = append(s, "Enable: "+fmt.Sprintf("%#v", this.Enable)+",\n")
-	s = append(s, "Num: "+fmt.Sprintf("%#v", this.Num)+",\n")
-	s = append(s, "Str: "+fmt.Sprintf("%#v", this.Str)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *CreateRecordReq) GoString() string {
-	if this == nil {
-		return "nil"
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s := make([]string, 0, 7)
-	s = append(s, "&pb.CreateRecordReq{")
-	s = append(s, "TheNum: "+fmt.Sprintf("%#v", this.TheNum)+",\n")
-	s = append(s, "TheStr: "+fmt.Sprintf("%#v", this.TheStr)+",\n")
-	s = append(s, "CreatedAt: "+fmt.Sprintf("%#v", this.CreatedAt)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *CreateRecordRes) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.Enable != that1.Enable {
+		return false
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&pb.CreateRecordRes{")
-	if this.Record != nil {
-		s = append(s, "Record: "+fmt.Sprintf("%#v", this.Record)+",\n")
+	if this.Num != that1.Num {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *GetRecordReq) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.Str != that1.Str {
+		return false
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&pb.GetRecordReq{")
-	s = append(s, "ID: "+fmt.Sprintf("%#v", this.ID)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *GetRecordRes) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *CreateRecordReq) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&pb.GetRecordRes{")
-	if this.Record != nil {
-		s = append(s, "Record: "+fmt.Sprintf("%#v", this.Record)+",\n")
+
+	that1, ok := that.(*CreateRecordReq)
+	if !ok {
+		that2, ok := that.(CreateRecordReq)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *ListRecordReq) GoString() string {
-	if this == nil {
-		return "nil"
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&pb.ListRecordReq{")
-	s = append(s, "PageSize: "+fmt.Sprintf("%#v", this.PageSize)+",\n")
-	s = append(s, "Page: "+fmt.Sprintf("%#v", this.Page)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *ListRecordRes) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.TheNum != that1.TheNum {
+		return false
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&pb.ListRecordRes{")
-	if this.Records != nil {
-		s = append(s, "Records: "+fmt.Sprintf("%#v", this.Records)+",\n")
+	if this.TheStr != that1.TheStr {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func valueToGoStringRpc(v interface{}, typ string) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
-		return "nil"
+	if that1.CreatedAt == nil {
+		if this.CreatedAt != nil {
+			return false
+		}
+	} else if !this.CreatedAt.Equal(*that1.CreatedAt) {
+		return false
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
-}
-
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// GoAmazingClient is the client API for GoAmazing service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type GoAmazingClient interface {
-	// Health check api for k8s.
-	Health(ctx context.Context, in *HealthReq, opts ...grpc.CallOption) (*HealthRes, error)
-	Config(ctx context.Context, in *ConfigReq, opts ...grpc.CallOption) (*ConfigRes, error)
-	CreateRecord(ctx context.Context, in *CreateRecordReq, opts ...grpc.CallOption) (*CreateRecordRes, error)
-	GetRecord(ctx context.Context, in *GetRecordReq, opts ...grpc.CallOption) (*GetRecordRes, error)
-	ListRecord(ctx context.Context, in *ListRecordReq, opts ...grpc.CallOption) (*ListRecordRes, error)
-}
-
-type goAmazingClient struct {
-	cc *grpc.ClientConn
-}
-
-func NewGoAmazingClient(cc *grpc.ClientConn) GoAmazingClient {
-	return &goAmazingClient{cc}
-}
-
-func (c *goAmazingClient) Health(ctx context.Context, in *HealthReq, opts ...grpc.CallOption) (*HealthRes, error) {
-	out := new(HealthRes)
-	err := c.cc.Invoke(ctx, "/pb.GoAmazing/Health", in, out, opts...)
-	if err != nil {
-		return nil, err
+	if this.IdempotencyKey != that1.IdempotencyKey {
+		return false
 	}
-	return out, nil
+	return true
 }
-
-func (c *goAmazingClient) Config(ctx context.Context, in *ConfigReq, opts ...grpc.CallOption) (*ConfigRes, error) {
-	out := new(ConfigRes)
-	err := c.cc.Invoke(ctx, "/pb.GoAmazing/Config", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (this *CreateRecordRes) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return out, nil
-}
 
-func (c *goAmazingClient) CreateRecord(ctx context.Context, in *CreateRecordReq, opts ...grpc.CallOption) (*CreateRecordRes, error) {
-	out := new(CreateRecordRes)
-	err := c.cc.Invoke(ctx, "/pb.GoAmazing/CreateRecord", in, out, opts...)
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*CreateRecordRes)
+	if !ok {
+		that2, ok := that.(CreateRecordRes)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return out, nil
-}
-
-func (c *goAmazingClient) GetRecord(ctx context.Context, in *GetRecordReq, opts ...grpc.CallOption) (*GetRecordRes, error) {
-	out := new(GetRecordRes)
-	err := c.cc.Invoke(ctx, "/pb.GoAmazing/GetRecord", in, out, opts...)
-	if err != nil {
-		return nil, err
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return out, nil
-}
-
-func (c *goAmazingClient) ListRecord(ctx context.Context, in *ListRecordReq, opts ...grpc.CallOption) (*ListRecordRes, error) {
-	out := new(ListRecordRes)
-	err := c.cc.Invoke(ctx, "/pb.GoAmazing/ListRecord", in, out, opts...)
-	if err != nil {
-		return nil, err
+	if !this.Record.Equal(that1.Record) {
+		return false
 	}
-	return out, nil
-}
-
-// GoAmazingServer is the server API for GoAmazing service.
-type GoAmazingServer interface {
-	// Health check api for k8s.
-	Health(context.Context, *HealthReq) (*HealthRes, error)
-	Config(context.Context, *ConfigReq) (*ConfigRes, error)
-	CreateRecord(context.Context, *CreateRecordReq) (*CreateRecordRes, error)
-	GetRecord(context.Context, *GetRecordReq) (*GetRecordRes, error)
-	ListRecord(context.Context, *ListRecordReq) (*ListRecordRes, error)
-}
-
-// UnimplementedGoAmazingServer can be embedded to have forward compatible implementations.
-type UnimplementedGoAmazingServer struct {
+	return true
 }
+func (this *GetRecordReq) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (*UnimplementedGoAmazingServer) Health(ctx context.Context, req *HealthReq) (*HealthRes, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Health not implemented")
-}
-func (*UnimplementedGoAmazingServer) Config(ctx context.Context, req *ConfigReq) (*ConfigRes, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Config not implemented")
-}
-func (*UnimplementedGoAmazingServer) CreateRecord(ctx context.Context, req *CreateRecordReq) (*CreateRecordRes, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateRecord not implemented")
-}
-func (*UnimplementedGoAmazingServer) GetRecord(ctx context.Context, req *GetRecordReq) (*GetRecordRes, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetRecord not implemented")
-}
-func (*UnimplementedGoAmazingServer) ListRecord(ctx context.Context, req *ListRecordReq) (*ListRecordRes, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListRecord not implemented")
+	that1, ok := that.(*GetRecordReq)
+	if !ok {
+		that2, ok := that.(GetRecordReq)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.ID != that1.ID {
+		return false
+	}
+	return true
 }
+func (this *GetRecordRes) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func RegisterGoAmazingServer(s *grpc.Server, srv GoAmazingServer) {
-	s.RegisterService(&_GoAmazing_serviceDesc, srv)
+	that1, ok := that.(*GetRecordRes)
+	if !ok {
+		that2, ok := that.(GetRecordRes)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Record.Equal(that1.Record) {
+		return false
+	}
+	return true
 }
+func (this *ListRecordReq) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func _GoAmazing_Health_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(HealthReq)
-	if err := dec(in); err != nil {
-		return nil, err
+	that1, ok := that.(*ListRecordReq)
+	if !ok {
+		that2, ok := that.(ListRecordReq)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if interceptor == nil {
-		return srv.(GoAmazingServer).Health(ctx, in)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pb.GoAmazing/Health",
+	if this.PageSize != that1.PageSize {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GoAmazingServer).Health(ctx, req.(*HealthReq))
+	if this.Page != that1.Page {
+		return false
 	}
-	return interceptor(ctx, in, info, handler)
-}
-
-func _GoAmazing_Config_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ConfigReq)
-	if err := dec(in); err != nil {
-		return nil, err
+	if this.PageToken != that1.PageToken {
+		return false
 	}
-	if interceptor == nil {
-		return srv.(GoAmazingServer).Config(ctx, in)
+	if this.OrderBy != that1.OrderBy {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pb.GoAmazing/Config",
+	if this.TheNumMin != that1.TheNumMin {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GoAmazingServer).Config(ctx, req.(*ConfigReq))
+	if this.TheNumMax != that1.TheNumMax {
+		return false
 	}
-	return interceptor(ctx, in, info, handler)
+	if this.TheStr != that1.TheStr {
+		return false
+	}
+	if this.TheStrPrefix != that1.TheStrPrefix {
+		return false
+	}
+	if this.CreatedAfter != that1.CreatedAfter {
+		return false
+	}
+	if this.CreatedBefore != that1.CreatedBefore {
+		return false
+	}
+	if this.UpdatedAfter != that1.UpdatedAfter {
+		return false
+	}
+	if this.UpdatedBefore != that1.UpdatedBefore {
+		return false
+	}
+	if this.IncludeDeleted != that1.IncludeDeleted {
+		return false
+	}
+	return true
 }
+func (this *ListRecordRes) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func _GoAmazing_CreateRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateRecordReq)
-	if err := dec(in); err != nil {
-		return nil, err
+	that1, ok := that.(*ListRecordRes)
+	if !ok {
+		that2, ok := that.(ListRecordRes)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if interceptor == nil {
-		return srv.(GoAmazingServer).CreateRecord(ctx, in)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pb.GoAmazing/CreateRecord",
+	if len(this.Records) != len(that1.Records) {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GoAmazingServer).CreateRecord(ctx, req.(*CreateRecordReq))
+	for i := range this.Records {
+		if !this.Records[i].Equal(that1.Records[i]) {
+			return false
+		}
 	}
-	return interceptor(ctx, in, info, handler)
+	if this.NextPageToken != that1.NextPageToken {
+		return false
+	}
+	if this.TotalCount != that1.TotalCount {
+		return false
+	}
+	if this.Page != that1.Page {
+		return false
+	}
+	if this.PageSize != that1.PageSize {
+		return false
+	}
+	if this.HasMore != that1.HasMore {
+		return false
+	}
+	return true
 }
+func (this *UpdateRecordReq) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func _GoAmazing_GetRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetRecordReq)
-	if err := dec(in); err != nil {
-		return nil, err
+	that1, ok := that.(*UpdateRecordReq)
+	if !ok {
+		that2, ok := that.(UpdateRecordReq)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if interceptor == nil {
-		return srv.(GoAmazingServer).GetRecord(ctx, in)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pb.GoAmazing/GetRecord",
+	if this.ID != that1.ID {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GoAmazingServer).GetRecord(ctx, req.(*GetRecordReq))
+	if this.TheNum != that1.TheNum {
+		return false
 	}
-	return interceptor(ctx, in, info, handler)
+	if this.TheStr != that1.TheStr {
+		return false
+	}
+	if this.Version != that1.Version {
+		return false
+	}
+	return true
 }
-
-func _GoAmazing_ListRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListRecordReq)
-	if err := dec(in); err != nil {
-		return nil, err
+func (this *UpdateRecordRes) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	if interceptor == nil {
-		return srv.(GoAmazingServer).ListRecord(ctx, in)
+
+	that1, ok := that.(*UpdateRecordRes)
+	if !ok {
+		that2, ok := that.(UpdateRecordRes)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pb.GoAmazing/ListRecord",
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GoAmazingServer).ListRecord(ctx, req.(*ListRecordReq))
+	if !this.Record.Equal(that1.Record) {
+		return false
 	}
-	return interceptor(ctx, in, info, handler)
+	return true
 }
+func (this *DeleteRecordReq) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-var _GoAmazing_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "pb.GoAmazing",
-	HandlerType: (*GoAmazingServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Health",
-			Handler:    _GoAmazing_Health_Handler,
-		},
-		{
-			MethodName: "Config",
-			Handler:    _GoAmazing_Config_Handler,
-		},
-		{
-			MethodName: "CreateRecord",
-			Handler:    _GoAmazing_CreateRecord_Handler,
-		},
-		{
-			MethodName: "GetRecord",
-			Handler:    _GoAmazing_GetRecord_Handler,
-		},
-		{
-			MethodName: "ListRecord",
-			Handler:    _GoAmazing_ListRecord_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "pkg/pb/rpc.proto",
+	that1, ok := that.(*DeleteRecordReq)
+	if !ok {
+		that2, ok := that.(DeleteRecordReq)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.ID != that1.ID {
+		return false
+	}
+	return true
 }
+func (this *DeleteRecordRes) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *Record) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*DeleteRecordRes)
+	if !ok {
+		that2, ok := that.(DeleteRecordRes)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return dAtA[:n], nil
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.ID != that1.ID {
+		return false
+	}
+	return true
 }
+func (this *RestoreRecordReq) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *Record) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	that1, ok := that.(*RestoreRecordReq)
+	if !ok {
+		that2, ok := that.(RestoreRecordReq)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.ID != that1.ID {
+		return false
+	}
+	return true
 }
+func (this *RestoreRecordRes) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *Record) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.UpdatedAt != nil {
-		n1, err1 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.UpdatedAt, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.UpdatedAt):])
-		if err1 != nil {
-			return 0, err1
+	that1, ok := that.(*RestoreRecordRes)
+	if !ok {
+		that2, ok := that.(RestoreRecordRes)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
-		i -= n1
-		i = encodeVarintRpc(dAtA, i, uint64(n1))
-		i--
-		dAtA[i] = 0x2a
 	}
-	if m.CreatedAt != nil {
-		n2, err2 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.CreatedAt, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.CreatedAt):])
-		if err2 != nil {
-			return 0, err2
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Record.Equal(that1.Record) {
+		return false
+	}
+	return true
+}
+func (this *BatchGetRecordsReq) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*BatchGetRecordsReq)
+	if !ok {
+		that2, ok := that.(BatchGetRecordsReq)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
-		i -= n2
-		i = encodeVarintRpc(dAtA, i, uint64(n2))
-		i--
-		dAtA[i] = 0x22
 	}
-	if len(m.TheStr) > 0 {
-		i -= len(m.TheStr)
-		copy(dAtA[i:], m.TheStr)
-		i = encodeVarintRpc(dAtA, i, uint64(len(m.TheStr)))
-		i--
-		dAtA[i] = 0x1a
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.TheNum != 0 {
-		i = encodeVarintRpc(dAtA, i, uint64(m.TheNum))
-		i--
-		dAtA[i] = 0x10
+	if len(this.IDs) != len(that1.IDs) {
+		return false
 	}
-	if len(m.ID) > 0 {
-		i -= len(m.ID)
-		copy(dAtA[i:], m.ID)
-		i = encodeVarintRpc(dAtA, i, uint64(len(m.ID)))
-		i--
-		dAtA[i] = 0xa
+	for i := range this.IDs {
+		if this.IDs[i] != that1.IDs[i] {
+			return false
+		}
 	}
-	return len(dAtA) - i, nil
+	return true
 }
-
-func (m *HealthReq) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *BatchGetRecordsRes) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
-
-func (m *HealthReq) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *HealthReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	return len(dAtA) - i, nil
-}
 
-func (m *HealthRes) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*BatchGetRecordsRes)
+	if !ok {
+		that2, ok := that.(BatchGetRecordsRes)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return dAtA[:n], nil
-}
-
-func (m *HealthRes) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Results) != len(that1.Results) {
+		return false
+	}
+	for i := range this.Results {
+		if !this.Results[i].Equal(that1.Results[i]) {
+			return false
+		}
+	}
+	return true
 }
+func (this *BatchGetRecordsResult) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *HealthRes) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Ok {
-		i--
-		if m.Ok {
-			dAtA[i] = 1
+	that1, ok := that.(*BatchGetRecordsResult)
+	if !ok {
+		that2, ok := that.(BatchGetRecordsResult)
+		if ok {
+			that1 = &that2
 		} else {
-			dAtA[i] = 0
+			return false
 		}
-		i--
-		dAtA[i] = 0x8
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *ConfigReq) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return dAtA[:n], nil
+	if this.ID != that1.ID {
+		return false
+	}
+	if !this.Record.Equal(that1.Record) {
+		return false
+	}
+	if this.NotFound != that1.NotFound {
+		return false
+	}
+	return true
 }
+func (this *ListRecordRevisionsReq) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *ConfigReq) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *ConfigReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	return len(dAtA) - i, nil
-}
-
-func (m *ConfigRes) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*ListRecordRevisionsReq)
+	if !ok {
+		that2, ok := that.(ListRecordRevisionsReq)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return dAtA[:n], nil
-}
-
-func (m *ConfigRes) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *ConfigRes) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Str) > 0 {
-		i -= len(m.Str)
-		copy(dAtA[i:], m.Str)
-		i = encodeVarintRpc(dAtA, i, uint64(len(m.Str)))
-		i--
-		dAtA[i] = 0x1a
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.Num != 0 {
-		i = encodeVarintRpc(dAtA, i, uint64(m.Num))
-		i--
-		dAtA[i] = 0x10
+	if this.ID != that1.ID {
+		return false
 	}
-	if m.Enable {
-		i--
-		if m.Enable {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i--
-		dAtA[i] = 0x8
+	if this.PageSize != that1.PageSize {
+		return false
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *CreateRecordReq) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if this.PageToken != that1.PageToken {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *CreateRecordReq) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	return true
 }
+func (this *ListRecordRevisionsRes) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *CreateRecordReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.CreatedAt != nil {
-		n3, err3 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.CreatedAt, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.CreatedAt):])
-		if err3 != nil {
-			return 0, err3
+	that1, ok := that.(*ListRecordRevisionsRes)
+	if !ok {
+		that2, ok := that.(ListRecordRevisionsRes)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
-		i -= n3
-		i = encodeVarintRpc(dAtA, i, uint64(n3))
-		i--
-		dAtA[i] = 0x1a
 	}
-	if len(m.TheStr) > 0 {
-		i -= len(m.TheStr)
-		copy(dAtA[i:], m.TheStr)
-		i = encodeVarintRpc(dAtA, i, uint64(len(m.TheStr)))
-		i--
-		dAtA[i] = 0x12
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.TheNum != 0 {
-		i = encodeVarintRpc(dAtA, i, uint64(m.TheNum))
-		i--
-		dAtA[i] = 0x8
+	if len(this.Revisions) != len(that1.Revisions) {
+		return false
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *CreateRecordRes) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	for i := range this.Revisions {
+		if !this.Revisions[i].Equal(that1.Revisions[i]) {
+			return false
+		}
 	}
-	return dAtA[:n], nil
+	if this.NextPageToken != that1.NextPageToken {
+		return false
+	}
+	return true
 }
-
-func (m *CreateRecordRes) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (this *Record) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 11)
+	s = append(s, "&pb.Record{")
+	s = append(s, "ID: "+fmt.Sprintf("%#v", this.ID)+",\n")
+	s = append(s, "TheNum: "+fmt.Sprintf("%#v", this.TheNum)+",\n")
+	s = append(s, "TheStr: "+fmt.Sprintf("%#v", this.TheStr)+",\n")
+	s = append(s, "CreatedAt: "+fmt.Sprintf("%#v", this.CreatedAt)+",\n")
+	s = append(s, "UpdatedAt: "+fmt.Sprintf("%#v", this.UpdatedAt)+",\n")
+	s = append(s, "DeletedAt: "+fmt.Sprintf("%#v", this.DeletedAt)+",\n")
+	s = append(s, "Version: "+fmt.Sprintf("%#v", this.Version)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *CreateRecordRes) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Record != nil {
-		{
-			size, err := m.Record.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintRpc(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+func (this *RecordRevision) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return len(dAtA) - i, nil
+	s := make([]string, 0, 12)
+	s = append(s, "&pb.RecordRevision{")
+	s = append(s, "ID: "+fmt.Sprintf("%#v", this.ID)+",\n")
+	s = append(s, "RecordID: "+fmt.Sprintf("%#v", this.RecordID)+",\n")
+	s = append(s, "Action: "+fmt.Sprintf("%#v", this.Action)+",\n")
+	s = append(s, "Actor: "+fmt.Sprintf("%#v", this.Actor)+",\n")
+	s = append(s, "RequestID: "+fmt.Sprintf("%#v", this.RequestID)+",\n")
+	if this.OldValue != nil {
+		s = append(s, "OldValue: "+fmt.Sprintf("%#v", this.OldValue)+",\n")
+	}
+	if this.NewValue != nil {
+		s = append(s, "NewValue: "+fmt.Sprintf("%#v", this.NewValue)+",\n")
+	}
+	s = append(s, "CreatedAt: "+fmt.Sprintf("%#v", this.CreatedAt)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *GetRecordReq) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *HealthReq) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
+	s := make([]string, 0, 4)
+	s = append(s, "&pb.HealthReq{")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *GetRecordReq) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (this *HealthRes) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.HealthRes{")
+	s = append(s, "Ok: "+fmt.Sprintf("%#v", this.Ok)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *GetRecordReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.ID) > 0 {
-		i -= len(m.ID)
-		copy(dAtA[i:], m.ID)
-		i = encodeVarintRpc(dAtA, i, uint64(len(m.ID)))
-		i--
-		dAtA[i] = 0xa
+func (this *ConfigReq) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return len(dAtA) - i, nil
+	s := make([]string, 0, 4)
+	s = append(s, "&pb.ConfigReq{")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *GetRecordRes) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *ConfigRes) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
+	s := make([]string, 0, 7)
+	s = append(s, "&pb.ConfigRes{")
+	s = append(s, "Enable: "+fmt.Sprintf("%#v", this.Enable)+",\n")
+	s = append(s, "Num: "+fmt.Sprintf("%#v", this.Num)+",\n")
+	s = append(s, "Str: "+fmt.Sprintf("%#v", this.Str)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *GetRecordRes) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (this *CreateRecordReq) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 8)
+	s = append(s, "&pb.CreateRecordReq{")
+	s = append(s, "TheNum: "+fmt.Sprintf("%#v", this.TheNum)+",\n")
+	s = append(s, "TheStr: "+fmt.Sprintf("%#v", this.TheStr)+",\n")
+	s = append(s, "CreatedAt: "+fmt.Sprintf("%#v", this.CreatedAt)+",\n")
+	s = append(s, "IdempotencyKey: "+fmt.Sprintf("%#v", this.IdempotencyKey)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *GetRecordRes) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Record != nil {
-		{
-			size, err := m.Record.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintRpc(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+func (this *CreateRecordRes) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *ListRecordReq) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.CreateRecordRes{")
+	if this.Record != nil {
+		s = append(s, "Record: "+fmt.Sprintf("%#v", this.Record)+",\n")
 	}
-	return dAtA[:n], nil
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *ListRecordReq) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (this *GetRecordReq) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.GetRecordReq{")
+	s = append(s, "ID: "+fmt.Sprintf("%#v", this.ID)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *ListRecordReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Page) > 0 {
-		i -= len(m.Page)
-		copy(dAtA[i:], m.Page)
-		i = encodeVarintRpc(dAtA, i, uint64(len(m.Page)))
-		i--
-		dAtA[i] = 0x12
+func (this *GetRecordRes) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if len(m.PageSize) > 0 {
-		i -= len(m.PageSize)
-		copy(dAtA[i:], m.PageSize)
-		i = encodeVarintRpc(dAtA, i, uint64(len(m.PageSize)))
-		i--
-		dAtA[i] = 0xa
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.GetRecordRes{")
+	if this.Record != nil {
+		s = append(s, "Record: "+fmt.Sprintf("%#v", this.Record)+",\n")
 	}
-	return len(dAtA) - i, nil
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *ListRecordRes) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *ListRecordReq) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
-}
-
-func (m *ListRecordRes) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	s := make([]string, 0, 17)
+	s = append(s, "&pb.ListRecordReq{")
+	s = append(s, "PageSize: "+fmt.Sprintf("%#v", this.PageSize)+",\n")
+	s = append(s, "Page: "+fmt.Sprintf("%#v", this.Page)+",\n")
+	s = append(s, "PageToken: "+fmt.Sprintf("%#v", this.PageToken)+",\n")
+	s = append(s, "OrderBy: "+fmt.Sprintf("%#v", this.OrderBy)+",\n")
+	s = append(s, "TheNumMin: "+fmt.Sprintf("%#v", this.TheNumMin)+",\n")
+	s = append(s, "TheNumMax: "+fmt.Sprintf("%#v", this.TheNumMax)+",\n")
+	s = append(s, "TheStr: "+fmt.Sprintf("%#v", this.TheStr)+",\n")
+	s = append(s, "TheStrPrefix: "+fmt.Sprintf("%#v", this.TheStrPrefix)+",\n")
+	s = append(s, "CreatedAfter: "+fmt.Sprintf("%#v", this.CreatedAfter)+",\n")
+	s = append(s, "CreatedBefore: "+fmt.Sprintf("%#v", this.CreatedBefore)+",\n")
+	s = append(s, "UpdatedAfter: "+fmt.Sprintf("%#v", this.UpdatedAfter)+",\n")
+	s = append(s, "UpdatedBefore: "+fmt.Sprintf("%#v", this.UpdatedBefore)+",\n")
+	s = append(s, "IncludeDeleted: "+fmt.Sprintf("%#v", this.IncludeDeleted)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *ListRecordRes) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Records) > 0 {
-		for iNdEx := len(m.Records) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Records[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintRpc(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
+func (this *ListRecordRes) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return len(dAtA) - i, nil
-}
-
-func encodeVarintRpc(dAtA []byte, offset int, v uint64) int {
-	offset -= sovRpc(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+	s := make([]string, 0, 10)
+	s = append(s, "&pb.ListRecordRes{")
+	if this.Records != nil {
+		s = append(s, "Records: "+fmt.Sprintf("%#v", this.Records)+",\n")
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	s = append(s, "NextPageToken: "+fmt.Sprintf("%#v", this.NextPageToken)+",\n")
+	s = append(s, "TotalCount: "+fmt.Sprintf("%#v", this.TotalCount)+",\n")
+	s = append(s, "Page: "+fmt.Sprintf("%#v", this.Page)+",\n")
+	s = append(s, "PageSize: "+fmt.Sprintf("%#v", this.PageSize)+",\n")
+	s = append(s, "HasMore: "+fmt.Sprintf("%#v", this.HasMore)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-func (m *Record) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovRpc(uint64(l))
+func (this *UpdateRecordReq) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.TheNum != 0 {
-		n += 1 + sovRpc(uint64(m.TheNum))
+	s := make([]string, 0, 8)
+	s = append(s, "&pb.UpdateRecordReq{")
+	s = append(s, "ID: "+fmt.Sprintf("%#v", this.ID)+",\n")
+	s = append(s, "TheNum: "+fmt.Sprintf("%#v", this.TheNum)+",\n")
+	s = append(s, "TheStr: "+fmt.Sprintf("%#v", this.TheStr)+",\n")
+	s = append(s, "Version: "+fmt.Sprintf("%#v", this.Version)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *UpdateRecordRes) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	l = len(m.TheStr)
-	if l > 0 {
-		n += 1 + l + sovRpc(uint64(l))
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.UpdateRecordRes{")
+	if this.Record != nil {
+		s = append(s, "Record: "+fmt.Sprintf("%#v", this.Record)+",\n")
 	}
-	if m.CreatedAt != nil {
-		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.CreatedAt)
-		n += 1 + l + sovRpc(uint64(l))
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *DeleteRecordReq) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.UpdatedAt != nil {
-		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.UpdatedAt)
-		n += 1 + l + sovRpc(uint64(l))
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.DeleteRecordReq{")
+	s = append(s, "ID: "+fmt.Sprintf("%#v", this.ID)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *DeleteRecordRes) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return n
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.DeleteRecordRes{")
+	s = append(s, "ID: "+fmt.Sprintf("%#v", this.ID)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *HealthReq) Size() (n int) {
-	if m == nil {
-		return 0
+func (this *RestoreRecordReq) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	var l int
-	_ = l
-	return n
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.RestoreRecordReq{")
+	s = append(s, "ID: "+fmt.Sprintf("%#v", this.ID)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *HealthRes) Size() (n int) {
-	if m == nil {
-		return 0
+func (this *RestoreRecordRes) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	var l int
-	_ = l
-	if m.Ok {
-		n += 2
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.RestoreRecordRes{")
+	if this.Record != nil {
+		s = append(s, "Record: "+fmt.Sprintf("%#v", this.Record)+",\n")
 	}
-	return n
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *ConfigReq) Size() (n int) {
-	if m == nil {
-		return 0
+func (this *BatchGetRecordsReq) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	var l int
-	_ = l
-	return n
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.BatchGetRecordsReq{")
+	s = append(s, "IDs: "+fmt.Sprintf("%#v", this.IDs)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *ConfigRes) Size() (n int) {
-	if m == nil {
-		return 0
+func (this *BatchGetRecordsRes) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	var l int
-	_ = l
-	if m.Enable {
-		n += 2
+	s := make([]string, 0, 5)
+	s = append(s, "&pb.BatchGetRecordsRes{")
+	if this.Results != nil {
+		s = append(s, "Results: "+fmt.Sprintf("%#v", this.Results)+",\n")
 	}
-	if m.Num != 0 {
-		n += 1 + sovRpc(uint64(m.Num))
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *BatchGetRecordsResult) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	l = len(m.Str)
-	if l > 0 {
-		n += 1 + l + sovRpc(uint64(l))
+	s := make([]string, 0, 7)
+	s = append(s, "&pb.BatchGetRecordsResult{")
+	s = append(s, "ID: "+fmt.Sprintf("%#v", this.ID)+",\n")
+	if this.Record != nil {
+		s = append(s, "Record: "+fmt.Sprintf("%#v", this.Record)+",\n")
 	}
-	return n
+	s = append(s, "NotFound: "+fmt.Sprintf("%#v", this.NotFound)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *CreateRecordReq) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.TheNum != 0 {
-		n += 1 + sovRpc(uint64(m.TheNum))
+func (this *ListRecordRevisionsReq) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	l = len(m.TheStr)
-	if l > 0 {
-		n += 1 + l + sovRpc(uint64(l))
+	s := make([]string, 0, 7)
+	s = append(s, "&pb.ListRecordRevisionsReq{")
+	s = append(s, "ID: "+fmt.Sprintf("%#v", this.ID)+",\n")
+	s = append(s, "PageSize: "+fmt.Sprintf("%#v", this.PageSize)+",\n")
+	s = append(s, "PageToken: "+fmt.Sprintf("%#v", this.PageToken)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ListRecordRevisionsRes) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.CreatedAt != nil {
-		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.CreatedAt)
-		n += 1 + l + sovRpc(uint64(l))
+	s := make([]string, 0, 6)
+	s = append(s, "&pb.ListRecordRevisionsRes{")
+	if this.Revisions != nil {
+		s = append(s, "Revisions: "+fmt.Sprintf("%#v", this.Revisions)+",\n")
 	}
-	return n
+	s = append(s, "NextPageToken: "+fmt.Sprintf("%#v", this.NextPageToken)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *CreateRecordRes) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Record != nil {
-		l = m.Record.Size()
-		n += 1 + l + sovRpc(uint64(l))
+func valueToGoStringRpc(v interface{}, typ string) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
 	}
-	return n
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
 }
 
-func (m *GetRecordReq) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovRpc(uint64(l))
-	}
-	return n
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// GoAmazingClient is the client API for GoAmazing service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type GoAmazingClient interface {
+	// Health check api for k8s.
+	Health(ctx context.Context, in *HealthReq, opts ...grpc.CallOption) (*HealthRes, error)
+	Config(ctx context.Context, in *ConfigReq, opts ...grpc.CallOption) (*ConfigRes, error)
+	CreateRecord(ctx context.Context, in *CreateRecordReq, opts ...grpc.CallOption) (*CreateRecordRes, error)
+	GetRecord(ctx context.Context, in *GetRecordReq, opts ...grpc.CallOption) (*GetRecordRes, error)
+	ListRecord(ctx context.Context, in *ListRecordReq, opts ...grpc.CallOption) (*ListRecordRes, error)
+	UpdateRecord(ctx context.Context, in *UpdateRecordReq, opts ...grpc.CallOption) (*UpdateRecordRes, error)
+	// DeleteRecord soft deletes the record, it can be restored until it's purged.
+	DeleteRecord(ctx context.Context, in *DeleteRecordReq, opts ...grpc.CallOption) (*DeleteRecordRes, error)
+	RestoreRecord(ctx context.Context, in *RestoreRecordReq, opts ...grpc.CallOption) (*RestoreRecordRes, error)
+	BatchGetRecords(ctx context.Context, in *BatchGetRecordsReq, opts ...grpc.CallOption) (*BatchGetRecordsRes, error)
+	ListRecordRevisions(ctx context.Context, in *ListRecordRevisionsReq, opts ...grpc.CallOption) (*ListRecordRevisionsRes, error)
 }
 
-func (m *GetRecordRes) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Record != nil {
-		l = m.Record.Size()
-		n += 1 + l + sovRpc(uint64(l))
-	}
-	return n
+type goAmazingClient struct {
+	cc *grpc.ClientConn
 }
 
-func (m *ListRecordReq) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.PageSize)
-	if l > 0 {
-		n += 1 + l + sovRpc(uint64(l))
-	}
-	l = len(m.Page)
-	if l > 0 {
-		n += 1 + l + sovRpc(uint64(l))
-	}
-	return n
+func NewGoAmazingClient(cc *grpc.ClientConn) GoAmazingClient {
+	return &goAmazingClient{cc}
 }
 
-func (m *ListRecordRes) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.Records) > 0 {
-		for _, e := range m.Records {
-			l = e.Size()
-			n += 1 + l + sovRpc(uint64(l))
-		}
+func (c *goAmazingClient) Health(ctx context.Context, in *HealthReq, opts ...grpc.CallOption) (*HealthRes, error) {
+	out := new(HealthRes)
+	err := c.cc.Invoke(ctx, "/pb.GoAmazing/Health", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return out, nil
 }
 
-func sovRpc(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozRpc(x uint64) (n int) {
-	return sovRpc(uint64((x << 1) ^ uint64((int64(x) >> 63))))
-}
-func (this *Record) String() string {
-	if this == nil {
-		return "nil"
+func (c *goAmazingClient) Config(ctx context.Context, in *ConfigReq, opts ...grpc.CallOption) (*ConfigRes, error) {
+	out := new(ConfigRes)
+	err := c.cc.Invoke(ctx, "/pb.GoAmazing/Config", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&Record{`,
-		`ID:` + fmt.Sprintf("%v", this.ID) + `,`,
-		`TheNum:` + fmt.Sprintf("%v", this.TheNum) + `,`,
-		`TheStr:` + fmt.Sprintf("%v", this.TheStr) + `,`,
-		`CreatedAt:` + strings.Replace(fmt.Sprintf("%v", this.CreatedAt), "Timestamp", "types.Timestamp", 1) + `,`,
-		`UpdatedAt:` + strings.Replace(fmt.Sprintf("%v", this.UpdatedAt), "Timestamp", "types.Timestamp", 1) + `,`,
-		`}`,
-	}, "")
-	return s
+	return out, nil
 }
-func (this *HealthReq) String() string {
-	if this == nil {
-		return "nil"
+
+func (c *goAmazingClient) CreateRecord(ctx context.Context, in *CreateRecordReq, opts ...grpc.CallOption) (*CreateRecordRes, error) {
+	out := new(CreateRecordRes)
+	err := c.cc.Invoke(ctx, "/pb.GoAmazing/CreateRecord", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&HealthReq{`,
-		`}`,
-	}, "")
-	return s
+	return out, nil
 }
-func (this *HealthRes) String() string {
-	if this == nil {
-		return "nil"
+
+func (c *goAmazingClient) GetRecord(ctx context.Context, in *GetRecordReq, opts ...grpc.CallOption) (*GetRecordRes, error) {
+	out := new(GetRecordRes)
+	err := c.cc.Invoke(ctx, "/pb.GoAmazing/GetRecord", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&HealthRes{`,
-		`Ok:` + fmt.Sprintf("%v", this.Ok) + `,`,
-		`}`,
-	}, "")
-	return s
+	return out, nil
 }
-func (this *ConfigReq) String() string {
-	if this == nil {
-		return "nil"
+
+func (c *goAmazingClient) ListRecord(ctx context.Context, in *ListRecordReq, opts ...grpc.CallOption) (*ListRecordRes, error) {
+	out := new(ListRecordRes)
+	err := c.cc.Invoke(ctx, "/pb.GoAmazing/ListRecord", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&ConfigReq{`,
-		`}`,
-	}, "")
-	return s
+	return out, nil
 }
-func (this *ConfigRes) String() string {
-	if this == nil {
-		return "nil"
+
+func (c *goAmazingClient) UpdateRecord(ctx context.Context, in *UpdateRecordReq, opts ...grpc.CallOption) (*UpdateRecordRes, error) {
+	out := new(UpdateRecordRes)
+	err := c.cc.Invoke(ctx, "/pb.GoAmazing/UpdateRecord", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&ConfigRes{`,
-		`Enable:` + fmt.Sprintf("%v", this.Enable) + `,`,
-		`Num:` + fmt.Sprintf("%v", this.Num) + `,`,
-		`Str:` + fmt.Sprintf("%v", this.Str) + `,`,
-		`}`,
-	}, "")
-	return s
+	return out, nil
 }
-func (this *CreateRecordReq) String() string {
-	if this == nil {
-		return "nil"
+
+func (c *goAmazingClient) DeleteRecord(ctx context.Context, in *DeleteRecordReq, opts ...grpc.CallOption) (*DeleteRecordRes, error) {
+	out := new(DeleteRecordRes)
+	err := c.cc.Invoke(ctx, "/pb.GoAmazing/DeleteRecord", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&CreateRecordReq{`,
-		`TheNum:` + fmt.Sprintf("%v", this.TheNum) + `,`,
-		`TheStr:` + fmt.Sprintf("%v", this.TheStr) + `,`,
-		`CreatedAt:` + strings.Replace(fmt.Sprintf("%v", this.CreatedAt), "Timestamp", "types.Timestamp", 1) + `,`,
-		`}`,
-	}, "")
-	return s
+	return out, nil
 }
-func (this *CreateRecordRes) String() string {
-	if this == nil {
-		return "nil"
+
+func (c *goAmazingClient) RestoreRecord(ctx context.Context, in *RestoreRecordReq, opts ...grpc.CallOption) (*RestoreRecordRes, error) {
+	out := new(RestoreRecordRes)
+	err := c.cc.Invoke(ctx, "/pb.GoAmazing/RestoreRecord", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&CreateRecordRes{`,
-		`Record:` + strings.Replace(this.Record.String(), "Record", "Record", 1) + `,`,
-		`}`,
-	}, "")
-	return s
+	return out, nil
 }
-func (this *GetRecordReq) String() string {
-	if this == nil {
-		return "nil"
+
+func (c *goAmazingClient) BatchGetRecords(ctx context.Context, in *BatchGetRecordsReq, opts ...grpc.CallOption) (*BatchGetRecordsRes, error) {
+	out := new(BatchGetRecordsRes)
+	err := c.cc.Invoke(ctx, "/pb.GoAmazing/BatchGetRecords", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&GetRecordReq{`,
-		`ID:` + fmt.Sprintf("%v", this.ID) + `,`,
-		`}`,
-	}, "")
-	return s
+	return out, nil
 }
-func (this *GetRecordRes) String() string {
-	if this == nil {
-		return "nil"
+
+func (c *goAmazingClient) ListRecordRevisions(ctx context.Context, in *ListRecordRevisionsReq, opts ...grpc.CallOption) (*ListRecordRevisionsRes, error) {
+	out := new(ListRecordRevisionsRes)
+	err := c.cc.Invoke(ctx, "/pb.GoAmazing/ListRecordRevisions", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&GetRecordRes{`,
-		`Record:` + strings.Replace(this.Record.String(), "Record", "Record", 1) + `,`,
-		`}`,
-	}, "")
-	return s
+	return out, nil
 }
-func (this *ListRecordReq) String() string {
-	if this == nil {
-		return "nil"
+
+// GoAmazingServer is the server API for GoAmazing service.
+type GoAmazingServer interface {
+	// Health check api for k8s.
+	Health(context.Context, *HealthReq) (*HealthRes, error)
+	Config(context.Context, *ConfigReq) (*ConfigRes, error)
+	CreateRecord(context.Context, *CreateRecordReq) (*CreateRecordRes, error)
+	GetRecord(context.Context, *GetRecordReq) (*GetRecordRes, error)
+	ListRecord(context.Context, *ListRecordReq) (*ListRecordRes, error)
+	UpdateRecord(context.Context, *UpdateRecordReq) (*UpdateRecordRes, error)
+	// DeleteRecord soft deletes the record, it can be restored until it's purged.
+	DeleteRecord(context.Context, *DeleteRecordReq) (*DeleteRecordRes, error)
+	RestoreRecord(context.Context, *RestoreRecordReq) (*RestoreRecordRes, error)
+	BatchGetRecords(context.Context, *BatchGetRecordsReq) (*BatchGetRecordsRes, error)
+	ListRecordRevisions(context.Context, *ListRecordRevisionsReq) (*ListRecordRevisionsRes, error)
+}
+
+// UnimplementedGoAmazingServer can be embedded to have forward compatible implementations.
+type UnimplementedGoAmazingServer struct {
+}
+
+func (*UnimplementedGoAmazingServer) Health(ctx context.Context, req *HealthReq) (*HealthRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Health not implemented")
+}
+func (*UnimplementedGoAmazingServer) Config(ctx context.Context, req *ConfigReq) (*ConfigRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Config not implemented")
+}
+func (*UnimplementedGoAmazingServer) CreateRecord(ctx context.Context, req *CreateRecordReq) (*CreateRecordRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRecord not implemented")
+}
+func (*UnimplementedGoAmazingServer) GetRecord(ctx context.Context, req *GetRecordReq) (*GetRecordRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRecord not implemented")
+}
+func (*UnimplementedGoAmazingServer) ListRecord(ctx context.Context, req *ListRecordReq) (*ListRecordRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRecord not implemented")
+}
+func (*UnimplementedGoAmazingServer) UpdateRecord(ctx context.Context, req *UpdateRecordReq) (*UpdateRecordRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRecord not implemented")
+}
+func (*UnimplementedGoAmazingServer) DeleteRecord(ctx context.Context, req *DeleteRecordReq) (*DeleteRecordRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRecord not implemented")
+}
+func (*UnimplementedGoAmazingServer) RestoreRecord(ctx context.Context, req *RestoreRecordReq) (*RestoreRecordRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreRecord not implemented")
+}
+func (*UnimplementedGoAmazingServer) BatchGetRecords(ctx context.Context, req *BatchGetRecordsReq) (*BatchGetRecordsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetRecords not implemented")
+}
+func (*UnimplementedGoAmazingServer) ListRecordRevisions(ctx context.Context, req *ListRecordRevisionsReq) (*ListRecordRevisionsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRecordRevisions not implemented")
+}
+
+func RegisterGoAmazingServer(s *grpc.Server, srv GoAmazingServer) {
+	s.RegisterService(&_GoAmazing_serviceDesc, srv)
+}
+
+func _GoAmazing_Health_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HealthReq)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&ListRecordReq{`,
-		`PageSize:` + fmt.Sprintf("%v", this.PageSize) + `,`,
-		`Page:` + fmt.Sprintf("%v", this.Page) + `,`,
-		`}`,
-	}, "")
-	return s
+	if interceptor == nil {
+		return srv.(GoAmazingServer).Health(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.GoAmazing/Health",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoAmazingServer).Health(ctx, req.(*HealthReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (this *ListRecordRes) String() string {
-	if this == nil {
-		return "nil"
+
+func _GoAmazing_Config_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfigReq)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	repeatedStringForRecords := "[]*Record{"
-	for _, f := range this.Records {
-		repeatedStringForRecords += strings.Replace(f.String(), "Record", "Record", 1) + ","
+	if interceptor == nil {
+		return srv.(GoAmazingServer).Config(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.GoAmazing/Config",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoAmazingServer).Config(ctx, req.(*ConfigReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoAmazing_CreateRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRecordReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoAmazingServer).CreateRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.GoAmazing/CreateRecord",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoAmazingServer).CreateRecord(ctx, req.(*CreateRecordReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoAmazing_GetRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRecordReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoAmazingServer).GetRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.GoAmazing/GetRecord",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoAmazingServer).GetRecord(ctx, req.(*GetRecordReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoAmazing_ListRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRecordReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoAmazingServer).ListRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.GoAmazing/ListRecord",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoAmazingServer).ListRecord(ctx, req.(*ListRecordReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoAmazing_UpdateRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRecordReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoAmazingServer).UpdateRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.GoAmazing/UpdateRecord",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoAmazingServer).UpdateRecord(ctx, req.(*UpdateRecordReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoAmazing_DeleteRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRecordReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoAmazingServer).DeleteRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.GoAmazing/DeleteRecord",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoAmazingServer).DeleteRecord(ctx, req.(*DeleteRecordReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoAmazing_RestoreRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreRecordReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoAmazingServer).RestoreRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.GoAmazing/RestoreRecord",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoAmazingServer).RestoreRecord(ctx, req.(*RestoreRecordReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoAmazing_BatchGetRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetRecordsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoAmazingServer).BatchGetRecords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.GoAmazing/BatchGetRecords",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoAmazingServer).BatchGetRecords(ctx, req.(*BatchGetRecordsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoAmazing_ListRecordRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRecordRevisionsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoAmazingServer).ListRecordRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.GoAmazing/ListRecordRevisions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoAmazingServer).ListRecordRevisions(ctx, req.(*ListRecordRevisionsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _GoAmazing_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.GoAmazing",
+	HandlerType: (*GoAmazingServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Health",
+			Handler:    _GoAmazing_Health_Handler,
+		},
+		{
+			MethodName: "Config",
+			Handler:    _GoAmazing_Config_Handler,
+		},
+		{
+			MethodName: "CreateRecord",
+			Handler:    _GoAmazing_CreateRecord_Handler,
+		},
+		{
+			MethodName: "GetRecord",
+			Handler:    _GoAmazing_GetRecord_Handler,
+		},
+		{
+			MethodName: "ListRecord",
+			Handler:    _GoAmazing_ListRecord_Handler,
+		},
+		{
+			MethodName: "UpdateRecord",
+			Handler:    _GoAmazing_UpdateRecord_Handler,
+		},
+		{
+			MethodName: "DeleteRecord",
+			Handler:    _GoAmazing_DeleteRecord_Handler,
+		},
+		{
+			MethodName: "RestoreRecord",
+			Handler:    _GoAmazing_RestoreRecord_Handler,
+		},
+		{
+			MethodName: "BatchGetRecords",
+			Handler:    _GoAmazing_BatchGetRecords_Handler,
+		},
+		{
+			MethodName: "ListRecordRevisions",
+			Handler:    _GoAmazing_ListRecordRevisions_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pkg/pb/rpc.proto",
+}
+
+func (m *Record) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Record) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Record) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Version != 0 {
+		i = encodeVarintRpc(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.DeletedAt != nil {
+		n1, err1 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.DeletedAt, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.DeletedAt):])
+		if err1 != nil {
+			return 0, err1
+		}
+		i -= n1
+		i = encodeVarintRpc(dAtA, i, uint64(n1))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.UpdatedAt != nil {
+		n2, err2 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.UpdatedAt, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.UpdatedAt):])
+		if err2 != nil {
+			return 0, err2
+		}
+		i -= n2
+		i = encodeVarintRpc(dAtA, i, uint64(n2))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.CreatedAt != nil {
+		n3, err3 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.CreatedAt, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.CreatedAt):])
+		if err3 != nil {
+			return 0, err3
+		}
+		i -= n3
+		i = encodeVarintRpc(dAtA, i, uint64(n3))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.TheStr) > 0 {
+		i -= len(m.TheStr)
+		copy(dAtA[i:], m.TheStr)
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.TheStr)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.TheNum != 0 {
+		i = encodeVarintRpc(dAtA, i, uint64(m.TheNum))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RecordRevision) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RecordRevision) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RecordRevision) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.CreatedAt != nil {
+		n4, err4 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.CreatedAt, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.CreatedAt):])
+		if err4 != nil {
+			return 0, err4
+		}
+		i -= n4
+		i = encodeVarintRpc(dAtA, i, uint64(n4))
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.NewValue != nil {
+		{
+			size, err := m.NewValue.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintRpc(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.OldValue != nil {
+		{
+			size, err := m.OldValue.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintRpc(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.RequestID) > 0 {
+		i -= len(m.RequestID)
+		copy(dAtA[i:], m.RequestID)
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.RequestID)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Actor) > 0 {
+		i -= len(m.Actor)
+		copy(dAtA[i:], m.Actor)
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.Actor)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Action) > 0 {
+		i -= len(m.Action)
+		copy(dAtA[i:], m.Action)
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.Action)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.RecordID) > 0 {
+		i -= len(m.RecordID)
+		copy(dAtA[i:], m.RecordID)
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.RecordID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ID != 0 {
+		i = encodeVarintRpc(dAtA, i, uint64(m.ID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *HealthReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HealthReq) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *HealthReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *HealthRes) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HealthRes) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *HealthRes) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Ok {
+		i--
+		if m.Ok {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ConfigReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConfigReq) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ConfigReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *ConfigRes) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConfigRes) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ConfigRes) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Str) > 0 {
+		i -= len(m.Str)
+		copy(dAtA[i:], m.Str)
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.Str)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Num != 0 {
+		i = encodeVarintRpc(dAtA, i, uint64(m.Num))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Enable {
+		i--
+		if m.Enable {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CreateRecordReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CreateRecordReq) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CreateRecordReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.IdempotencyKey) > 0 {
+		i -= len(m.IdempotencyKey)
+		copy(dAtA[i:], m.IdempotencyKey)
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.IdempotencyKey)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.CreatedAt != nil {
+		n7, err7 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.CreatedAt, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.CreatedAt):])
+		if err7 != nil {
+			return 0, err7
+		}
+		i -= n7
+		i = encodeVarintRpc(dAtA, i, uint64(n7))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.TheStr) > 0 {
+		i -= len(m.TheStr)
+		copy(dAtA[i:], m.TheStr)
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.TheStr)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.TheNum != 0 {
+		i = encodeVarintRpc(dAtA, i, uint64(m.TheNum))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CreateRecordRes) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CreateRecordRes) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CreateRecordRes) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Record != nil {
+		{
+			size, err := m.Record.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintRpc(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetRecordReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetRecordReq) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetRecordReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetRecordRes) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetRecordRes) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetRecordRes) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Record != nil {
+		{
+			size, err := m.Record.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintRpc(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListRecordReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListRecordReq) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListRecordReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.IncludeDeleted) > 0 {
+		i -= len(m.IncludeDeleted)
+		copy(dAtA[i:], m.IncludeDeleted)
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.IncludeDeleted)))
+		i--
+		dAtA[i] = 0x6a
+	}
+	if len(m.UpdatedBefore) > 0 {
+		i -= len(m.UpdatedBefore)
+		copy(dAtA[i:], m.UpdatedBefore)
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.UpdatedBefore)))
+		i--
+		dAtA[i] = 0x62
+	}
+	if len(m.UpdatedAfter) > 0 {
+		i -= len(m.UpdatedAfter)
+		copy(dAtA[i:], m.UpdatedAfter)
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.UpdatedAfter)))
+		i--
+		dAtA[i] = 0x5a
+	}
+	if len(m.CreatedBefore) > 0 {
+		i -= len(m.CreatedBefore)
+		copy(dAtA[i:], m.CreatedBefore)
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.CreatedBefore)))
+		i--
+		dAtA[i] = 0x52
+	}
+	if len(m.CreatedAfter) > 0 {
+		i -= len(m.CreatedAfter)
+		copy(dAtA[i:], m.CreatedAfter)
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.CreatedAfter)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if len(m.TheStrPrefix) > 0 {
+		i -= len(m.TheStrPrefix)
+		copy(dAtA[i:], m.TheStrPrefix)
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.TheStrPrefix)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if len(m.TheStr) > 0 {
+		i -= len(m.TheStr)
+		copy(dAtA[i:], m.TheStr)
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.TheStr)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.TheNumMax) > 0 {
+		i -= len(m.TheNumMax)
+		copy(dAtA[i:], m.TheNumMax)
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.TheNumMax)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.TheNumMin) > 0 {
+		i -= len(m.TheNumMin)
+		copy(dAtA[i:], m.TheNumMin)
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.TheNumMin)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.OrderBy) > 0 {
+		i -= len(m.OrderBy)
+		copy(dAtA[i:], m.OrderBy)
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.OrderBy)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.PageToken) > 0 {
+		i -= len(m.PageToken)
+		copy(dAtA[i:], m.PageToken)
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.PageToken)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Page) > 0 {
+		i -= len(m.Page)
+		copy(dAtA[i:], m.Page)
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.Page)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.PageSize) > 0 {
+		i -= len(m.PageSize)
+		copy(dAtA[i:], m.PageSize)
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.PageSize)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListRecordRes) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListRecordRes) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListRecordRes) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.HasMore {
+		i--
+		if m.HasMore {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.PageSize != 0 {
+		i = encodeVarintRpc(dAtA, i, uint64(m.PageSize))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Page != 0 {
+		i = encodeVarintRpc(dAtA, i, uint64(m.Page))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.TotalCount != 0 {
+		i = encodeVarintRpc(dAtA, i, uint64(m.TotalCount))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.NextPageToken) > 0 {
+		i -= len(m.NextPageToken)
+		copy(dAtA[i:], m.NextPageToken)
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.NextPageToken)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Records) > 0 {
+		for iNdEx := len(m.Records) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Records[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintRpc(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *UpdateRecordReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UpdateRecordReq) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UpdateRecordReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Version != 0 {
+		i = encodeVarintRpc(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.TheStr) > 0 {
+		i -= len(m.TheStr)
+		copy(dAtA[i:], m.TheStr)
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.TheStr)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.TheNum != 0 {
+		i = encodeVarintRpc(dAtA, i, uint64(m.TheNum))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *UpdateRecordRes) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UpdateRecordRes) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UpdateRecordRes) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Record != nil {
+		{
+			size, err := m.Record.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintRpc(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DeleteRecordReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteRecordReq) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeleteRecordReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DeleteRecordRes) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteRecordRes) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeleteRecordRes) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RestoreRecordReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RestoreRecordReq) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RestoreRecordReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RestoreRecordRes) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RestoreRecordRes) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RestoreRecordRes) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Record != nil {
+		{
+			size, err := m.Record.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintRpc(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BatchGetRecordsReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BatchGetRecordsReq) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BatchGetRecordsReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.IDs) > 0 {
+		for iNdEx := len(m.IDs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.IDs[iNdEx])
+			copy(dAtA[i:], m.IDs[iNdEx])
+			i = encodeVarintRpc(dAtA, i, uint64(len(m.IDs[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BatchGetRecordsRes) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BatchGetRecordsRes) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BatchGetRecordsRes) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Results) > 0 {
+		for iNdEx := len(m.Results) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Results[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintRpc(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BatchGetRecordsResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BatchGetRecordsResult) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BatchGetRecordsResult) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.NotFound {
+		i--
+		if m.NotFound {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Record != nil {
+		{
+			size, err := m.Record.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintRpc(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListRecordRevisionsReq) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListRecordRevisionsReq) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListRecordRevisionsReq) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.PageToken) > 0 {
+		i -= len(m.PageToken)
+		copy(dAtA[i:], m.PageToken)
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.PageToken)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.PageSize) > 0 {
+		i -= len(m.PageSize)
+		copy(dAtA[i:], m.PageSize)
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.PageSize)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListRecordRevisionsRes) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListRecordRevisionsRes) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListRecordRevisionsRes) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.NextPageToken) > 0 {
+		i -= len(m.NextPageToken)
+		copy(dAtA[i:], m.NextPageToken)
+		i = encodeVarintRpc(dAtA, i, uint64(len(m.NextPageToken)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Revisions) > 0 {
+		for iNdEx := len(m.Revisions) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Revisions[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintRpc(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintRpc(dAtA []byte, offset int, v uint64) int {
+	offset -= sovRpc(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Record) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	if m.TheNum != 0 {
+		n += 1 + sovRpc(uint64(m.TheNum))
+	}
+	l = len(m.TheStr)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	if m.CreatedAt != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.CreatedAt)
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	if m.UpdatedAt != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.UpdatedAt)
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	if m.DeletedAt != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.DeletedAt)
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	if m.Version != 0 {
+		n += 1 + sovRpc(uint64(m.Version))
+	}
+	return n
+}
+
+func (m *RecordRevision) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ID != 0 {
+		n += 1 + sovRpc(uint64(m.ID))
+	}
+	l = len(m.RecordID)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	l = len(m.Action)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	l = len(m.Actor)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	l = len(m.RequestID)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	if m.OldValue != nil {
+		l = m.OldValue.Size()
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	if m.NewValue != nil {
+		l = m.NewValue.Size()
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	if m.CreatedAt != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.CreatedAt)
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	return n
+}
+
+func (m *HealthReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *HealthRes) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Ok {
+		n += 2
+	}
+	return n
+}
+
+func (m *ConfigReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *ConfigRes) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Enable {
+		n += 2
+	}
+	if m.Num != 0 {
+		n += 1 + sovRpc(uint64(m.Num))
+	}
+	l = len(m.Str)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	return n
+}
+
+func (m *CreateRecordReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.TheNum != 0 {
+		n += 1 + sovRpc(uint64(m.TheNum))
+	}
+	l = len(m.TheStr)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	if m.CreatedAt != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.CreatedAt)
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	l = len(m.IdempotencyKey)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	return n
+}
+
+func (m *CreateRecordRes) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Record != nil {
+		l = m.Record.Size()
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	return n
+}
+
+func (m *GetRecordReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	return n
+}
+
+func (m *GetRecordRes) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Record != nil {
+		l = m.Record.Size()
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	return n
+}
+
+func (m *ListRecordReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.PageSize)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	l = len(m.Page)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	l = len(m.PageToken)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	l = len(m.OrderBy)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	l = len(m.TheNumMin)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	l = len(m.TheNumMax)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	l = len(m.TheStr)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	l = len(m.TheStrPrefix)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	l = len(m.CreatedAfter)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	l = len(m.CreatedBefore)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	l = len(m.UpdatedAfter)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	l = len(m.UpdatedBefore)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	l = len(m.IncludeDeleted)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	return n
+}
+
+func (m *ListRecordRes) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Records) > 0 {
+		for _, e := range m.Records {
+			l = e.Size()
+			n += 1 + l + sovRpc(uint64(l))
+		}
+	}
+	l = len(m.NextPageToken)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	if m.TotalCount != 0 {
+		n += 1 + sovRpc(uint64(m.TotalCount))
+	}
+	if m.Page != 0 {
+		n += 1 + sovRpc(uint64(m.Page))
+	}
+	if m.PageSize != 0 {
+		n += 1 + sovRpc(uint64(m.PageSize))
+	}
+	if m.HasMore {
+		n += 2
+	}
+	return n
+}
+
+func (m *UpdateRecordReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	if m.TheNum != 0 {
+		n += 1 + sovRpc(uint64(m.TheNum))
+	}
+	l = len(m.TheStr)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	if m.Version != 0 {
+		n += 1 + sovRpc(uint64(m.Version))
+	}
+	return n
+}
+
+func (m *UpdateRecordRes) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Record != nil {
+		l = m.Record.Size()
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	return n
+}
+
+func (m *DeleteRecordReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	return n
+}
+
+func (m *DeleteRecordRes) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	return n
+}
+
+func (m *RestoreRecordReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	return n
+}
+
+func (m *RestoreRecordRes) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Record != nil {
+		l = m.Record.Size()
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	return n
+}
+
+func (m *BatchGetRecordsReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.IDs) > 0 {
+		for _, s := range m.IDs {
+			l = len(s)
+			n += 1 + l + sovRpc(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *BatchGetRecordsRes) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Results) > 0 {
+		for _, e := range m.Results {
+			l = e.Size()
+			n += 1 + l + sovRpc(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *BatchGetRecordsResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	if m.Record != nil {
+		l = m.Record.Size()
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	if m.NotFound {
+		n += 2
+	}
+	return n
+}
+
+func (m *ListRecordRevisionsReq) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	l = len(m.PageSize)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	l = len(m.PageToken)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	return n
+}
+
+func (m *ListRecordRevisionsRes) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Revisions) > 0 {
+		for _, e := range m.Revisions {
+			l = e.Size()
+			n += 1 + l + sovRpc(uint64(l))
+		}
+	}
+	l = len(m.NextPageToken)
+	if l > 0 {
+		n += 1 + l + sovRpc(uint64(l))
+	}
+	return n
+}
+
+func sovRpc(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozRpc(x uint64) (n int) {
+	return sovRpc(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *Record) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Record{`,
+		`ID:` + fmt.Sprintf("%v", this.ID) + `,`,
+		`TheNum:` + fmt.Sprintf("%v", this.TheNum) + `,`,
+		`TheStr:` + fmt.Sprintf("%v", this.TheStr) + `,`,
+		`CreatedAt:` + strings.Replace(fmt.Sprintf("%v", this.CreatedAt), "Timestamp", "types.Timestamp", 1) + `,`,
+		`UpdatedAt:` + strings.Replace(fmt.Sprintf("%v", this.UpdatedAt), "Timestamp", "types.Timestamp", 1) + `,`,
+		`DeletedAt:` + strings.Replace(fmt.Sprintf("%v", this.DeletedAt), "Timestamp", "types.Timestamp", 1) + `,`,
+		`Version:` + fmt.Sprintf("%v", this.Version) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *RecordRevision) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&RecordRevision{`,
+		`ID:` + fmt.Sprintf("%v", this.ID) + `,`,
+		`RecordID:` + fmt.Sprintf("%v", this.RecordID) + `,`,
+		`Action:` + fmt.Sprintf("%v", this.Action) + `,`,
+		`Actor:` + fmt.Sprintf("%v", this.Actor) + `,`,
+		`RequestID:` + fmt.Sprintf("%v", this.RequestID) + `,`,
+		`OldValue:` + strings.Replace(this.OldValue.String(), "Record", "Record", 1) + `,`,
+		`NewValue:` + strings.Replace(this.NewValue.String(), "Record", "Record", 1) + `,`,
+		`CreatedAt:` + strings.Replace(fmt.Sprintf("%v", this.CreatedAt), "Timestamp", "types.Timestamp", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *HealthReq) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&HealthReq{`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *HealthRes) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&HealthRes{`,
+		`Ok:` + fmt.Sprintf("%v", this.Ok) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ConfigReq) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ConfigReq{`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ConfigRes) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ConfigRes{`,
+		`Enable:` + fmt.Sprintf("%v", this.Enable) + `,`,
+		`Num:` + fmt.Sprintf("%v", this.Num) + `,`,
+		`Str:` + fmt.Sprintf("%v", this.Str) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *CreateRecordReq) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&CreateRecordReq{`,
+		`TheNum:` + fmt.Sprintf("%v", this.TheNum) + `,`,
+		`TheStr:` + fmt.Sprintf("%v", this.TheStr) + `,`,
+		`CreatedAt:` + strings.Replace(fmt.Sprintf("%v", this.CreatedAt), "Timestamp", "types.Timestamp", 1) + `,`,
+		`IdempotencyKey:` + fmt.Sprintf("%v", this.IdempotencyKey) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *CreateRecordRes) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&CreateRecordRes{`,
+		`Record:` + strings.Replace(this.Record.String(), "Record", "Record", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GetRecordReq) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GetRecordReq{`,
+		`ID:` + fmt.Sprintf("%v", this.ID) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GetRecordRes) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GetRecordRes{`,
+		`Record:` + strings.Replace(this.Record.String(), "Record", "Record", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ListRecordReq) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ListRecordReq{`,
+		`PageSize:` + fmt.Sprintf("%v", this.PageSize) + `,`,
+		`Page:` + fmt.Sprintf("%v", this.Page) + `,`,
+		`PageToken:` + fmt.Sprintf("%v", this.PageToken) + `,`,
+		`OrderBy:` + fmt.Sprintf("%v", this.OrderBy) + `,`,
+		`TheNumMin:` + fmt.Sprintf("%v", this.TheNumMin) + `,`,
+		`TheNumMax:` + fmt.Sprintf("%v", this.TheNumMax) + `,`,
+		`TheStr:` + fmt.Sprintf("%v", this.TheStr) + `,`,
+		`TheStrPrefix:` + fmt.Sprintf("%v", this.TheStrPrefix) + `,`,
+		`CreatedAfter:` + fmt.Sprintf("%v", this.CreatedAfter) + `,`,
+		`CreatedBefore:` + fmt.Sprintf("%v", this.CreatedBefore) + `,`,
+		`UpdatedAfter:` + fmt.Sprintf("%v", this.UpdatedAfter) + `,`,
+		`UpdatedBefore:` + fmt.Sprintf("%v", this.UpdatedBefore) + `,`,
+		`IncludeDeleted:` + fmt.Sprintf("%v", this.IncludeDeleted) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ListRecordRes) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForRecords := "[]*Record{"
+	for _, f := range this.Records {
+		repeatedStringForRecords += strings.Replace(f.String(), "Record", "Record", 1) + ","
+	}
+	repeatedStringForRecords += "}"
+	s := strings.Join([]string{`&ListRecordRes{`,
+		`Records:` + repeatedStringForRecords + `,`,
+		`NextPageToken:` + fmt.Sprintf("%v", this.NextPageToken) + `,`,
+		`TotalCount:` + fmt.Sprintf("%v", this.TotalCount) + `,`,
+		`Page:` + fmt.Sprintf("%v", this.Page) + `,`,
+		`PageSize:` + fmt.Sprintf("%v", this.PageSize) + `,`,
+		`HasMore:` + fmt.Sprintf("%v", this.HasMore) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *UpdateRecordReq) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&UpdateRecordReq{`,
+		`ID:` + fmt.Sprintf("%v", this.ID) + `,`,
+		`TheNum:` + fmt.Sprintf("%v", this.TheNum) + `,`,
+		`TheStr:` + fmt.Sprintf("%v", this.TheStr) + `,`,
+		`Version:` + fmt.Sprintf("%v", this.Version) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *UpdateRecordRes) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&UpdateRecordRes{`,
+		`Record:` + strings.Replace(this.Record.String(), "Record", "Record", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *DeleteRecordReq) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&DeleteRecordReq{`,
+		`ID:` + fmt.Sprintf("%v", this.ID) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *DeleteRecordRes) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&DeleteRecordRes{`,
+		`ID:` + fmt.Sprintf("%v", this.ID) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *RestoreRecordReq) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&RestoreRecordReq{`,
+		`ID:` + fmt.Sprintf("%v", this.ID) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *RestoreRecordRes) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&RestoreRecordRes{`,
+		`Record:` + strings.Replace(this.Record.String(), "Record", "Record", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *BatchGetRecordsReq) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&BatchGetRecordsReq{`,
+		`IDs:` + fmt.Sprintf("%v", this.IDs) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *BatchGetRecordsRes) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForResults := "[]*BatchGetRecordsResult{"
+	for _, f := range this.Results {
+		repeatedStringForResults += strings.Replace(f.String(), "BatchGetRecordsResult", "BatchGetRecordsResult", 1) + ","
+	}
+	repeatedStringForResults += "}"
+	s := strings.Join([]string{`&BatchGetRecordsRes{`,
+		`Results:` + repeatedStringForResults + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *BatchGetRecordsResult) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&BatchGetRecordsResult{`,
+		`ID:` + fmt.Sprintf("%v", this.ID) + `,`,
+		`Record:` + strings.Replace(this.Record.String(), "Record", "Record", 1) + `,`,
+		`NotFound:` + fmt.Sprintf("%v", this.NotFound) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ListRecordRevisionsReq) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ListRecordRevisionsReq{`,
+		`ID:` + fmt.Sprintf("%v", this.ID) + `,`,
+		`PageSize:` + fmt.Sprintf("%v", this.PageSize) + `,`,
+		`PageToken:` + fmt.Sprintf("%v", this.PageToken) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ListRecordRevisionsRes) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForRevisions := "[]*RecordRevision{"
+	for _, f := range this.Revisions {
+		repeatedStringForRevisions += strings.Replace(f.String(), "RecordRevision", "RecordRevision", 1) + ","
+	}
+	repeatedStringForRevisions += "}"
+	s := strings.Join([]string{`&ListRecordRevisionsRes{`,
+		`Revisions:` + repeatedStringForRevisions + `,`,
+		`NextPageToken:` + fmt.Sprintf("%v", this.NextPageToken) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringRpc(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *Record) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Record: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Record: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TheNum", wireType)
+			}
+			m.TheNum = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TheNum |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TheStr", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TheStr = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.CreatedAt == nil {
+				m.CreatedAt = new(time.Time)
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(m.CreatedAt, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UpdatedAt", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.UpdatedAt == nil {
+				m.UpdatedAt = new(time.Time)
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(m.UpdatedAt, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeletedAt", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DeletedAt == nil {
+				m.DeletedAt = new(time.Time)
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(m.DeletedAt, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RecordRevision) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RecordRevision: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RecordRevision: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RecordID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RecordID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Action", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Action = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Actor", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Actor = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RequestID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OldValue", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.OldValue == nil {
+				m.OldValue = &Record{}
+			}
+			if err := m.OldValue.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewValue", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.NewValue == nil {
+				m.NewValue = &Record{}
+			}
+			if err := m.NewValue.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.CreatedAt == nil {
+				m.CreatedAt = new(time.Time)
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(m.CreatedAt, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HealthReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HealthReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HealthReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HealthRes) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HealthRes: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HealthRes: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ok", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Ok = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConfigReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConfigReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConfigReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConfigRes) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConfigRes: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConfigRes: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Enable", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Enable = bool(v != 0)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Num", wireType)
+			}
+			m.Num = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Num |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Str", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Str = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CreateRecordReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CreateRecordReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CreateRecordReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TheNum", wireType)
+			}
+			m.TheNum = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TheNum |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TheStr", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TheStr = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.CreatedAt == nil {
+				m.CreatedAt = new(time.Time)
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(m.CreatedAt, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IdempotencyKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IdempotencyKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CreateRecordRes) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CreateRecordRes: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CreateRecordRes: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Record", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Record == nil {
+				m.Record = &Record{}
+			}
+			if err := m.Record.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetRecordReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetRecordReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetRecordReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetRecordRes) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetRecordRes: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetRecordRes: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Record", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Record == nil {
+				m.Record = &Record{}
+			}
+			if err := m.Record.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListRecordReq) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListRecordReq: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListRecordReq: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PageSize", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PageSize = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Page", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Page = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PageToken", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PageToken = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderBy", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OrderBy = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TheNumMin", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TheNumMin = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TheNumMax", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TheNumMax = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TheStr", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TheStr = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TheStrPrefix", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TheStrPrefix = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAfter", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CreatedAfter = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreatedBefore", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CreatedBefore = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UpdatedAfter", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UpdatedAfter = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UpdatedBefore", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UpdatedBefore = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IncludeDeleted", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IncludeDeleted = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListRecordRes) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListRecordRes: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListRecordRes: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Records", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Records = append(m.Records, &Record{})
+			if err := m.Records[len(m.Records)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NextPageToken", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NextPageToken = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalCount", wireType)
+			}
+			m.TotalCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TotalCount |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Page", wireType)
+			}
+			m.Page = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Page |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PageSize", wireType)
+			}
+			m.PageSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PageSize |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HasMore", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.HasMore = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
 	}
-	repeatedStringForRecords += "}"
-	s := strings.Join([]string{`&ListRecordRes{`,
-		`Records:` + repeatedStringForRecords + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func valueToStringRpc(v interface{}) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
-		return "nil"
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("*%v", pv)
+	return nil
 }
-func (m *Record) Unmarshal(dAtA []byte) error {
+func (m *UpdateRecordReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2042,10 +6734,10 @@ func (m *Record) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Record: wiretype end group for non-group")
+			return fmt.Errorf("proto: UpdateRecordReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Record: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UpdateRecordReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -2132,46 +6824,10 @@ func (m *Record) Unmarshal(dAtA []byte) error {
 			m.TheStr = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpc
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthRpc
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpc
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.CreatedAt == nil {
-				m.CreatedAt = new(time.Time)
-			}
-			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(m.CreatedAt, dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UpdatedAt", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
 			}
-			var msglen int
+			m.Version = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpc
@@ -2181,28 +6837,11 @@ func (m *Record) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Version |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthRpc
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRpc
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.UpdatedAt == nil {
-				m.UpdatedAt = new(time.Time)
-			}
-			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(m.UpdatedAt, dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpc(dAtA[iNdEx:])
@@ -2224,7 +6863,7 @@ func (m *Record) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HealthReq) Unmarshal(dAtA []byte) error {
+func (m *UpdateRecordRes) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2247,12 +6886,48 @@ func (m *HealthReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HealthReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: UpdateRecordRes: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HealthReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UpdateRecordRes: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Record", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Record == nil {
+				m.Record = &Record{}
+			}
+			if err := m.Record.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpc(dAtA[iNdEx:])
@@ -2274,7 +6949,7 @@ func (m *HealthReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HealthRes) Unmarshal(dAtA []byte) error {
+func (m *DeleteRecordReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2297,17 +6972,17 @@ func (m *HealthRes) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HealthRes: wiretype end group for non-group")
+			return fmt.Errorf("proto: DeleteRecordReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HealthRes: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DeleteRecordReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Ok", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
 			}
-			var v int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpc
@@ -2317,62 +6992,24 @@ func (m *HealthRes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Ok = bool(v != 0)
-		default:
-			iNdEx = preIndex
-			skippy, err := skipRpc(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthRpc
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ConfigReq) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowRpc
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ConfigReq: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ConfigReq: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpc(dAtA[iNdEx:])
@@ -2394,7 +7031,7 @@ func (m *ConfigReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ConfigRes) Unmarshal(dAtA []byte) error {
+func (m *DeleteRecordRes) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2416,55 +7053,16 @@ func (m *ConfigRes) Unmarshal(dAtA []byte) error {
 		}
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ConfigRes: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ConfigRes: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Enable", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpc
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.Enable = bool(v != 0)
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Num", wireType)
-			}
-			m.Num = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpc
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Num |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeleteRecordRes: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeleteRecordRes: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Str", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2492,7 +7090,7 @@ func (m *ConfigRes) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Str = string(dAtA[iNdEx:postIndex])
+			m.ID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2515,7 +7113,7 @@ func (m *ConfigRes) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CreateRecordReq) Unmarshal(dAtA []byte) error {
+func (m *RestoreRecordReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2538,34 +7136,15 @@ func (m *CreateRecordReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CreateRecordReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: RestoreRecordReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CreateRecordReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RestoreRecordReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TheNum", wireType)
-			}
-			m.TheNum = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRpc
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.TheNum |= int64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TheStr", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2593,11 +7172,61 @@ func (m *CreateRecordReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.TheStr = string(dAtA[iNdEx:postIndex])
+			m.ID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRpc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RestoreRecordRes) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRpc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RestoreRecordRes: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RestoreRecordRes: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Record", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2624,10 +7253,10 @@ func (m *CreateRecordReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.CreatedAt == nil {
-				m.CreatedAt = new(time.Time)
+			if m.Record == nil {
+				m.Record = &Record{}
 			}
-			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(m.CreatedAt, dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Record.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -2652,7 +7281,7 @@ func (m *CreateRecordReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CreateRecordRes) Unmarshal(dAtA []byte) error {
+func (m *BatchGetRecordsReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2675,17 +7304,17 @@ func (m *CreateRecordRes) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CreateRecordRes: wiretype end group for non-group")
+			return fmt.Errorf("proto: BatchGetRecordsReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CreateRecordRes: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BatchGetRecordsReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Record", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field IDs", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpc
@@ -2695,27 +7324,23 @@ func (m *CreateRecordRes) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthRpc
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpc
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Record == nil {
-				m.Record = &Record{}
-			}
-			if err := m.Record.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.IDs = append(m.IDs, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2738,7 +7363,7 @@ func (m *CreateRecordRes) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetRecordReq) Unmarshal(dAtA []byte) error {
+func (m *BatchGetRecordsRes) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2761,17 +7386,17 @@ func (m *GetRecordReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetRecordReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: BatchGetRecordsRes: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetRecordReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BatchGetRecordsRes: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Results", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRpc
@@ -2781,23 +7406,25 @@ func (m *GetRecordReq) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthRpc
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthRpc
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ID = string(dAtA[iNdEx:postIndex])
+			m.Results = append(m.Results, &BatchGetRecordsResult{})
+			if err := m.Results[len(m.Results)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2820,7 +7447,7 @@ func (m *GetRecordReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetRecordRes) Unmarshal(dAtA []byte) error {
+func (m *BatchGetRecordsResult) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2843,13 +7470,45 @@ func (m *GetRecordRes) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetRecordRes: wiretype end group for non-group")
+			return fmt.Errorf("proto: BatchGetRecordsResult: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetRecordRes: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BatchGetRecordsResult: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Record", wireType)
 			}
@@ -2885,6 +7544,26 @@ func (m *GetRecordRes) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NotFound", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.NotFound = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpc(dAtA[iNdEx:])
@@ -2906,7 +7585,7 @@ func (m *GetRecordRes) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ListRecordReq) Unmarshal(dAtA []byte) error {
+func (m *ListRecordRevisionsReq) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2929,13 +7608,45 @@ func (m *ListRecordReq) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ListRecordReq: wiretype end group for non-group")
+			return fmt.Errorf("proto: ListRecordRevisionsReq: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ListRecordReq: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ListRecordRevisionsReq: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field PageSize", wireType)
 			}
@@ -2967,9 +7678,9 @@ func (m *ListRecordReq) Unmarshal(dAtA []byte) error {
 			}
 			m.PageSize = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Page", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PageToken", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2997,7 +7708,7 @@ func (m *ListRecordReq) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Page = string(dAtA[iNdEx:postIndex])
+			m.PageToken = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -3020,7 +7731,7 @@ func (m *ListRecordReq) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ListRecordRes) Unmarshal(dAtA []byte) error {
+func (m *ListRecordRevisionsRes) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3043,15 +7754,15 @@ func (m *ListRecordRes) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ListRecordRes: wiretype end group for non-group")
+			return fmt.Errorf("proto: ListRecordRevisionsRes: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ListRecordRes: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ListRecordRevisionsRes: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Records", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Revisions", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3078,11 +7789,43 @@ func (m *ListRecordRes) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Records = append(m.Records, &Record{})
-			if err := m.Records[len(m.Records)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Revisions = append(m.Revisions, &RecordRevision{})
+			if err := m.Revisions[len(m.Revisions)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NextPageToken", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRpc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRpc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRpc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NextPageToken = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRpc(dAtA[iNdEx:])
@@ -0,0 +1,81 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+)
+
+// sweepInterval drops the full buckets, they are the same as the new ones.
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens float64
+	ts     time.Time
+	// limit is the last limit of the key, the limits of the keys differ and are hot reloaded.
+	limit Limit
+}
+
+type localLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewLocalLimiter keeps the buckets in the memory, the limits are per pod.
+func NewLocalLimiter() Limiter {
+	return newLocalLimiter(time.Now)
+}
+
+func newLocalLimiter(now func() time.Time) *localLimiter {
+	return &localLimiter{buckets: map[string]*bucket{}, lastSweep: now(), now: now}
+}
+
+func (l *localLimiter) Allow(_ context.Context, key string, limit Limit) (Result, error) {
+	if limit.Rate <= 0 || limit.Burst <= 0 {
+		return Result{}, errors.New("the rate and the burst must be positive")
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), ts: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens = math.Min(float64(limit.Burst), b.tokens+now.Sub(b.ts).Seconds()*limit.Rate)
+	b.ts = now
+	b.limit = limit
+
+	if b.tokens < 1 {
+		return Result{
+			Remaining:  b.tokens,
+			RetryAfter: time.Duration((1 - b.tokens) / limit.Rate * float64(time.Second)),
+		}, nil
+	}
+
+	b.tokens--
+	return Result{Allowed: true, Remaining: b.tokens}, nil
+}
+
+// sweep drops the buckets refilled by their own limits.
+func (l *localLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	for key, b := range l.buckets {
+		if b.tokens+now.Sub(b.ts).Seconds()*b.limit.Rate >= float64(b.limit.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
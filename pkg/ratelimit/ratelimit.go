@@ -0,0 +1,56 @@
+// Package ratelimit limits the calls by token buckets, shared by all the pods through the redis ring.
+package ratelimit
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/AmazingTalker/go-rpc-kit/logkit"
+)
+
+// Limit is a token bucket, Rate tokens are added every second up to Burst.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+type Result struct {
+	Allowed bool
+	// Remaining is the tokens left in the bucket.
+	Remaining float64
+	// RetryAfter is the time until the next token when the call is not allowed.
+	RetryAfter time.Duration
+}
+
+// Limiter takes a token from the bucket of key.
+type Limiter interface {
+	Allow(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+type fallbackLimiter struct {
+	primary  Limiter
+	fallback Limiter
+	// failing is 1 while the primary fails, only the switches between them are logged.
+	failing int32
+}
+
+// NewFallbackLimiter uses fallback when primary fails, ex: the local limiter when redis is unreachable.
+func NewFallbackLimiter(primary, fallback Limiter) Limiter {
+	return &fallbackLimiter{primary: primary, fallback: fallback}
+}
+
+func (l *fallbackLimiter) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	res, err := l.primary.Allow(ctx, key, limit)
+	if err == nil {
+		if atomic.CompareAndSwapInt32(&l.failing, 1, 0) {
+			logkit.Info(ctx, "primary limiter recovered", logkit.Payload{"key": key})
+		}
+		return res, nil
+	}
+
+	if atomic.CompareAndSwapInt32(&l.failing, 0, 1) {
+		logkit.WarnV2(ctx, "primary limiter failed, use the fallback till it recovers", err, logkit.Payload{"key": key})
+	}
+	return l.fallback.Allow(ctx, key, limit)
+}
@@ -0,0 +1,229 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/AmazingTalker/go-rpc-kit/dockerkit"
+	"github.com/AmazingTalker/go-rpc-kit/logkit"
+)
+
+const (
+	rdsURLFormat = ":%s"
+)
+
+var (
+	mockCTX     = context.Background()
+	mockTimeNow = time.Unix(1629446406, 0)
+	mockLimit   = Limit{Rate: 1, Burst: 2}
+)
+
+// failingLimiter fails every call.
+type failingLimiter struct{}
+
+func (failingLimiter) Allow(_ context.Context, _ string, _ Limit) (Result, error) {
+	return Result{}, errors.New("XD")
+}
+
+// switchLimiter fails the calls while err is set, and allows the others.
+type switchLimiter struct {
+	err error
+}
+
+func (l *switchLimiter) Allow(_ context.Context, _ string, _ Limit) (Result, error) {
+	if l.err != nil {
+		return Result{}, l.err
+	}
+	return Result{Allowed: true}, nil
+}
+
+type ratelimitSuite struct {
+	suite.Suite
+
+	ring      *redis.Ring
+	redisPort string
+}
+
+func (s *ratelimitSuite) redisAddrs() map[string]string {
+	if dockerkit.RunCITest() {
+		addrs := strings.Split(os.Getenv("REDIS_ADDRS"), ",")
+
+		m := map[string]string{}
+		for _, addr := range addrs {
+			strs := strings.SplitN(addr, ":", 2)
+			m[strs[0]] = strs[1]
+		}
+
+		return m
+	}
+
+	return map[string]string{"server1": fmt.Sprintf(rdsURLFormat, s.redisPort)}
+}
+
+func (s *ratelimitSuite) SetupSuite() {
+	logkit.RegisterAmazingLogger(&logkit.Config{
+		Logger:              logkit.LoggerZap,
+		Development:         true,
+		IntegrationAirbrake: &logkit.IntegrationAirbrake{},
+	})
+
+	// run dockerkit when dealing with go test locally
+	if dockerkit.RunLocalTest() {
+		ports, err := dockerkit.RunExtDockers(mockCTX, []dockerkit.Image{
+			dockerkit.ImageRedis,
+		})
+		s.Require().NoError(err)
+		s.redisPort = ports[0]
+	}
+
+	s.ring = redis.NewRing(&redis.RingOptions{
+		Addrs: s.redisAddrs(),
+	})
+}
+
+func (s *ratelimitSuite) TearDownSuite() {
+	s.ring.Close()
+
+	if dockerkit.RunLocalTest() {
+		dockerkit.PurgeExtDockers(mockCTX, []dockerkit.Image{
+			dockerkit.ImageRedis,
+		})
+	}
+
+	logkit.Flush()
+}
+
+func (s *ratelimitSuite) SetupTest() {
+	s.Require().NoError(s.ring.ForEachShard(mockCTX, func(ctx context.Context, client *redis.Client) error {
+		return client.FlushDB(ctx).Err()
+	}))
+}
+
+func TestRateLimitSuite(t *testing.T) {
+	suite.Run(t, new(ratelimitSuite))
+}
+
+func (s *ratelimitSuite) TestLocalLimiter() {
+	now := mockTimeNow
+	l := newLocalLimiter(func() time.Time { return now })
+
+	tests := []struct {
+		Desc          string
+		Key           string
+		Elapsed       time.Duration
+		ExpAllowed    bool
+		ExpRetryAfter time.Duration
+	}{
+		{Desc: "first token of the burst", Key: "a", ExpAllowed: true},
+		{Desc: "second token of the burst", Key: "a", ExpAllowed: true},
+		{Desc: "bucket is empty", Key: "a", ExpAllowed: false, ExpRetryAfter: time.Second},
+		{Desc: "another key", Key: "b", ExpAllowed: true},
+		{Desc: "half refilled", Key: "a", Elapsed: 500 * time.Millisecond, ExpAllowed: false, ExpRetryAfter: 500 * time.Millisecond},
+		{Desc: "refilled", Key: "a", Elapsed: 500 * time.Millisecond, ExpAllowed: true},
+	}
+
+	for _, t := range tests {
+		now = now.Add(t.Elapsed)
+
+		res, err := l.Allow(mockCTX, t.Key, mockLimit)
+		s.Require().NoError(err, t.Desc)
+		s.Require().Equal(t.ExpAllowed, res.Allowed, t.Desc)
+		s.Require().Equal(t.ExpRetryAfter, res.RetryAfter, t.Desc)
+	}
+
+	// the buckets are swept by their own limits, the slow one isn't full yet
+	_, err := l.Allow(mockCTX, "slow", Limit{Rate: 0.001, Burst: 2})
+	s.Require().NoError(err)
+
+	now = now.Add(sweepInterval)
+	_, err = l.Allow(mockCTX, "c", mockLimit)
+	s.Require().NoError(err)
+	s.Require().Len(l.buckets, 2)
+	s.Require().Contains(l.buckets, "slow")
+
+	_, err = l.Allow(mockCTX, "a", Limit{})
+	s.Require().Error(err, "invalid limit")
+}
+
+func (s *ratelimitSuite) TestRedisLimiter() {
+	l := NewRedisLimiter(s.ring)
+
+	for i := 0; i < mockLimit.Burst; i++ {
+		res, err := l.Allow(mockCTX, "a", mockLimit)
+		s.Require().NoError(err)
+		s.Require().True(res.Allowed, "token %d of the burst", i)
+	}
+
+	res, err := l.Allow(mockCTX, "a", mockLimit)
+	s.Require().NoError(err)
+	s.Require().False(res.Allowed, "bucket is empty")
+	s.Require().True(res.RetryAfter > 0 && res.RetryAfter <= time.Second, "retry after %s", res.RetryAfter)
+
+	res, err = l.Allow(mockCTX, "b", mockLimit)
+	s.Require().NoError(err)
+	s.Require().True(res.Allowed, "another key")
+
+	ttl, err := s.ring.TTL(mockCTX, pfxRateLimit+"a").Result()
+	s.Require().NoError(err)
+	s.Require().True(ttl > 0, "the bucket expires")
+
+	time.Sleep(res.RetryAfter + time.Second)
+
+	res, err = l.Allow(mockCTX, "a", mockLimit)
+	s.Require().NoError(err)
+	s.Require().True(res.Allowed, "refilled")
+}
+
+func (s *ratelimitSuite) TestFallbackLimiter() {
+	l := NewFallbackLimiter(failingLimiter{}, NewLocalLimiter())
+
+	for i := 0; i < mockLimit.Burst; i++ {
+		res, err := l.Allow(mockCTX, "a", mockLimit)
+		s.Require().NoError(err)
+		s.Require().True(res.Allowed, "token %d of the burst", i)
+	}
+
+	res, err := l.Allow(mockCTX, "a", mockLimit)
+	s.Require().NoError(err)
+	s.Require().False(res.Allowed, "limited by the fallback")
+}
+
+func (s *ratelimitSuite) TestFallbackLimiterSwitch() {
+	primary := &switchLimiter{}
+	l := NewFallbackLimiter(primary, NewLocalLimiter()).(*fallbackLimiter)
+
+	tests := []struct {
+		Desc       string
+		Err        error
+		ExpFailing int32
+	}{
+		{Desc: "primary works", ExpFailing: 0},
+		{Desc: "primary fails", Err: errors.New("XD"), ExpFailing: 1},
+		{Desc: "primary still fails", Err: errors.New("XD"), ExpFailing: 1},
+		{Desc: "primary recovers", ExpFailing: 0},
+	}
+
+	for _, t := range tests {
+		primary.err = t.Err
+
+		res, err := l.Allow(mockCTX, "a", mockLimit)
+		s.Require().NoError(err, t.Desc)
+		s.Require().True(res.Allowed, t.Desc)
+		s.Require().Equal(t.ExpFailing, atomic.LoadInt32(&l.failing), t.Desc)
+	}
+}
+
+func (s *ratelimitSuite) TestRetryAfterSeconds() {
+	s.Require().Equal("1", retryAfterSeconds(0))
+	s.Require().Equal("1", retryAfterSeconds(200*time.Millisecond))
+	s.Require().Equal("2", retryAfterSeconds(1200*time.Millisecond))
+}
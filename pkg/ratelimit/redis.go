@@ -0,0 +1,95 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const pfxRateLimit = "go-amazing:ratelimit:"
+
+// tokenBucket refills the bucket by the time of redis, so the clocks of the pods don't matter.
+// KEYS[1]: the bucket, ARGV: rate, burst. It returns {allowed, remaining, retry after in seconds}.
+var tokenBucket = redis.NewScript(`
+redis.replicate_commands()
+
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+
+local t = redis.call("TIME")
+local now = tonumber(t[1]) + tonumber(t[2]) / 1000000
+
+local state = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(state[1]) or burst
+local ts = tonumber(state[2]) or now
+
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate)
+
+local allowed = 0
+local retry_after = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	retry_after = (1 - tokens) / rate
+end
+
+redis.call("HMSET", KEYS[1], "tokens", tokens, "ts", now)
+redis.call("EXPIRE", KEYS[1], math.ceil(burst / rate) + 1)
+
+return {allowed, tostring(tokens), tostring(retry_after)}
+`)
+
+type redisLimiter struct {
+	ring *redis.Ring
+}
+
+// NewRedisLimiter keeps the buckets in the ring, every key is on one shard.
+func NewRedisLimiter(ring *redis.Ring) Limiter {
+	return &redisLimiter{ring: ring}
+}
+
+func (l *redisLimiter) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	if limit.Rate <= 0 || limit.Burst <= 0 {
+		return Result{}, errors.New("the rate and the burst must be positive")
+	}
+
+	v, err := tokenBucket.Run(ctx, l.ring, []string{pfxRateLimit + key}, limit.Rate, limit.Burst).Result()
+	if err != nil {
+		return Result{}, err
+	}
+
+	values, ok := v.([]interface{})
+	if !ok || len(values) != 3 {
+		return Result{}, fmt.Errorf("unexpected result %v", v)
+	}
+
+	allowed, _ := values[0].(int64)
+	remaining, err := parseFloat(values[1])
+	if err != nil {
+		return Result{}, err
+	}
+	retryAfter, err := parseFloat(values[2])
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Result{
+		Allowed:    allowed == 1,
+		Remaining:  remaining,
+		RetryAfter: time.Duration(retryAfter * float64(time.Second)),
+	}, nil
+}
+
+func parseFloat(v interface{}) (float64, error) {
+	s, ok := v.(string)
+	if !ok {
+		return 0, fmt.Errorf("unexpected value %v", v)
+	}
+
+	return strconv.ParseFloat(s, 64)
+}
@@ -0,0 +1,81 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	headerRetryAfter = "Retry-After"
+	mdRetryAfter     = "retry-after"
+)
+
+type clientIPKey struct{}
+
+type retryAfterKey struct{}
+
+// ClientIP returns the ip of the caller, it's empty outside the middlewares.
+func ClientIP(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey{}).(string)
+	return ip
+}
+
+// SetRetryAfter sends the Retry-After header or metadata of the transport, it has to be called before the
+// response is written.
+func SetRetryAfter(ctx context.Context, d time.Duration) {
+	if set, ok := ctx.Value(retryAfterKey{}).(func(time.Duration)); ok {
+		set(d)
+	}
+}
+
+// retryAfterSeconds rounds d up, at least 1 second.
+func retryAfterSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Max(1, math.Ceil(d.Seconds()))))
+}
+
+func withTransport(ctx context.Context, ip string, setRetryAfter func(time.Duration)) context.Context {
+	ctx = context.WithValue(ctx, clientIPKey{}, ip)
+	return context.WithValue(ctx, retryAfterKey{}, setRetryAfter)
+}
+
+// GinMiddleware puts the client ip and the Retry-After header into the context for the limiter.
+func GinMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		c := withTransport(ctx.Request.Context(), ctx.ClientIP(), func(d time.Duration) {
+			ctx.Header(headerRetryAfter, retryAfterSeconds(d))
+		})
+
+		ctx.Request = ctx.Request.WithContext(c)
+		ctx.Next()
+	}
+}
+
+func grpcTransport(ctx context.Context) context.Context {
+	ip := ""
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		ip = p.Addr.String()
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+	}
+
+	return withTransport(ctx, ip, func(d time.Duration) {
+		// it fails only when the header is sent already
+		_ = grpc.SetHeader(ctx, metadata.Pairs(mdRetryAfter, retryAfterSeconds(d)))
+	})
+}
+
+// UnaryServerInterceptor puts the peer ip and the retry-after metadata into the context for the limiter.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(grpcTransport(ctx), req)
+	}
+}
@@ -0,0 +1,41 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	errCodes "github.com/AmazingTalker/at-error-code"
+	"github.com/AmazingTalker/go-amazing/pkg/auth"
+	"github.com/AmazingTalker/go-amazing/pkg/rpc/config"
+	"github.com/AmazingTalker/go-rpc-kit/errorkit"
+	"github.com/AmazingTalker/go-rpc-kit/logkit"
+)
+
+func newPermissionDeniedError(err error) error {
+	return errorkit.NewFromError(errCodes.ErrPermissionDenied, err, errorkit.WithHttpStatusCode(http.StatusForbidden))
+}
+
+// authorize checks the roles of the caller against the policy of the dynamic config, the policy is
+// hot reloaded. The denials are logged for the audit.
+func (serv GoAmazingServer) authorize(ctx context.Context, method string) error {
+	id, _ := auth.IdentityFromContext(ctx)
+
+	if serv.authzConfig().Allows(id.Roles, method) {
+		return nil
+	}
+
+	logkit.Warn(ctx, "authz denied", logkit.Payload{
+		"audit":      true,
+		"method":     method,
+		"caller":     id.Subject,
+		"authMethod": id.Method,
+		"roles":      id.Roles,
+	})
+
+	return newPermissionDeniedError(fmt.Errorf("the caller is not allowed to call %s", method))
+}
+
+func defaultAuthzConfig() config.AuthzConfig {
+	return config.Config().Authz
+}
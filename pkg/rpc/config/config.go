@@ -15,9 +15,69 @@ var (
 )
 
 type DynamicConfig struct {
-	Enable bool   `json:"enable,omitempty"`
-	Num    int64  `json:"num,omitempty"`
-	Str    string `json:"str,omitempty"`
+	Enable bool        `json:"enable,omitempty"`
+	Num    int64       `json:"num,omitempty"`
+	Str    string      `json:"str,omitempty"`
+	Authz  AuthzConfig `json:"authz,omitempty"`
+
+	RateLimit RateLimitConfig `json:"rateLimit,omitempty"`
+}
+
+// AuthzConfig is the role based policy of the GoAmazing methods. The HTTP routes and the GraphQL
+// fields are authorized by the methods they call, ex: GET /api/records/:id is GetRecord.
+type AuthzConfig struct {
+	// Enable turns on the authorization, every authenticated caller may call every method when it's off.
+	Enable bool `json:"enable,omitempty"`
+	// Roles maps a role to the methods it may call, "*" means all the methods.
+	// ex: {"reader": ["GetRecord", "BatchGetRecords", "ListRecord"], "admin": ["*"]}
+	Roles map[string][]string `json:"roles,omitempty"`
+}
+
+// Allows tells whether any of the roles may call the method.
+func (c AuthzConfig) Allows(roles []string, method string) bool {
+	if !c.Enable {
+		return true
+	}
+
+	for _, role := range roles {
+		for _, m := range c.Roles[role] {
+			if m == "*" || m == method {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// RateLimitConfig limits the calls of every caller to every GoAmazing method, the callers are the
+// authenticated subjects, or the ips.
+type RateLimitConfig struct {
+	Enable bool `json:"enable,omitempty"`
+	// Default limits the methods not in Methods, they are not limited when it's zero.
+	Default RateLimit `json:"default,omitempty"`
+	// Methods maps a method to its limit, ex: {"CreateRecord": {"rate": 5, "burst": 10}}
+	Methods map[string]RateLimit `json:"methods,omitempty"`
+}
+
+// RateLimit is a token bucket, Rate tokens are added every second up to Burst.
+type RateLimit struct {
+	Rate  float64 `json:"rate,omitempty"`
+	Burst int     `json:"burst,omitempty"`
+}
+
+// Limit returns the limit of the method, ok is false when the method is not limited.
+func (c RateLimitConfig) Limit(method string) (RateLimit, bool) {
+	if !c.Enable {
+		return RateLimit{}, false
+	}
+
+	l, ok := c.Methods[method]
+	if !ok {
+		l = c.Default
+	}
+
+	return l, l.Rate > 0 && l.Burst > 0
 }
 
 func init() {
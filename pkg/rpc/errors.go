@@ -0,0 +1,89 @@
+package rpc
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	grpcCodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	errCodes "github.com/AmazingTalker/at-error-code"
+	"github.com/AmazingTalker/go-amazing/pkg/dao"
+	"github.com/AmazingTalker/go-rpc-kit/errorkit"
+)
+
+// statusClientClosedRequest is the status of nginx for the requests canceled by the clients.
+const statusClientClosedRequest = 499
+
+// formatError translates the errors from dao into AT errors.
+// Other errors are returned as they are.
+func formatError(err error) error {
+	switch {
+	case errors.Is(err, dao.ErrNotFound):
+		return errorkit.NewFromError(errCodes.ErrNotFound, err, errorkit.WithHttpStatusCode(http.StatusNotFound))
+	case errors.Is(err, dao.ErrConflict):
+		atErr := errorkit.NewFromError(errCodes.ErrConflict, err, errorkit.WithHttpStatusCode(http.StatusConflict))
+
+		var versionErr *dao.VersionConflictError
+		if errors.As(err, &versionErr) {
+			return &versionConflictError{ATError: atErr, currentVersion: versionErr.Current}
+		}
+		return atErr
+	case errors.Is(err, dao.ErrInvalidArgument):
+		return newInvalidArgumentError(err)
+	case errors.Is(err, dao.ErrUnavailable):
+		return newUnavailableError(err)
+	case errors.Is(err, dao.ErrCanceled):
+		return errorkit.NewFromError(errCodes.ErrServiceUnavailable, err, errorkit.WithHttpStatusCode(statusClientClosedRequest))
+	}
+
+	return err
+}
+
+func newUnavailableError(err error) error {
+	return errorkit.NewFromError(errCodes.ErrServiceUnavailable, err, errorkit.WithHttpStatusCode(http.StatusServiceUnavailable))
+}
+
+func newInvalidArgumentError(err error) error {
+	return errorkit.NewFromError(errCodes.ErrInvalidArgument, err, errorkit.WithHttpStatusCode(http.StatusBadRequest))
+}
+
+func newPreconditionFailedError(err error) error {
+	return errorkit.NewFromError(errCodes.ErrConflict, err, errorkit.WithHttpStatusCode(http.StatusPreconditionFailed))
+}
+
+// versionConflictError adds the current version into the error body of the conflict error,
+// the clients retry the update with it after reading the record again.
+type versionConflictError struct {
+	errorkit.ATError
+	currentVersion int64
+}
+
+func (e *versionConflictError) GinHashMap() gin.H {
+	h := gin.H{}
+	for k, v := range e.ATError.GinHashMap() {
+		h[k] = v
+	}
+	h["currentVersion"] = e.currentVersion
+
+	return h
+}
+
+// GRPCStatus gives the current version to the grpc callers in the metadata of the ErrorInfo
+// details, ex: {"currentVersion": "3"}
+func (e *versionConflictError) GRPCStatus() *status.Status {
+	info := &errdetails.ErrorInfo{
+		Reason:   "VERSION_CONFLICT",
+		Domain:   "go-amazing",
+		Metadata: map[string]string{"currentVersion": strconv.FormatInt(e.currentVersion, 10)},
+	}
+
+	st := status.New(grpcCodes.Aborted, e.Error())
+	if withDetails, err := st.WithDetails(info); err == nil {
+		return withDetails
+	}
+	return st
+}
@@ -0,0 +1,125 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/AmazingTalker/go-amazing/pkg/dao"
+	"github.com/AmazingTalker/go-amazing/pkg/pb"
+)
+
+const (
+	headerETag    = "ETag"
+	headerIfMatch = "If-Match"
+)
+
+// ifMatch is the If-Match header (RFC 7232) of an update.
+type ifMatch struct {
+	// any is "*", it matches any current version of the record.
+	any bool
+	// versions are of the ETags listed, ex: "3", "4"
+	versions []int64
+}
+
+func (m *ifMatch) matches(version int64) bool {
+	if m.any {
+		return true
+	}
+
+	for _, v := range m.versions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
+// setRecordETag sets the ETag header of the http response to the version of the record.
+func setRecordETag(ctx context.Context, r *pb.Record) {
+	if r != nil {
+		setHeader(ctx, headerETag, recordETag(r.Version))
+	}
+}
+
+// expectedVersion returns the version expected by the update, it's the version of the request or
+// the If-Match header on HTTP, they must match when both are given. "*" and the lists of ETags
+// are matched against the current version of the record, and the update is 412 when the record
+// doesn't exist or isn't at any of the versions. It's 0 when neither is given.
+func (serv GoAmazingServer) expectedVersion(ctx context.Context, id string, version int64) (int64, error) {
+	m, err := parseIfMatch(requestHeader(ctx, headerIfMatch))
+	if err != nil {
+		return 0, newInvalidArgumentError(err)
+	}
+
+	switch {
+	case m == nil:
+		return version, nil
+	case version != 0:
+		if !m.matches(version) {
+			return 0, newInvalidArgumentError(fmt.Errorf("the version %d doesn't match If-Match", version))
+		}
+		return version, nil
+	case len(m.versions) == 1:
+		// compared by the update
+		return m.versions[0], nil
+	}
+
+	current, err := serv.recordDao.GetRecord(ctx, id)
+	if errors.Is(err, dao.ErrNotFound) {
+		return 0, newPreconditionFailedError(err)
+	}
+	if err != nil {
+		return 0, formatError(err)
+	}
+
+	if !m.matches(current.Version) {
+		return 0, newPreconditionFailedError(fmt.Errorf("the current version %d doesn't match If-Match", current.Version))
+	}
+	return current.Version, nil
+}
+
+// recordETag is the ETag header of the record at the version, ex: "3"
+func recordETag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// parseIfMatch parses the If-Match header, it's nil when the header isn't given. The weak ETags
+// never match the updates by the strong comparison, so they're rejected as the malformed ones.
+func parseIfMatch(header string) (*ifMatch, error) {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return nil, nil
+	}
+	if header == "*" {
+		return &ifMatch{any: true}, nil
+	}
+
+	m := &ifMatch{}
+	for _, etag := range strings.Split(header, ",") {
+		version, err := parseRecordETag(etag)
+		if err != nil {
+			return nil, err
+		}
+		m.versions = append(m.versions, version)
+	}
+
+	return m, nil
+}
+
+// parseRecordETag returns the version of a strong ETag of recordETag.
+func parseRecordETag(etag string) (int64, error) {
+	etag = strings.TrimSpace(etag)
+	if len(etag) < 2 || etag[0] != '"' || etag[len(etag)-1] != '"' {
+		return 0, fmt.Errorf("invalid If-Match %q, it should be quoted versions or *, ex: \"3\"", etag)
+	}
+
+	version, err := strconv.ParseInt(etag[1:len(etag)-1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid If-Match %q: %w", etag, err)
+	}
+
+	return version, nil
+}
@@ -0,0 +1,39 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/metadata"
+
+	"github.com/AmazingTalker/go-amazing/pkg/pb"
+)
+
+const (
+	// mdIdempotencyKey is the grpc metadata carrying the idempotency key of CreateRecord,
+	// and headerIdempotencyKey is the http header.
+	mdIdempotencyKey     = "idempotency-key"
+	headerIdempotencyKey = "Idempotency-Key"
+	maxIdempotencyKeyLen = 255
+)
+
+// idempotencyKey takes the key from the request, or the metadata or the header when the request
+// doesn't carry one.
+func idempotencyKey(ctx context.Context, req *pb.CreateRecordReq) (string, error) {
+	key := req.IdempotencyKey
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok && key == "" {
+		if vals := md.Get(mdIdempotencyKey); len(vals) > 0 {
+			key = vals[0]
+		}
+	}
+	if key == "" {
+		key = requestHeader(ctx, headerIdempotencyKey)
+	}
+
+	if len(key) > maxIdempotencyKeyLen {
+		return "", fmt.Errorf("idempotency key is longer than %d characters", maxIdempotencyKeyLen)
+	}
+
+	return key, nil
+}
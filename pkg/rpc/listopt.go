@@ -0,0 +1,139 @@
+package rpc
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AmazingTalker/go-amazing/pkg/dao"
+	"github.com/AmazingTalker/go-amazing/pkg/pb"
+)
+
+// parsePageSize parses the size of the lists, it's defaultPageSize when empty or not positive,
+// and maxPageSize at most.
+func parsePageSize(s string) (int, error) {
+	if s == "" {
+		return defaultPageSize, nil
+	}
+
+	size, err := strconv.ParseInt(s, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+
+	if size <= 0 {
+		return defaultPageSize, nil
+	}
+	if size > maxPageSize {
+		return maxPageSize, nil
+	}
+
+	return int(size), nil
+}
+
+// parseRecordFilter parses the filters of ListRecord, they come in the query string as strings.
+func parseRecordFilter(req *pb.ListRecordReq) (dao.RecordFilter, error) {
+	filter := dao.RecordFilter{TheStr: req.TheStr, TheStrPrefix: req.TheStrPrefix}
+
+	nums := []struct {
+		name string
+		val  string
+		dst  **int64
+	}{
+		{"the_num_min", req.TheNumMin, &filter.TheNumMin},
+		{"the_num_max", req.TheNumMax, &filter.TheNumMax},
+	}
+	for _, n := range nums {
+		if n.val == "" {
+			continue
+		}
+
+		v, err := strconv.ParseInt(n.val, 10, 64)
+		if err != nil {
+			return filter, fmt.Errorf("invalid %s: %w", n.name, err)
+		}
+		*n.dst = &v
+	}
+
+	times := []struct {
+		name string
+		val  string
+		dst  **time.Time
+	}{
+		{"created_after", req.CreatedAfter, &filter.CreatedAfter},
+		{"created_before", req.CreatedBefore, &filter.CreatedBefore},
+		{"updated_after", req.UpdatedAfter, &filter.UpdatedAfter},
+		{"updated_before", req.UpdatedBefore, &filter.UpdatedBefore},
+	}
+	for _, t := range times {
+		if t.val == "" {
+			continue
+		}
+
+		v, err := time.Parse(time.RFC3339, t.val)
+		if err != nil {
+			return filter, fmt.Errorf("invalid %s: %w", t.name, err)
+		}
+		v = v.UTC()
+		*t.dst = &v
+	}
+
+	if req.IncludeDeleted != "" {
+		v, err := strconv.ParseBool(req.IncludeDeleted)
+		if err != nil {
+			return filter, fmt.Errorf("invalid include_deleted: %w", err)
+		}
+		filter.IncludeDeleted = v
+	}
+
+	return filter, nil
+}
+
+// parseRecordOrder parses a comma separated list of "field [asc|desc]", ex: "the_num desc,created_at".
+// The fields are checked by the dao.
+func parseRecordOrder(orderBy string) ([]dao.RecordOrder, error) {
+	if strings.TrimSpace(orderBy) == "" {
+		return nil, nil
+	}
+
+	order := []dao.RecordOrder{}
+	for _, part := range strings.Split(orderBy, ",") {
+		fields := strings.Fields(part)
+		if len(fields) == 0 || len(fields) > 2 {
+			return nil, fmt.Errorf("invalid order_by %q", orderBy)
+		}
+
+		o := dao.RecordOrder{Field: fields[0]}
+		if len(fields) == 2 {
+			switch strings.ToLower(fields[1]) {
+			case "asc":
+			case "desc":
+				o.Desc = true
+			default:
+				return nil, fmt.Errorf("invalid order direction %q", fields[1])
+			}
+		}
+
+		order = append(order, o)
+	}
+
+	return order, nil
+}
+
+// listQueryFingerprint identifies the filters and the order, to bind the page tokens to them.
+func listQueryFingerprint(filter dao.RecordFilter, orderBy []dao.RecordOrder) (string, error) {
+	b, err := json.Marshal(struct {
+		Filter  dao.RecordFilter
+		OrderBy []dao.RecordOrder
+	}{filter, orderBy})
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(b)
+	return base64.RawURLEncoding.EncodeToString(sum[:12]), nil
+}
@@ -0,0 +1,140 @@
+package rpc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/AmazingTalker/go-amazing/pkg/dao"
+)
+
+var (
+	errInvalidPageToken = errors.New("invalid page token")
+)
+
+// pageToken is the payload of the page tokens of ListRecord.
+type pageToken struct {
+	ID        string    `json:"i"`
+	TheNum    int64     `json:"n"`
+	TheStr    string    `json:"s"`
+	CreatedAt time.Time `json:"c"`
+	UpdatedAt time.Time `json:"u"`
+	// Page is the page the token leads to, paging by token has no page number otherwise.
+	Page int `json:"p"`
+	// Total is the total count of the first page, the later pages don't count again.
+	Total int64 `json:"t"`
+	// Query is the fingerprint of the filters and the order the token is issued for,
+	// the cursor means nothing to another query.
+	Query string `json:"q"`
+}
+
+// revisionPageToken is the payload of the page tokens of ListRecordRevisions.
+type revisionPageToken struct {
+	// Before is the last revision id of the previous page.
+	Before int64 `json:"b"`
+	// RecordID binds the token to the record, the revision ids mean nothing to another record.
+	RecordID string `json:"r"`
+}
+
+// encodePageToken signs the cursor, so clients can't forge it.
+func encodePageToken(secret []byte, cursor dao.Cursor, page int, total int64, query string) (string, error) {
+	return sealPageToken(secret, pageToken{
+		ID:        cursor.ID,
+		TheNum:    cursor.TheNum,
+		TheStr:    cursor.TheStr,
+		CreatedAt: cursor.CreatedAt,
+		UpdatedAt: cursor.UpdatedAt,
+		Page:      page,
+		Total:     total,
+		Query:     query,
+	})
+}
+
+func decodePageToken(secret []byte, token string, query string) (*dao.Cursor, int, int64, error) {
+	pt := pageToken{}
+	if err := openPageToken(secret, token, &pt); err != nil {
+		return nil, 0, 0, err
+	}
+
+	if pt.Query != query {
+		return nil, 0, 0, errInvalidPageToken
+	}
+
+	return &dao.Cursor{
+		ID:        pt.ID,
+		TheNum:    pt.TheNum,
+		TheStr:    pt.TheStr,
+		CreatedAt: pt.CreatedAt,
+		UpdatedAt: pt.UpdatedAt,
+	}, pt.Page, pt.Total, nil
+}
+
+// encodeRevisionPageToken signs the last revision id of the page.
+func encodeRevisionPageToken(secret []byte, recordID string, before int64) (string, error) {
+	return sealPageToken(secret, revisionPageToken{Before: before, RecordID: recordID})
+}
+
+// decodeRevisionPageToken returns the revision id the next page is before.
+func decodeRevisionPageToken(secret []byte, token string, recordID string) (int64, error) {
+	pt := revisionPageToken{}
+	if err := openPageToken(secret, token, &pt); err != nil {
+		return 0, err
+	}
+
+	if pt.RecordID != recordID || pt.Before <= 0 {
+		return 0, errInvalidPageToken
+	}
+
+	return pt.Before, nil
+}
+
+// sealPageToken marshals and signs the payload, the token looks like base64(payload).base64(signature).
+func sealPageToken(secret []byte, v interface{}) (string, error) {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString(payload) + "." + enc.EncodeToString(signPageToken(secret, payload)), nil
+}
+
+// openPageToken verifies the token made by sealPageToken and unmarshals the payload into v.
+func openPageToken(secret []byte, token string, v interface{}) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return errInvalidPageToken
+	}
+
+	enc := base64.RawURLEncoding
+
+	payload, err := enc.DecodeString(parts[0])
+	if err != nil {
+		return errInvalidPageToken
+	}
+
+	sig, err := enc.DecodeString(parts[1])
+	if err != nil {
+		return errInvalidPageToken
+	}
+
+	if !hmac.Equal(sig, signPageToken(secret, payload)) {
+		return errInvalidPageToken
+	}
+
+	if err := json.Unmarshal(payload, v); err != nil {
+		return errInvalidPageToken
+	}
+
+	return nil
+}
+
+func signPageToken(secret, payload []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
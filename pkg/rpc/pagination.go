@@ -0,0 +1,85 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/AmazingTalker/go-amazing/pkg/pb"
+)
+
+// the pagination headers of ListRecord are kept for the clients which don't read the
+// pagination from the body.
+const (
+	headerNextPageToken = "X-Next-Page-Token"
+	headerTotalCount    = "X-Total-Count"
+	headerLink          = "Link"
+)
+
+// setPaginationHeaders sets the pagination headers of the http response of ListRecord.
+func setPaginationHeaders(ctx context.Context, resp *pb.ListRecordRes) {
+	u := requestURL(ctx)
+	if u == nil {
+		return
+	}
+
+	if resp.NextPageToken != "" {
+		setHeader(ctx, headerNextPageToken, resp.NextPageToken)
+	}
+	setHeader(ctx, headerTotalCount, strconv.FormatInt(resp.TotalCount, 10))
+	if link := listRecordLink(u, resp); link != "" {
+		setHeader(ctx, headerLink, link)
+	}
+}
+
+// listRecordLink builds the Link header (RFC 8288) of ListRecord from the request url.
+// Paging by offset links to the first, prev, next and last pages, paging by token only
+// links to the first and next pages.
+func listRecordLink(u *url.URL, resp *pb.ListRecordRes) string {
+	query := u.Query()
+	links := []string{}
+
+	link := func(rel string, set func(url.Values)) {
+		q := url.Values{}
+		for k, v := range query {
+			q[k] = v
+		}
+		set(q)
+
+		ref := url.URL{Path: u.Path, RawQuery: q.Encode()}
+		links = append(links, fmt.Sprintf(`<%s>; rel="%s"`, ref.String(), rel))
+	}
+
+	setPage := func(page int64) func(url.Values) {
+		return func(q url.Values) {
+			q.Set("page", strconv.FormatInt(page, 10))
+		}
+	}
+
+	if query.Get("page") == "" {
+		link("first", func(q url.Values) { q.Del("page_token") })
+		if resp.NextPageToken != "" {
+			link("next", func(q url.Values) { q.Set("page_token", resp.NextPageToken) })
+		}
+		return strings.Join(links, ", ")
+	}
+
+	page := int64(resp.Page)
+	last := int64(0)
+	if resp.PageSize > 0 && resp.TotalCount > 0 {
+		last = (resp.TotalCount - 1) / int64(resp.PageSize)
+	}
+
+	link("first", setPage(0))
+	if page > 0 {
+		link("prev", setPage(page-1))
+	}
+	if resp.HasMore {
+		link("next", setPage(page+1))
+	}
+	link("last", setPage(last))
+
+	return strings.Join(links, ", ")
+}
@@ -0,0 +1,55 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	errCodes "github.com/AmazingTalker/at-error-code"
+	"github.com/AmazingTalker/go-amazing/pkg/auth"
+	"github.com/AmazingTalker/go-amazing/pkg/ratelimit"
+	"github.com/AmazingTalker/go-amazing/pkg/rpc/config"
+	"github.com/AmazingTalker/go-rpc-kit/errorkit"
+	"github.com/AmazingTalker/go-rpc-kit/logkit"
+)
+
+func newResourceExhaustedError(err error) error {
+	return errorkit.NewFromError(errCodes.ErrTooManyRequests, err, errorkit.WithHttpStatusCode(http.StatusTooManyRequests))
+}
+
+// rateLimitCaller returns the authenticated subject, or the ip of the caller.
+func rateLimitCaller(ctx context.Context) string {
+	if id, ok := auth.IdentityFromContext(ctx); ok {
+		return fmt.Sprintf("%s:%s", id.Method, id.Subject)
+	}
+
+	return fmt.Sprintf("ip:%s", ratelimit.ClientIP(ctx))
+}
+
+// limit takes a token of the caller for the method by the limits of the dynamic config.
+// The calls are not limited when the limiter fails.
+func (serv GoAmazingServer) limit(ctx context.Context, method string) error {
+	l, ok := serv.rateLimitConfig().Limit(method)
+	if !ok || serv.rateLimiter == nil {
+		return nil
+	}
+
+	key := fmt.Sprintf("%s:%s", method, rateLimitCaller(ctx))
+	res, err := serv.rateLimiter.Allow(ctx, key, ratelimit.Limit{Rate: l.Rate, Burst: l.Burst})
+	if err != nil {
+		logkit.ErrorV2(ctx, "rateLimiter.Allow failed", err, logkit.Payload{"key": key})
+		return nil
+	}
+
+	if !res.Allowed {
+		logkit.Info(ctx, "rate limited", logkit.Payload{"key": key, "retryAfter": res.RetryAfter.String()})
+		ratelimit.SetRetryAfter(ctx, res.RetryAfter)
+		return newResourceExhaustedError(fmt.Errorf("too many calls to %s, retry after %s", method, res.RetryAfter))
+	}
+
+	return nil
+}
+
+func defaultRateLimitConfig() config.RateLimitConfig {
+	return config.Config().RateLimit
+}
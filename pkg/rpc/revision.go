@@ -0,0 +1,20 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/AmazingTalker/go-amazing/pkg/auth"
+	"github.com/AmazingTalker/go-amazing/pkg/dao"
+	"github.com/AmazingTalker/go-amazing/pkg/interceptor"
+)
+
+// withAudit tells the dao who makes the change, it's written into the revision of the record.
+func withAudit(ctx context.Context) context.Context {
+	a := dao.Audit{RequestID: interceptor.RequestID(ctx)}
+
+	if id, ok := auth.IdentityFromContext(ctx); ok {
+		a.Actor = id.Subject
+	}
+
+	return dao.WithAudit(ctx, a)
+}
@@ -2,48 +2,95 @@ package rpc
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"strconv"
 	"unsafe"
 
+	"github.com/google/uuid"
+
 	"github.com/AmazingTalker/go-amazing/pkg/dao"
+	"github.com/AmazingTalker/go-amazing/pkg/health"
 	"github.com/AmazingTalker/go-amazing/pkg/pb"
+	"github.com/AmazingTalker/go-amazing/pkg/ratelimit"
 	"github.com/AmazingTalker/go-amazing/pkg/rpc/config"
 	"github.com/AmazingTalker/go-rpc-kit/logkit"
 	"github.com/AmazingTalker/go-rpc-kit/metrickit"
 	"github.com/AmazingTalker/go-rpc-kit/validatorkit"
 )
 
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+
+	maxBatchGetSize = 100
+)
+
 var (
 	rpcMet = metrickit.NewWithPkgName(
 		metrickit.EnableAutoFillInFuncName(true),
 	)
+
+	errDraining = errors.New("the server is shutting down")
 )
 
 type GoAmazingServerOpt struct {
 	Validator validatorkit.Validator
 	RecordDao dao.RecordDAO
+	// PageTokenSecret signs the page tokens of ListRecord.
+	PageTokenSecret string
+	// Health tells whether the server is shutting down, it never does when it's nil.
+	Health *health.Checker
+	// RateLimiter limits the calls by the limits of the dynamic config, no limit when it's nil.
+	RateLimiter ratelimit.Limiter
 }
 
 // GoAmazingServer 1. Implement a struct as you like.
 // Generate everything with an interface named "GoAmazingRPC"
 type GoAmazingServer struct {
-	validator validatorkit.Validator
-	recordDao dao.RecordDAO
+	validator       validatorkit.Validator
+	recordDao       dao.RecordDAO
+	pageTokenSecret []byte
+	health          *health.Checker
+	// authzConfig returns the policy of the dynamic config.
+	authzConfig func() config.AuthzConfig
+
+	rateLimiter ratelimit.Limiter
+	// rateLimitConfig returns the limits of the dynamic config.
+	rateLimitConfig func() config.RateLimitConfig
 }
 
 func NewGoAmazingServer(opt GoAmazingServerOpt) GoAmazingServer {
 	return GoAmazingServer{
-		validator: opt.Validator,
-		recordDao: opt.RecordDao,
+		validator:       opt.Validator,
+		recordDao:       opt.RecordDao,
+		pageTokenSecret: []byte(opt.PageTokenSecret),
+		health:          opt.Health,
+		authzConfig:     defaultAuthzConfig,
+		rateLimiter:     opt.RateLimiter,
+		rateLimitConfig: defaultRateLimitConfig,
 	}
 }
 
 // Health 2. Complete these methods.
+// It doesn't check the dependencies for the probes configured before /health/ready, see health.Checker.
 func (serv GoAmazingServer) Health(_ context.Context, _ *pb.HealthReq) (*pb.HealthRes, error) {
+	if serv.health != nil && serv.health.Draining() {
+		return nil, newUnavailableError(errDraining)
+	}
+
 	return &pb.HealthRes{Ok: true}, nil
 }
 
 func (serv GoAmazingServer) Config(ctx context.Context, _ *pb.ConfigReq) (*pb.ConfigRes, error) {
+	if err := serv.authorize(ctx, "Config"); err != nil {
+		return nil, err
+	}
+
+	if err := serv.limit(ctx, "Config"); err != nil {
+		return nil, err
+	}
+
 	cfg := config.Config()
 
 	return &pb.ConfigRes{
@@ -56,19 +103,42 @@ func (serv GoAmazingServer) Config(ctx context.Context, _ *pb.ConfigReq) (*pb.Co
 func (serv GoAmazingServer) CreateRecord(ctx context.Context, req *pb.CreateRecordReq) (*pb.CreateRecordRes, error) {
 	defer rpcMet.RecordDuration([]string{"time"}, map[string]string{}).End()
 
+	if err := serv.authorize(ctx, "CreateRecord"); err != nil {
+		return nil, err
+	}
+
+	if err := serv.limit(ctx, "CreateRecord"); err != nil {
+		return nil, err
+	}
+
+	if err := serv.valid(ctx, req); err != nil {
+		return nil, err
+	}
+
+	key, err := idempotencyKey(ctx, req)
+	if err != nil {
+		logkit.ErrorV2(ctx, "idempotencyKey failed", err, nil)
+		return nil, newInvalidArgumentError(err)
+	}
+
 	r := &dao.Record{
 		TheNum: req.TheNum,
 		TheStr: req.TheStr,
 	}
+	if key != "" {
+		r.IdempotencyKey = &key
+	}
 
-	if err := serv.recordDao.CreateRecord(ctx, r); err != nil {
+	if err := serv.recordDao.CreateRecord(withAudit(ctx), r); err != nil {
 		logkit.ErrorV2(ctx, "dao.CreateRecord failed", err, nil)
-		return nil, err
+		return nil, formatError(err)
 	}
 
 	resp := pb.CreateRecordRes{Record: r.FormatPb()}
 	rpcMet.SetGauge([]string{"resp_size"}, float64(unsafe.Sizeof(resp)), map[string]string{})
 
+	setRecordETag(ctx, resp.Record)
+
 	return &resp, nil
 }
 
@@ -77,44 +147,202 @@ func (serv GoAmazingServer) GetRecord(ctx context.Context, req *pb.GetRecordReq)
 
 	ctx = logkit.EnrichPayload(ctx, logkit.Payload{"id": req.ID})
 
+	if err := serv.authorize(ctx, "GetRecord"); err != nil {
+		return nil, err
+	}
+
+	if err := serv.limit(ctx, "GetRecord"); err != nil {
+		return nil, err
+	}
+
+	if err := serv.valid(ctx, req); err != nil {
+		return nil, err
+	}
+
 	r, err := serv.recordDao.GetRecord(ctx, req.ID)
 	if err != nil {
 		logkit.ErrorV2(ctx, "dao.GetRecord failed", err, nil)
-		return nil, err
+		return nil, formatError(err)
 	}
 
 	resp := pb.GetRecordRes{Record: r.FormatPb()}
 	rpcMet.SetGauge([]string{"resp_size"}, float64(unsafe.Sizeof(resp)), map[string]string{})
 
-	return &resp, err
+	setRecordETag(ctx, resp.Record)
+
+	return &resp, nil
+}
+
+func (serv GoAmazingServer) BatchGetRecords(ctx context.Context, req *pb.BatchGetRecordsReq) (*pb.BatchGetRecordsRes, error) {
+	defer rpcMet.RecordDuration([]string{"time"}, map[string]string{}).End()
+
+	if err := serv.authorize(ctx, "BatchGetRecords"); err != nil {
+		return nil, err
+	}
+
+	if err := serv.limit(ctx, "BatchGetRecords"); err != nil {
+		return nil, err
+	}
+
+	if len(req.IDs) == 0 {
+		return nil, newInvalidArgumentError(errors.New("ids are required"))
+	}
+	if len(req.IDs) > maxBatchGetSize {
+		return nil, newInvalidArgumentError(fmt.Errorf("at most %d ids in a batch", maxBatchGetSize))
+	}
+
+	// the ids are fetched once in the canonical form, the invalid ones are not found for sure.
+	canonical := make([]string, len(req.IDs))
+	ids := []string{}
+	seen := map[string]bool{}
+	for i, id := range req.IDs {
+		u, err := uuid.Parse(id)
+		if err != nil {
+			continue
+		}
+
+		canonical[i] = u.String()
+		if !seen[canonical[i]] {
+			seen[canonical[i]] = true
+			ids = append(ids, canonical[i])
+		}
+	}
+
+	found := map[string]*dao.Record{}
+	if len(ids) > 0 {
+		records, err := serv.recordDao.BatchGetRecords(ctx, ids)
+		if err != nil {
+			logkit.ErrorV2(ctx, "dao.BatchGetRecords failed", err, logkit.Payload{"ids": ids})
+			return nil, formatError(err)
+		}
+
+		for i, r := range records {
+			if r != nil {
+				found[ids[i]] = r
+			}
+		}
+	}
+
+	results := make([]*pb.BatchGetRecordsResult, len(req.IDs))
+	for i, id := range req.IDs {
+		r, ok := found[canonical[i]]
+		if !ok {
+			results[i] = &pb.BatchGetRecordsResult{ID: id, NotFound: true}
+			continue
+		}
+
+		results[i] = &pb.BatchGetRecordsResult{ID: id, Record: r.FormatPb()}
+	}
+
+	resp := pb.BatchGetRecordsRes{Results: results}
+	rpcMet.SetGauge([]string{"resp_size"}, float64(unsafe.Sizeof(resp)), map[string]string{})
+
+	return &resp, nil
 }
 
 func (serv GoAmazingServer) ListRecord(ctx context.Context, req *pb.ListRecordReq) (*pb.ListRecordRes, error) {
 	defer rpcMet.RecordDuration([]string{"time"}, map[string]string{}).End()
 
-	if err := serv.validator.Valid(ctx, req); err != nil {
+	if err := serv.authorize(ctx, "ListRecord"); err != nil {
 		return nil, err
 	}
 
-	size, err := strconv.ParseInt(req.PageSize, 10, 32)
-	if err != nil {
-		logkit.ErrorV2(ctx, "strconv.ParseInt failed", err, logkit.Payload{"size": req.PageSize})
+	if err := serv.limit(ctx, "ListRecord"); err != nil {
 		return nil, err
 	}
-	page, err := strconv.ParseInt(req.Page, 10, 32)
+
+	if err := serv.valid(ctx, req); err != nil {
+		return nil, err
+	}
+
+	size, err := parsePageSize(req.PageSize)
 	if err != nil {
-		logkit.ErrorV2(ctx, "strconv.ParseInt failed", err, logkit.Payload{"page": req.Page})
+		logkit.ErrorV2(ctx, "parsePageSize failed", err, logkit.Payload{"size": req.PageSize})
+		return nil, newInvalidArgumentError(err)
+	}
+
+	filter, err := parseRecordFilter(req)
+	if err != nil {
+		logkit.ErrorV2(ctx, "parseRecordFilter failed", err, nil)
+		return nil, newInvalidArgumentError(err)
+	}
+
+	orderBy, err := parseRecordOrder(req.OrderBy)
+	if err != nil {
+		logkit.ErrorV2(ctx, "parseRecordOrder failed", err, logkit.Payload{"orderBy": req.OrderBy})
+		return nil, newInvalidArgumentError(err)
+	}
+
+	query, err := listQueryFingerprint(filter, orderBy)
+	if err != nil {
+		logkit.ErrorV2(ctx, "listQueryFingerprint failed", err, nil)
 		return nil, err
 	}
 
-	// Just demo
-	records, err := serv.recordDao.ListRecords(ctx, dao.ListRecordsOpt{
-		Size: int(size),
-		Page: int(page),
-	})
+	opt := dao.ListRecordsOpt{Size: size, Filter: filter, OrderBy: orderBy}
+	// page is counted by the page tokens when paging by token
+	page := 0
+	// total is counted on the first page when paging by token, the token carries it to the later pages
+	total := int64(0)
+
+	// old clients page by offset
+	if req.Page != "" {
+		if req.PageToken != "" {
+			return nil, newInvalidArgumentError(errors.New("page and page_token can't be used together"))
+		}
+
+		p, err := strconv.ParseInt(req.Page, 10, 32)
+		if err != nil {
+			logkit.ErrorV2(ctx, "strconv.ParseInt failed", err, logkit.Payload{"page": req.Page})
+			return nil, newInvalidArgumentError(err)
+		}
+		if p < 0 {
+			return nil, newInvalidArgumentError(errors.New("page can't be negative"))
+		}
+		page = int(p)
+		opt.Page = page
+	} else {
+		if req.PageToken != "" {
+			after, p, t, err := decodePageToken(serv.pageTokenSecret, req.PageToken, query)
+			if err != nil {
+				logkit.ErrorV2(ctx, "decodePageToken failed", err, logkit.Payload{"pageToken": req.PageToken})
+				return nil, newInvalidArgumentError(err)
+			}
+			opt.After = after
+			page, total = p, t
+		}
+
+		// one more record tells whether there is a next page
+		opt.Size = size + 1
+	}
+
+	records, err := serv.recordDao.ListRecords(ctx, opt)
 	if err != nil {
 		logkit.ErrorV2(ctx, "dao.ListRecords failed", err, logkit.Payload{"page": req.Page, "size": req.PageSize})
-		return nil, err
+		return nil, formatError(err)
+	}
+
+	if req.Page != "" || req.PageToken == "" {
+		if total, err = serv.recordDao.CountRecords(ctx, filter); err != nil {
+			logkit.ErrorV2(ctx, "dao.CountRecords failed", err, nil)
+			return nil, formatError(err)
+		}
+	}
+
+	// paging by token fetches one more record, paging by offset has to count.
+	hasMore := len(records) > size
+	if req.Page != "" {
+		hasMore = int64(page+1)*int64(size) < total
+	}
+
+	nextPageToken := ""
+	if req.Page == "" && hasMore {
+		records = records[:size]
+
+		if nextPageToken, err = encodePageToken(serv.pageTokenSecret, records[size-1].Cursor(), page+1, total, query); err != nil {
+			logkit.ErrorV2(ctx, "encodePageToken failed", err, nil)
+			return nil, err
+		}
 	}
 
 	result := make([]*pb.Record, len(records))
@@ -123,7 +351,194 @@ func (serv GoAmazingServer) ListRecord(ctx context.Context, req *pb.ListRecordRe
 		result[i] = r.FormatPb()
 	}
 
-	resp := pb.ListRecordRes{Records: result}
+	resp := pb.ListRecordRes{
+		Records:       result,
+		NextPageToken: nextPageToken,
+		TotalCount:    total,
+		Page:          int32(page),
+		PageSize:      int32(size),
+		HasMore:       hasMore,
+	}
+	rpcMet.SetGauge([]string{"resp_size"}, float64(unsafe.Sizeof(resp)), map[string]string{})
+
+	setPaginationHeaders(ctx, &resp)
+
+	return &resp, nil
+}
+
+func (serv GoAmazingServer) UpdateRecord(ctx context.Context, req *pb.UpdateRecordReq) (*pb.UpdateRecordRes, error) {
+	defer rpcMet.RecordDuration([]string{"time"}, map[string]string{}).End()
+
+	ctx = logkit.EnrichPayload(ctx, logkit.Payload{"id": req.ID})
+
+	if err := serv.authorize(ctx, "UpdateRecord"); err != nil {
+		return nil, err
+	}
+
+	if err := serv.limit(ctx, "UpdateRecord"); err != nil {
+		return nil, err
+	}
+
+	if err := serv.valid(ctx, req); err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.Parse(req.ID)
+	if err != nil {
+		logkit.ErrorV2(ctx, "uuid.Parse failed", err, nil)
+		return nil, newInvalidArgumentError(err)
+	}
+
+	// the If-Match header works like the version field
+	version, err := serv.expectedVersion(ctx, req.ID, req.Version)
+	if err != nil {
+		logkit.ErrorV2(ctx, "expectedVersion failed", err, logkit.Payload{"version": req.Version})
+		return nil, err
+	}
+	if version == 0 {
+		return nil, newInvalidArgumentError(errors.New("the version is required, or the If-Match header on HTTP"))
+	}
+
+	r := &dao.Record{
+		ID:      id,
+		TheNum:  req.TheNum,
+		TheStr:  req.TheStr,
+		Version: version,
+	}
+
+	if err := serv.recordDao.UpdateRecord(withAudit(ctx), r); err != nil {
+		logkit.ErrorV2(ctx, "dao.UpdateRecord failed", err, nil)
+		return nil, formatError(err)
+	}
+
+	resp := pb.UpdateRecordRes{Record: r.FormatPb()}
+	rpcMet.SetGauge([]string{"resp_size"}, float64(unsafe.Sizeof(resp)), map[string]string{})
+
+	setRecordETag(ctx, resp.Record)
+
+	return &resp, nil
+}
+
+func (serv GoAmazingServer) DeleteRecord(ctx context.Context, req *pb.DeleteRecordReq) (*pb.DeleteRecordRes, error) {
+	defer rpcMet.RecordDuration([]string{"time"}, map[string]string{}).End()
+
+	ctx = logkit.EnrichPayload(ctx, logkit.Payload{"id": req.ID})
+
+	if err := serv.authorize(ctx, "DeleteRecord"); err != nil {
+		return nil, err
+	}
+
+	if err := serv.limit(ctx, "DeleteRecord"); err != nil {
+		return nil, err
+	}
+
+	if err := serv.valid(ctx, req); err != nil {
+		return nil, err
+	}
+
+	if err := serv.recordDao.DeleteRecord(withAudit(ctx), req.ID); err != nil {
+		logkit.ErrorV2(ctx, "dao.DeleteRecord failed", err, nil)
+		return nil, formatError(err)
+	}
+
+	resp := pb.DeleteRecordRes{ID: req.ID}
+	rpcMet.SetGauge([]string{"resp_size"}, float64(unsafe.Sizeof(resp)), map[string]string{})
+
+	return &resp, nil
+}
+
+func (serv GoAmazingServer) RestoreRecord(ctx context.Context, req *pb.RestoreRecordReq) (*pb.RestoreRecordRes, error) {
+	defer rpcMet.RecordDuration([]string{"time"}, map[string]string{}).End()
+
+	ctx = logkit.EnrichPayload(ctx, logkit.Payload{"id": req.ID})
+
+	if err := serv.authorize(ctx, "RestoreRecord"); err != nil {
+		return nil, err
+	}
+
+	if err := serv.limit(ctx, "RestoreRecord"); err != nil {
+		return nil, err
+	}
+
+	if err := serv.valid(ctx, req); err != nil {
+		return nil, err
+	}
+
+	r, err := serv.recordDao.RestoreRecord(withAudit(ctx), req.ID)
+	if err != nil {
+		logkit.ErrorV2(ctx, "dao.RestoreRecord failed", err, nil)
+		return nil, formatError(err)
+	}
+
+	resp := pb.RestoreRecordRes{Record: r.FormatPb()}
+	rpcMet.SetGauge([]string{"resp_size"}, float64(unsafe.Sizeof(resp)), map[string]string{})
+
+	setRecordETag(ctx, resp.Record)
+
+	return &resp, nil
+}
+
+func (serv GoAmazingServer) ListRecordRevisions(ctx context.Context, req *pb.ListRecordRevisionsReq) (*pb.ListRecordRevisionsRes, error) {
+	defer rpcMet.RecordDuration([]string{"time"}, map[string]string{}).End()
+
+	ctx = logkit.EnrichPayload(ctx, logkit.Payload{"id": req.ID})
+
+	if err := serv.authorize(ctx, "ListRecordRevisions"); err != nil {
+		return nil, err
+	}
+
+	if err := serv.limit(ctx, "ListRecordRevisions"); err != nil {
+		return nil, err
+	}
+
+	if err := serv.valid(ctx, req); err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.Parse(req.ID)
+	if err != nil {
+		logkit.ErrorV2(ctx, "uuid.Parse failed", err, nil)
+		return nil, newInvalidArgumentError(err)
+	}
+
+	size, err := parsePageSize(req.PageSize)
+	if err != nil {
+		logkit.ErrorV2(ctx, "parsePageSize failed", err, logkit.Payload{"size": req.PageSize})
+		return nil, newInvalidArgumentError(err)
+	}
+
+	// one more revision tells whether there is a next page
+	opt := dao.ListRevisionsOpt{Size: size + 1}
+	if req.PageToken != "" {
+		if opt.Before, err = decodeRevisionPageToken(serv.pageTokenSecret, req.PageToken, id.String()); err != nil {
+			logkit.ErrorV2(ctx, "decodeRevisionPageToken failed", err, logkit.Payload{"pageToken": req.PageToken})
+			return nil, newInvalidArgumentError(err)
+		}
+	}
+
+	revisions, err := serv.recordDao.ListRecordRevisions(ctx, id.String(), opt)
+	if err != nil {
+		logkit.ErrorV2(ctx, "dao.ListRecordRevisions failed", err, nil)
+		return nil, formatError(err)
+	}
+
+	nextPageToken := ""
+	if len(revisions) > size {
+		revisions = revisions[:size]
+
+		if nextPageToken, err = encodeRevisionPageToken(serv.pageTokenSecret, id.String(), revisions[size-1].ID); err != nil {
+			logkit.ErrorV2(ctx, "encodeRevisionPageToken failed", err, nil)
+			return nil, err
+		}
+	}
+
+	result := make([]*pb.RecordRevision, len(revisions))
+	for i, r := range revisions {
+		r := r
+		result[i] = r.FormatPb()
+	}
+
+	resp := pb.ListRecordRevisionsRes{Revisions: result, NextPageToken: nextPageToken}
 	rpcMet.SetGauge([]string{"resp_size"}, float64(unsafe.Sizeof(resp)), map[string]string{})
 
 	return &resp, nil
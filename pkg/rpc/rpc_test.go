@@ -2,18 +2,36 @@ package rpc
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	grpcCodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	codes "github.com/AmazingTalker/at-error-code"
 	mockDAO "github.com/AmazingTalker/go-amazing/internal/pkg/dao"
+	"github.com/AmazingTalker/go-amazing/pkg/auth"
 	"github.com/AmazingTalker/go-amazing/pkg/dao"
+	"github.com/AmazingTalker/go-amazing/pkg/health"
+	"github.com/AmazingTalker/go-amazing/pkg/interceptor"
 	"github.com/AmazingTalker/go-amazing/pkg/pb"
+	"github.com/AmazingTalker/go-amazing/pkg/ratelimit"
+	"github.com/AmazingTalker/go-amazing/pkg/rpc/config"
 	"github.com/AmazingTalker/go-rpc-kit/errorkit"
 	"github.com/AmazingTalker/go-rpc-kit/logkit"
 	"github.com/AmazingTalker/go-rpc-kit/validatorkit"
@@ -22,12 +40,47 @@ import (
 var (
 	mockCTX    = context.Background()
 	mockUUID   = uuid.New()
+	mockSecret = "secret"
 	mockRecord = &dao.Record{
 		TheNum: 3838,
 		TheStr: "AT",
 	}
+	mockTimeNow = time.Unix(1629446406, 0).UTC()
+	mockRecords = []dao.Record{
+		{ID: uuid.New(), TheNum: 1, TheStr: "AT1", CreatedAt: &mockTimeNow, UpdatedAt: &mockTimeNow},
+		{ID: uuid.New(), TheNum: 2, TheStr: "AT2", CreatedAt: &mockTimeNow, UpdatedAt: &mockTimeNow},
+		{ID: uuid.New(), TheNum: 3, TheStr: "AT3", CreatedAt: &mockTimeNow, UpdatedAt: &mockTimeNow},
+	}
+	mockCursor       = mockRecords[1].Cursor()
+	mockQuery, _     = listQueryFingerprint(dao.RecordFilter{}, nil)
+	mockPageToken, _ = encodePageToken([]byte(mockSecret), mockCursor, 1, 3, mockQuery)
 )
 
+// wrappingValidator wraps the errors of the validator into the AT errors.
+type wrappingValidator struct {
+	validatorkit.Validator
+}
+
+func (w wrappingValidator) Valid(ctx context.Context, req interface{}) error {
+	if err := w.Validator.Valid(ctx, req); err != nil {
+		return errorkit.NewFromError(codes.ErrInvalidArgument, err, errorkit.WithHttpStatusCode(http.StatusBadRequest))
+	}
+	return nil
+}
+
+// fakeLimiter returns res and err, and keeps the last key and limit.
+type fakeLimiter struct {
+	res   ratelimit.Result
+	err   error
+	key   string
+	limit ratelimit.Limit
+}
+
+func (l *fakeLimiter) Allow(_ context.Context, key string, limit ratelimit.Limit) (ratelimit.Result, error) {
+	l.key, l.limit = key, limit
+	return l.res, l.err
+}
+
 type ExpAtError struct {
 	ExpStatus int64
 	ExpCode   codes.ATErrorCode
@@ -39,7 +92,8 @@ type rpcSuite struct {
 	// mocks
 	mockRecord *mockDAO.RecordDAO
 
-	serv GoAmazingServer
+	health *health.Checker
+	serv   GoAmazingServer
 }
 
 func (s *rpcSuite) SetupSuite() {
@@ -57,10 +111,13 @@ func (s *rpcSuite) TearDownSuite() {
 func (s *rpcSuite) SetupTest() {
 	// setup mock
 	s.mockRecord = mockDAO.NewRecordDAO(s.T())
+	s.health = health.NewChecker(health.CheckerOpt{})
 
 	s.serv = NewGoAmazingServer(GoAmazingServerOpt{
-		Validator: validatorkit.NewGoPlaygroundValidator(),
-		RecordDao: s.mockRecord,
+		Validator:       validatorkit.NewGoPlaygroundValidator(),
+		RecordDao:       s.mockRecord,
+		PageTokenSecret: mockSecret,
+		Health:          s.health,
 	})
 }
 
@@ -72,22 +129,68 @@ func TestRPCSuite(t *testing.T) {
 	suite.Run(t, new(rpcSuite))
 }
 
+// requireError checks err against expAtErr when it's given, or expErr otherwise.
+func (s *rpcSuite) requireError(expErr error, expAtErr *ExpAtError, err error, desc string) {
+	if expAtErr == nil {
+		s.Require().Equal(expErr, err, desc)
+		return
+	}
+
+	s.Require().Error(err, desc)
+	atErr := errorkit.FormatError(err)
+	s.Require().Equal(expAtErr.ExpCode, atErr.ATErrorCode(), desc)
+	s.Require().Equal(int(expAtErr.ExpStatus), atErr.HttpStatus(), desc)
+}
+
+// serveHTTP serves the request by the http adapter of the server.
+func (s *rpcSuite) serveHTTP(req *http.Request) *httptest.ResponseRecorder {
+	e := gin.New()
+	e.Use(GinMiddleware())
+	pb.RegisterGoAmazingHttpService(e, s.serv)
+
+	w := httptest.NewRecorder()
+	e.ServeHTTP(w, req)
+	return w
+}
+
 func (s *rpcSuite) TestHealth() {
 	tests := []struct {
-		Desc     string
-		Req      *pb.HealthReq
-		ExpError *ExpAtError
-		ExpRes   *pb.HealthRes
+		Desc      string
+		SetupTest func(string)
+		Req       *pb.HealthReq
+		ExpError  *ExpAtError
+		ExpRes    *pb.HealthRes
 	}{
 		{
 			Desc:     "normal case",
 			ExpError: nil,
 			ExpRes:   &pb.HealthRes{Ok: true},
 		},
+		{
+			Desc: "draining",
+			SetupTest: func(desc string) {
+				s.health.Drain()
+			},
+			ExpError: &ExpAtError{ExpStatus: http.StatusServiceUnavailable, ExpCode: codes.ErrServiceUnavailable},
+			ExpRes:   nil,
+		},
+		{
+			Desc: "no health checker",
+			SetupTest: func(desc string) {
+				s.serv = NewGoAmazingServer(GoAmazingServerOpt{RecordDao: s.mockRecord})
+			},
+			ExpError: nil,
+			ExpRes:   &pb.HealthRes{Ok: true},
+		},
 	}
 
 	for _, t := range tests {
+		if t.SetupTest != nil {
+			t.SetupTest(t.Desc)
+		}
+
 		resp, err := s.serv.Health(mockCTX, t.Req)
+		s.Require().Equal(t.ExpError == nil, err == nil, t.Desc)
 
 		if err == nil {
 			s.Require().Equal(nil, err, t.Desc)
@@ -105,12 +208,29 @@ func (s *rpcSuite) TestHealth() {
 
 func (s *rpcSuite) TestCreateRecord() {
 	tests := []struct {
-		Desc      string
-		SetupTest func(string)
-		Req       *pb.CreateRecordReq
-		ExpError  error
-		ExpResp   *pb.CreateRecordRes
+		Desc       string
+		SetupTest  func(string)
+		Ctx        context.Context
+		Req        *pb.CreateRecordReq
+		ExpError   error
+		ExpAtError *ExpAtError
+		ExpResp    *pb.CreateRecordRes
 	}{
+		{
+			Desc:       "empty the_str",
+			Req:        &pb.CreateRecordReq{TheNum: mockRecord.TheNum},
+			ExpAtError: &ExpAtError{ExpStatus: http.StatusBadRequest, ExpCode: codes.ErrInvalidArgument},
+		},
+		{
+			Desc:       "the_str too long",
+			Req:        &pb.CreateRecordReq{TheNum: mockRecord.TheNum, TheStr: strings.Repeat("s", 256)},
+			ExpAtError: &ExpAtError{ExpStatus: http.StatusBadRequest, ExpCode: codes.ErrInvalidArgument},
+		},
+		{
+			Desc:       "the_num out of range",
+			Req:        &pb.CreateRecordReq{TheNum: 1 << 31, TheStr: mockRecord.TheStr},
+			ExpAtError: &ExpAtError{ExpStatus: http.StatusBadRequest, ExpCode: codes.ErrInvalidArgument},
+		},
 		{
 			Desc: "create failed",
 			SetupTest: func(desc string) {
@@ -126,6 +246,87 @@ func (s *rpcSuite) TestCreateRecord() {
 			},
 			ExpError: errors.New("XD"),
 		},
+		{
+			Desc: "conflict",
+			SetupTest: func(desc string) {
+				s.mockRecord.On(
+					"CreateRecord", mock.Anything, mockRecord,
+				).Return(
+					&dao.Error{Kind: dao.ErrConflict, Err: errors.New("XD")},
+				).Once()
+			},
+			Req: &pb.CreateRecordReq{
+				TheNum: mockRecord.TheNum,
+				TheStr: mockRecord.TheStr,
+			},
+			ExpAtError: &ExpAtError{ExpStatus: http.StatusConflict, ExpCode: codes.ErrConflict},
+		},
+		{
+			Desc: "idempotency key",
+			SetupTest: func(desc string) {
+				key := "key"
+				s.mockRecord.On(
+					"CreateRecord", mock.Anything, &dao.Record{TheNum: mockRecord.TheNum, TheStr: mockRecord.TheStr, IdempotencyKey: &key},
+				).Return(
+					nil,
+				).Once()
+			},
+			Req: &pb.CreateRecordReq{
+				TheNum:         mockRecord.TheNum,
+				TheStr:         mockRecord.TheStr,
+				IdempotencyKey: "key",
+			},
+			ExpError: nil,
+			ExpResp: &pb.CreateRecordRes{
+				Record: mockRecord.FormatPb(),
+			},
+		},
+		{
+			Desc: "idempotency key from metadata",
+			SetupTest: func(desc string) {
+				key := "key from md"
+				s.mockRecord.On(
+					"CreateRecord", mock.Anything, &dao.Record{TheNum: mockRecord.TheNum, TheStr: mockRecord.TheStr, IdempotencyKey: &key},
+				).Return(
+					nil,
+				).Once()
+			},
+			Ctx: metadata.NewIncomingContext(mockCTX, metadata.Pairs(mdIdempotencyKey, "key from md")),
+			Req: &pb.CreateRecordReq{
+				TheNum: mockRecord.TheNum,
+				TheStr: mockRecord.TheStr,
+			},
+			ExpError: nil,
+			ExpResp: &pb.CreateRecordRes{
+				Record: mockRecord.FormatPb(),
+			},
+		},
+		{
+			Desc: "idempotency key too long",
+			Req: &pb.CreateRecordReq{
+				TheNum:         mockRecord.TheNum,
+				TheStr:         mockRecord.TheStr,
+				IdempotencyKey: strings.Repeat("k", maxIdempotencyKeyLen+1),
+			},
+			ExpAtError: &ExpAtError{ExpStatus: http.StatusBadRequest, ExpCode: codes.ErrInvalidArgument},
+		},
+		{
+			Desc: "idempotency key reused",
+			SetupTest: func(desc string) {
+				key := "key"
+				s.mockRecord.On(
+					"CreateRecord", mock.Anything, &dao.Record{TheNum: mockRecord.TheNum, TheStr: mockRecord.TheStr, IdempotencyKey: &key},
+				).Return(
+					&dao.Error{Kind: dao.ErrConflict, Err: errors.New("the idempotency key is used with another payload")},
+				).Once()
+			},
+			Req: &pb.CreateRecordReq{
+				TheNum:         mockRecord.TheNum,
+				TheStr:         mockRecord.TheStr,
+				IdempotencyKey: "key",
+			},
+			ExpAtError: &ExpAtError{ExpStatus: http.StatusConflict, ExpCode: codes.ErrConflict},
+		},
 		{
 			Desc: "normal case",
 			SetupTest: func(desc string) {
@@ -151,8 +352,13 @@ func (s *rpcSuite) TestCreateRecord() {
 			t.SetupTest(t.Desc)
 		}
 
-		resp, err := s.serv.CreateRecord(mockCTX, t.Req)
-		s.Require().Equal(t.ExpError, err, t.Desc)
+		ctx := mockCTX
+		if t.Ctx != nil {
+			ctx = t.Ctx
+		}
+
+		resp, err := s.serv.CreateRecord(ctx, t.Req)
+		s.requireError(t.ExpError, t.ExpAtError, err, t.Desc)
 
 		if err == nil {
 			s.Require().Equal(t.ExpResp, resp, t.Desc)
@@ -162,14 +368,61 @@ func (s *rpcSuite) TestCreateRecord() {
 	}
 }
 
+func (s *rpcSuite) TestIdempotencyKeyHeader() {
+	tests := []struct {
+		Desc   string
+		Body   string
+		Header string
+		ExpKey string
+	}{
+		{
+			Desc:   "the header",
+			Body:   `{"theNum":3838,"theStr":"AT"}`,
+			Header: "key from header",
+			ExpKey: "key from header",
+		},
+		{
+			Desc:   "the field goes first",
+			Body:   `{"theNum":3838,"theStr":"AT","idempotencyKey":"key"}`,
+			Header: "key from header",
+			ExpKey: "key",
+		},
+	}
+
+	for _, t := range tests {
+		s.SetupTest()
+
+		key := t.ExpKey
+		s.mockRecord.On(
+			"CreateRecord", mock.Anything, &dao.Record{TheNum: mockRecord.TheNum, TheStr: mockRecord.TheStr, IdempotencyKey: &key},
+		).Return(
+			nil,
+		).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/api/record", strings.NewReader(t.Body))
+		req.Header.Set("Idempotency-Key", t.Header)
+
+		w := s.serveHTTP(req)
+		s.Require().Equal(http.StatusCreated, w.Code, t.Desc)
+
+		s.TearDownTest()
+	}
+}
+
 func (s *rpcSuite) TestGetRecord() {
 	tests := []struct {
-		Desc      string
-		SetupTest func(string)
-		Req       *pb.GetRecordReq
-		ExpError  error
-		ExpResp   *pb.GetRecordRes
+		Desc       string
+		SetupTest  func(string)
+		Req        *pb.GetRecordReq
+		ExpError   error
+		ExpAtError *ExpAtError
+		ExpResp    *pb.GetRecordRes
 	}{
+		{
+			Desc:       "invalid id",
+			Req:        &pb.GetRecordReq{ID: "abc"},
+			ExpAtError: &ExpAtError{ExpStatus: http.StatusBadRequest, ExpCode: codes.ErrInvalidArgument},
+		},
 		{
 			Desc: "get failed",
 			SetupTest: func(desc string) {
@@ -182,6 +435,42 @@ func (s *rpcSuite) TestGetRecord() {
 			Req:      &pb.GetRecordReq{ID: mockUUID.String()},
 			ExpError: errors.New("XD"),
 		},
+		{
+			Desc: "not found",
+			SetupTest: func(desc string) {
+				s.mockRecord.On(
+					"GetRecord", mock.Anything, mockUUID.String(),
+				).Return(
+					nil, &dao.Error{Kind: dao.ErrNotFound, Err: errors.New("record not found")},
+				).Once()
+			},
+			Req:        &pb.GetRecordReq{ID: mockUUID.String()},
+			ExpAtError: &ExpAtError{ExpStatus: http.StatusNotFound, ExpCode: codes.ErrNotFound},
+		},
+		{
+			Desc: "unavailable",
+			SetupTest: func(desc string) {
+				s.mockRecord.On(
+					"GetRecord", mock.Anything, mockUUID.String(),
+				).Return(
+					nil, &dao.Error{Kind: dao.ErrUnavailable, Err: errors.New("invalid connection")},
+				).Once()
+			},
+			Req:        &pb.GetRecordReq{ID: mockUUID.String()},
+			ExpAtError: &ExpAtError{ExpStatus: http.StatusServiceUnavailable, ExpCode: codes.ErrServiceUnavailable},
+		},
+		{
+			Desc: "canceled",
+			SetupTest: func(desc string) {
+				s.mockRecord.On(
+					"GetRecord", mock.Anything, mockUUID.String(),
+				).Return(
+					nil, &dao.Error{Kind: dao.ErrCanceled, Err: context.Canceled},
+				).Once()
+			},
+			Req:        &pb.GetRecordReq{ID: mockUUID.String()},
+			ExpAtError: &ExpAtError{ExpStatus: statusClientClosedRequest, ExpCode: codes.ErrServiceUnavailable},
+		},
 		{
 			Desc: "normal case",
 			SetupTest: func(desc string) {
@@ -205,7 +494,7 @@ func (s *rpcSuite) TestGetRecord() {
 		}
 
 		resp, err := s.serv.GetRecord(mockCTX, t.Req)
-		s.Require().Equal(t.ExpError, err, t.Desc)
+		s.requireError(t.ExpError, t.ExpAtError, err, t.Desc)
 
 		if err == nil {
 			s.Require().Equal(t.ExpResp, resp, t.Desc)
@@ -215,13 +504,128 @@ func (s *rpcSuite) TestGetRecord() {
 	}
 }
 
-func (s *rpcSuite) TestListRecord() {
+func (s *rpcSuite) TestBatchGetRecords() {
+	tooMany := make([]string, maxBatchGetSize+1)
+	for i := range tooMany {
+		tooMany[i] = uuid.New().String()
+	}
+
+	tests := []struct {
+		Desc       string
+		SetupTest  func(string)
+		Req        *pb.BatchGetRecordsReq
+		ExpError   error
+		ExpAtError *ExpAtError
+		ExpResp    *pb.BatchGetRecordsRes
+	}{
+		{
+			Desc:       "no ids",
+			Req:        &pb.BatchGetRecordsReq{},
+			ExpAtError: &ExpAtError{ExpStatus: http.StatusBadRequest, ExpCode: codes.ErrInvalidArgument},
+		},
+		{
+			Desc:       "too many ids",
+			Req:        &pb.BatchGetRecordsReq{IDs: tooMany},
+			ExpAtError: &ExpAtError{ExpStatus: http.StatusBadRequest, ExpCode: codes.ErrInvalidArgument},
+		},
+		{
+			Desc: "batch get failed",
+			SetupTest: func(desc string) {
+				s.mockRecord.On(
+					"BatchGetRecords", mock.Anything, []string{mockUUID.String()},
+				).Return(
+					nil, &dao.Error{Kind: dao.ErrUnavailable, Err: errors.New("invalid connection")},
+				).Once()
+			},
+			Req:        &pb.BatchGetRecordsReq{IDs: []string{mockUUID.String()}},
+			ExpAtError: &ExpAtError{ExpStatus: http.StatusServiceUnavailable, ExpCode: codes.ErrServiceUnavailable},
+		},
+		{
+			Desc: "invalid ids are not found",
+			Req:  &pb.BatchGetRecordsReq{IDs: []string{"abc"}},
+			ExpResp: &pb.BatchGetRecordsRes{
+				Results: []*pb.BatchGetRecordsResult{{ID: "abc", NotFound: true}},
+			},
+		},
+		{
+			Desc: "normal case",
+			SetupTest: func(desc string) {
+				s.mockRecord.On(
+					"BatchGetRecords", mock.Anything, []string{
+						mockRecords[1].ID.String(), mockUUID.String(), mockRecords[0].ID.String(),
+					},
+				).Return(
+					[]*dao.Record{&mockRecords[1], nil, &mockRecords[0]}, nil,
+				).Once()
+			},
+			Req: &pb.BatchGetRecordsReq{IDs: []string{
+				mockRecords[1].ID.String(),
+				mockUUID.String(),
+				"abc",
+				strings.ToUpper(mockRecords[0].ID.String()),
+				mockRecords[1].ID.String(),
+			}},
+			ExpError: nil,
+			ExpResp: &pb.BatchGetRecordsRes{
+				Results: []*pb.BatchGetRecordsResult{
+					{ID: mockRecords[1].ID.String(), Record: mockRecords[1].FormatPb()},
+					{ID: mockUUID.String(), NotFound: true},
+					{ID: "abc", NotFound: true},
+					{ID: strings.ToUpper(mockRecords[0].ID.String()), Record: mockRecords[0].FormatPb()},
+					{ID: mockRecords[1].ID.String(), Record: mockRecords[1].FormatPb()},
+				},
+			},
+		},
+	}
+
+	for _, t := range tests {
+		if t.SetupTest != nil {
+			t.SetupTest(t.Desc)
+		}
+
+		resp, err := s.serv.BatchGetRecords(mockCTX, t.Req)
+		s.requireError(t.ExpError, t.ExpAtError, err, t.Desc)
+
+		if err == nil {
+			s.Require().Equal(t.ExpResp, resp, t.Desc)
+		}
+
+		s.TearDownTest()
+	}
+}
+
+func (s *rpcSuite) TestBatchGetRecordsRoute() {
 	tests := []struct {
 		Desc      string
-		SetupTest func(string)
-		Req       *pb.ListRecordReq
-		ExpError  error
-		ExpResp   *pb.ListRecordRes
+		Path      string
+		ExpStatus int
+	}{
+		{
+			Desc:      "batch get",
+			Path:      "/api/records/batchGet",
+			ExpStatus: http.StatusBadRequest, // no ids
+		},
+		{
+			Desc:      "the path isn't a pattern",
+			Path:      "/api/recordsX",
+			ExpStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, t := range tests {
+		w := s.serveHTTP(httptest.NewRequest(http.MethodPost, t.Path, strings.NewReader(`{"ids":[]}`)))
+		s.Require().Equal(t.ExpStatus, w.Code, t.Desc)
+	}
+}
+
+func (s *rpcSuite) TestListRecord() {
+	tests := []struct {
+		Desc       string
+		SetupTest  func(string)
+		Req        *pb.ListRecordReq
+		ExpError   error
+		ExpAtError *ExpAtError
+		ExpResp    *pb.ListRecordRes
 	}{
 		{
 			Desc: "parse size failed",
@@ -229,23 +633,23 @@ func (s *rpcSuite) TestListRecord() {
 				PageSize: "abc",
 				Page:     "1",
 			},
-			ExpError: &strconv.NumError{
-				Func: "ParseInt",
-				Num:  "abc",
-				Err:  errors.New("invalid syntax"),
-			},
+			ExpAtError: &ExpAtError{ExpStatus: http.StatusBadRequest, ExpCode: codes.ErrInvalidArgument},
 		},
 		{
-			Desc: "parse size failed",
+			Desc: "parse page failed",
 			Req: &pb.ListRecordReq{
 				PageSize: "1",
 				Page:     "abc",
 			},
-			ExpError: &strconv.NumError{
-				Func: "ParseInt",
-				Num:  "abc",
-				Err:  errors.New("invalid syntax"),
+			ExpAtError: &ExpAtError{ExpStatus: http.StatusBadRequest, ExpCode: codes.ErrInvalidArgument},
+		},
+		{
+			Desc: "negative page",
+			Req: &pb.ListRecordReq{
+				PageSize: "1",
+				Page:     "-1",
 			},
+			ExpAtError: &ExpAtError{ExpStatus: http.StatusBadRequest, ExpCode: codes.ErrInvalidArgument},
 		},
 		{
 			Desc: "list failed",
@@ -274,26 +678,1179 @@ func (s *rpcSuite) TestListRecord() {
 				).Return(
 					[]dao.Record{*mockRecord}, nil,
 				).Once()
+				s.mockRecord.On(
+					"CountRecords", mock.Anything, dao.RecordFilter{},
+				).Return(
+					int64(21), nil,
+				).Once()
 			},
 			ExpError: nil,
 			ExpResp: &pb.ListRecordRes{
-				Records: []*pb.Record{mockRecord.FormatPb()},
+				Records:    []*pb.Record{mockRecord.FormatPb()},
+				TotalCount: 21,
+				Page:       1,
+				PageSize:   10,
+				HasMore:    true,
 			},
 		},
-	}
-
-	for _, t := range tests {
-		if t.SetupTest != nil {
-			t.SetupTest(t.Desc)
-		}
-
-		resp, err := s.serv.ListRecord(mockCTX, t.Req)
-		s.Require().Equal(t.ExpError, err, t.Desc)
-
-		if err == nil {
-			s.Require().Equal(t.ExpResp, resp, t.Desc)
-		}
-
-		s.TearDownTest()
-	}
+		{
+			Desc: "count failed",
+			Req: &pb.ListRecordReq{
+				PageSize: "10",
+				Page:     "1",
+			},
+			SetupTest: func(desc string) {
+				s.mockRecord.On(
+					"ListRecords", mock.Anything, dao.ListRecordsOpt{Size: 10, Page: 1},
+				).Return(
+					[]dao.Record{*mockRecord}, nil,
+				).Once()
+				s.mockRecord.On(
+					"CountRecords", mock.Anything, dao.RecordFilter{},
+				).Return(
+					int64(0), &dao.Error{Kind: dao.ErrUnavailable, Err: errors.New("bad connection")},
+				).Once()
+			},
+			ExpAtError: &ExpAtError{ExpStatus: http.StatusServiceUnavailable, ExpCode: codes.ErrServiceUnavailable},
+		},
+		{
+			Desc: "page and page token together",
+			Req: &pb.ListRecordReq{
+				PageSize:  "10",
+				Page:      "1",
+				PageToken: mockPageToken,
+			},
+			ExpAtError: &ExpAtError{ExpStatus: http.StatusBadRequest, ExpCode: codes.ErrInvalidArgument},
+		},
+		{
+			Desc: "invalid page token",
+			Req: &pb.ListRecordReq{
+				PageSize:  "10",
+				PageToken: mockPageToken + "XD",
+			},
+			ExpAtError: &ExpAtError{ExpStatus: http.StatusBadRequest, ExpCode: codes.ErrInvalidArgument},
+		},
+		{
+			Desc: "first page by token",
+			Req: &pb.ListRecordReq{
+				PageSize: "2",
+			},
+			SetupTest: func(desc string) {
+				s.mockRecord.On(
+					"ListRecords", mock.Anything, dao.ListRecordsOpt{Size: 3},
+				).Return(
+					mockRecords, nil,
+				).Once()
+				s.mockRecord.On(
+					"CountRecords", mock.Anything, dao.RecordFilter{},
+				).Return(
+					int64(3), nil,
+				).Once()
+			},
+			ExpError: nil,
+			ExpResp: &pb.ListRecordRes{
+				Records:       []*pb.Record{mockRecords[0].FormatPb(), mockRecords[1].FormatPb()},
+				NextPageToken: mockPageToken,
+				TotalCount:    3,
+				Page:          0,
+				PageSize:      2,
+				HasMore:       true,
+			},
+		},
+		{
+			Desc: "last page by token",
+			Req: &pb.ListRecordReq{
+				PageSize:  "2",
+				PageToken: mockPageToken,
+			},
+			SetupTest: func(desc string) {
+				s.mockRecord.On(
+					"ListRecords", mock.Anything, dao.ListRecordsOpt{
+						Size:  3,
+						After: &mockCursor,
+					},
+				).Return(
+					mockRecords[2:], nil,
+				).Once()
+				// the total comes from the token
+			},
+			ExpError: nil,
+			ExpResp: &pb.ListRecordRes{
+				Records:    []*pb.Record{mockRecords[2].FormatPb()},
+				TotalCount: 3,
+				Page:       1,
+				PageSize:   2,
+				HasMore:    false,
+			},
+		},
+		{
+			Desc: "page token of another query",
+			Req: &pb.ListRecordReq{
+				PageSize:  "2",
+				PageToken: mockPageToken,
+				OrderBy:   "the_num desc",
+			},
+			ExpAtError: &ExpAtError{ExpStatus: http.StatusBadRequest, ExpCode: codes.ErrInvalidArgument},
+		},
+		{
+			Desc: "filter and order",
+			Req: &pb.ListRecordReq{
+				PageSize:     "2",
+				OrderBy:      "the_num DESC, created_at",
+				TheNumMin:    "1",
+				TheNumMax:    "3",
+				TheStrPrefix: "AT",
+				CreatedAfter: "2021-08-20T16:00:06+08:00",
+			},
+			SetupTest: func(desc string) {
+				numMin, numMax := int64(1), int64(3)
+				filter := dao.RecordFilter{
+					TheNumMin:    &numMin,
+					TheNumMax:    &numMax,
+					TheStrPrefix: "AT",
+					CreatedAfter: &mockTimeNow,
+				}
+				s.mockRecord.On(
+					"ListRecords", mock.Anything, dao.ListRecordsOpt{
+						Size:    3,
+						Filter:  filter,
+						OrderBy: []dao.RecordOrder{{Field: "the_num", Desc: true}, {Field: "created_at"}},
+					},
+				).Return(
+					mockRecords[:1], nil,
+				).Once()
+				s.mockRecord.On(
+					"CountRecords", mock.Anything, filter,
+				).Return(
+					int64(1), nil,
+				).Once()
+			},
+			ExpError: nil,
+			ExpResp: &pb.ListRecordRes{
+				Records:    []*pb.Record{mockRecords[0].FormatPb()},
+				TotalCount: 1,
+				PageSize:   2,
+			},
+		},
+		{
+			Desc: "include deleted",
+			Req: &pb.ListRecordReq{
+				PageSize:       "2",
+				IncludeDeleted: "true",
+			},
+			SetupTest: func(desc string) {
+				filter := dao.RecordFilter{IncludeDeleted: true}
+				s.mockRecord.On(
+					"ListRecords", mock.Anything, dao.ListRecordsOpt{Size: 3, Filter: filter},
+				).Return(
+					mockRecords[:1], nil,
+				).Once()
+				s.mockRecord.On(
+					"CountRecords", mock.Anything, filter,
+				).Return(
+					int64(1), nil,
+				).Once()
+			},
+			ExpError: nil,
+			ExpResp: &pb.ListRecordRes{
+				Records:    []*pb.Record{mockRecords[0].FormatPb()},
+				TotalCount: 1,
+				PageSize:   2,
+			},
+		},
+		{
+			Desc: "invalid filter",
+			Req: &pb.ListRecordReq{
+				TheNumMin: "one",
+			},
+			ExpAtError: &ExpAtError{ExpStatus: http.StatusBadRequest, ExpCode: codes.ErrInvalidArgument},
+		},
+		{
+			Desc: "invalid time filter",
+			Req: &pb.ListRecordReq{
+				UpdatedBefore: "2021-08-20",
+			},
+			ExpAtError: &ExpAtError{ExpStatus: http.StatusBadRequest, ExpCode: codes.ErrInvalidArgument},
+		},
+		{
+			Desc: "invalid order direction",
+			Req: &pb.ListRecordReq{
+				OrderBy: "the_num up",
+			},
+			ExpAtError: &ExpAtError{ExpStatus: http.StatusBadRequest, ExpCode: codes.ErrInvalidArgument},
+		},
+		{
+			Desc: "unsupported order field",
+			Req: &pb.ListRecordReq{
+				OrderBy: "id",
+			},
+			SetupTest: func(desc string) {
+				s.mockRecord.On(
+					"ListRecords", mock.Anything, dao.ListRecordsOpt{
+						Size:    defaultPageSize + 1,
+						OrderBy: []dao.RecordOrder{{Field: "id"}},
+					},
+				).Return(
+					nil, &dao.Error{Kind: dao.ErrInvalidArgument, Err: errors.New("unsupported order field")},
+				).Once()
+			},
+			ExpAtError: &ExpAtError{ExpStatus: http.StatusBadRequest, ExpCode: codes.ErrInvalidArgument},
+		},
+		{
+			Desc: "default and max page size",
+			Req: &pb.ListRecordReq{
+				PageSize: "1000",
+				Page:     "0",
+			},
+			SetupTest: func(desc string) {
+				s.mockRecord.On(
+					"ListRecords", mock.Anything, dao.ListRecordsOpt{Size: maxPageSize, Page: 0},
+				).Return(
+					[]dao.Record{}, nil,
+				).Once()
+				s.mockRecord.On(
+					"CountRecords", mock.Anything, dao.RecordFilter{},
+				).Return(
+					int64(0), nil,
+				).Once()
+			},
+			ExpError: nil,
+			ExpResp: &pb.ListRecordRes{
+				Records:  []*pb.Record{},
+				PageSize: maxPageSize,
+			},
+		},
+	}
+
+	for _, t := range tests {
+		if t.SetupTest != nil {
+			t.SetupTest(t.Desc)
+		}
+
+		resp, err := s.serv.ListRecord(mockCTX, t.Req)
+		s.requireError(t.ExpError, t.ExpAtError, err, t.Desc)
+
+		if err == nil {
+			s.Require().Equal(t.ExpResp, resp, t.Desc)
+		}
+
+		s.TearDownTest()
+	}
+}
+
+func (s *rpcSuite) TestPaginationHeaders() {
+	tests := []struct {
+		Desc      string
+		SetupTest func(string)
+		URL       string
+		ExpHeader http.Header
+	}{
+		{
+			Desc: "paging by offset",
+			URL:  "/api/records?size=10&page=1",
+			SetupTest: func(desc string) {
+				s.mockRecord.On(
+					"ListRecords", mock.Anything, dao.ListRecordsOpt{Size: 10, Page: 1},
+				).Return(
+					[]dao.Record{*mockRecord}, nil,
+				).Once()
+				s.mockRecord.On(
+					"CountRecords", mock.Anything, dao.RecordFilter{},
+				).Return(
+					int64(21), nil,
+				).Once()
+			},
+			ExpHeader: http.Header{
+				"X-Total-Count": {"21"},
+				"Link": {`</api/records?page=0&size=10>; rel="first", </api/records?page=0&size=10>; rel="prev", ` +
+					`</api/records?page=2&size=10>; rel="next", </api/records?page=2&size=10>; rel="last"`},
+			},
+		},
+		{
+			Desc: "paging by token",
+			URL:  "/api/records?size=2",
+			SetupTest: func(desc string) {
+				s.mockRecord.On(
+					"ListRecords", mock.Anything, dao.ListRecordsOpt{Size: 3},
+				).Return(
+					mockRecords, nil,
+				).Once()
+				s.mockRecord.On(
+					"CountRecords", mock.Anything, dao.RecordFilter{},
+				).Return(
+					int64(3), nil,
+				).Once()
+			},
+			ExpHeader: http.Header{
+				"X-Next-Page-Token": {mockPageToken},
+				"X-Total-Count":     {"3"},
+				"Link": {fmt.Sprintf(`</api/records?size=2>; rel="first", </api/records?%s>; rel="next"`,
+					url.Values{"size": {"2"}, "page_token": {mockPageToken}}.Encode())},
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.SetupTest()
+		t.SetupTest(t.Desc)
+
+		w := s.serveHTTP(httptest.NewRequest(http.MethodGet, t.URL, nil))
+		s.Require().Equal(http.StatusOK, w.Code, t.Desc)
+		for key := range t.ExpHeader {
+			s.Require().Equal(t.ExpHeader.Get(key), w.Header().Get(key), t.Desc)
+		}
+		s.Require().Equal(t.ExpHeader.Get("X-Next-Page-Token"), w.Header().Get("X-Next-Page-Token"), t.Desc)
+
+		s.TearDownTest()
+	}
+}
+
+func (s *rpcSuite) TestUpdateRecord() {
+	tests := []struct {
+		Desc       string
+		SetupTest  func(string)
+		Req        *pb.UpdateRecordReq
+		ExpError   error
+		ExpAtError *ExpAtError
+		ExpResp    *pb.UpdateRecordRes
+		// ExpCurrentVersion is in the error body of the version conflicts.
+		ExpCurrentVersion int64
+	}{
+		{
+			Desc: "invalid id",
+			Req: &pb.UpdateRecordReq{
+				ID:      "abc",
+				TheNum:  mockRecord.TheNum,
+				TheStr:  mockRecord.TheStr,
+				Version: 1,
+			},
+			ExpAtError: &ExpAtError{ExpStatus: http.StatusBadRequest, ExpCode: codes.ErrInvalidArgument},
+		},
+		{
+			Desc: "not found",
+			SetupTest: func(desc string) {
+				s.mockRecord.On(
+					"UpdateRecord", mock.Anything, &dao.Record{ID: mockUUID, TheNum: mockRecord.TheNum, TheStr: mockRecord.TheStr, Version: 1},
+				).Return(
+					&dao.Error{Kind: dao.ErrNotFound, Err: errors.New("record not found")},
+				).Once()
+			},
+			Req: &pb.UpdateRecordReq{
+				ID:      mockUUID.String(),
+				TheNum:  mockRecord.TheNum,
+				TheStr:  mockRecord.TheStr,
+				Version: 1,
+			},
+			ExpAtError: &ExpAtError{ExpStatus: http.StatusNotFound, ExpCode: codes.ErrNotFound},
+		},
+		{
+			Desc: "no version",
+			Req: &pb.UpdateRecordReq{
+				ID:     mockUUID.String(),
+				TheNum: mockRecord.TheNum,
+				TheStr: mockRecord.TheStr,
+			},
+			ExpAtError: &ExpAtError{ExpStatus: http.StatusBadRequest, ExpCode: codes.ErrInvalidArgument},
+		},
+		{
+			Desc: "version conflict",
+			SetupTest: func(desc string) {
+				s.mockRecord.On(
+					"UpdateRecord", mock.Anything, &dao.Record{ID: mockUUID, TheNum: mockRecord.TheNum, TheStr: mockRecord.TheStr, Version: 1},
+				).Return(
+					&dao.Error{Kind: dao.ErrConflict, Err: &dao.VersionConflictError{Expected: 1, Current: 3}},
+				).Once()
+			},
+			Req: &pb.UpdateRecordReq{
+				ID:      mockUUID.String(),
+				TheNum:  mockRecord.TheNum,
+				TheStr:  mockRecord.TheStr,
+				Version: 1,
+			},
+			ExpAtError:        &ExpAtError{ExpStatus: http.StatusConflict, ExpCode: codes.ErrConflict},
+			ExpCurrentVersion: 3,
+		},
+		{
+			Desc: "update failed",
+			SetupTest: func(desc string) {
+				s.mockRecord.On(
+					"UpdateRecord", mock.Anything, &dao.Record{ID: mockUUID, TheNum: mockRecord.TheNum, TheStr: mockRecord.TheStr, Version: 1},
+				).Return(
+					errors.New("XD"),
+				).Once()
+			},
+			Req: &pb.UpdateRecordReq{
+				ID:      mockUUID.String(),
+				TheNum:  mockRecord.TheNum,
+				TheStr:  mockRecord.TheStr,
+				Version: 1,
+			},
+			ExpError: errors.New("XD"),
+		},
+		{
+			Desc: "normal case",
+			SetupTest: func(desc string) {
+				s.mockRecord.On(
+					"UpdateRecord", mock.Anything, &dao.Record{ID: mockUUID, TheNum: mockRecord.TheNum, TheStr: mockRecord.TheStr, Version: 1},
+				).Return(
+					nil,
+				).Once()
+			},
+			Req: &pb.UpdateRecordReq{
+				ID:      mockUUID.String(),
+				TheNum:  mockRecord.TheNum,
+				TheStr:  mockRecord.TheStr,
+				Version: 1,
+			},
+			ExpError: nil,
+			ExpResp: &pb.UpdateRecordRes{
+				Record: (&dao.Record{ID: mockUUID, TheNum: mockRecord.TheNum, TheStr: mockRecord.TheStr, Version: 1}).FormatPb(),
+			},
+		},
+	}
+
+	for _, t := range tests {
+		if t.SetupTest != nil {
+			t.SetupTest(t.Desc)
+		}
+
+		resp, err := s.serv.UpdateRecord(mockCTX, t.Req)
+		s.requireError(t.ExpError, t.ExpAtError, err, t.Desc)
+
+		if t.ExpCurrentVersion != 0 {
+			s.Require().Equal(t.ExpCurrentVersion, errorkit.FormatError(err).GinHashMap()["currentVersion"], t.Desc)
+
+			// the grpc callers get it in the details
+			_, grpcErr := interceptor.UnaryErrorInterceptor()(mockCTX, t.Req, &grpc.UnaryServerInfo{}, func(context.Context, interface{}) (interface{}, error) {
+				return nil, err
+			})
+			st, ok := status.FromError(grpcErr)
+			s.Require().True(ok, t.Desc)
+			s.Require().Equal(grpcCodes.Aborted, st.Code(), t.Desc)
+			s.Require().Len(st.Details(), 1, t.Desc)
+			info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+			s.Require().True(ok, t.Desc)
+			s.Require().Equal(strconv.FormatInt(t.ExpCurrentVersion, 10), info.Metadata["currentVersion"], t.Desc)
+		}
+
+		if err == nil {
+			s.Require().Equal(t.ExpResp, resp, t.Desc)
+		}
+
+		s.TearDownTest()
+	}
+}
+
+func (s *rpcSuite) TestETag() {
+	tests := []struct {
+		Desc      string
+		SetupTest func(string)
+		Method    string
+		Body      string
+		IfMatch   string
+		ExpStatus int
+		ExpETag   string
+	}{
+		{
+			Desc: "get record",
+			SetupTest: func(desc string) {
+				s.mockRecord.On(
+					"GetRecord", mock.Anything, mockUUID.String(),
+				).Return(
+					&dao.Record{ID: mockUUID, TheNum: mockRecord.TheNum, TheStr: mockRecord.TheStr, Version: 3}, nil,
+				).Once()
+			},
+			Method:    http.MethodGet,
+			ExpStatus: http.StatusOK,
+			ExpETag:   `"3"`,
+		},
+		{
+			Desc: "update by If-Match",
+			SetupTest: func(desc string) {
+				s.mockRecord.On(
+					"UpdateRecord", mock.Anything, &dao.Record{ID: mockUUID, TheNum: mockRecord.TheNum, TheStr: mockRecord.TheStr, Version: 3},
+				).Return(
+					nil,
+				).Once()
+			},
+			Method:    http.MethodPut,
+			Body:      `{"theNum":3838,"theStr":"AT"}`,
+			IfMatch:   `"3"`,
+			ExpStatus: http.StatusOK,
+			ExpETag:   `"3"`,
+		},
+		{
+			Desc: "the version field matches If-Match",
+			SetupTest: func(desc string) {
+				s.mockRecord.On(
+					"UpdateRecord", mock.Anything, &dao.Record{ID: mockUUID, TheNum: mockRecord.TheNum, TheStr: mockRecord.TheStr, Version: 3},
+				).Return(
+					nil,
+				).Once()
+			},
+			Method:    http.MethodPut,
+			Body:      `{"theNum":3838,"theStr":"AT","version":"3"}`,
+			IfMatch:   `"2", "3"`,
+			ExpStatus: http.StatusOK,
+			ExpETag:   `"3"`,
+		},
+		{
+			Desc:      "the version field doesn't match If-Match",
+			Method:    http.MethodPut,
+			Body:      `{"theNum":3838,"theStr":"AT","version":"2"}`,
+			IfMatch:   `"3"`,
+			ExpStatus: http.StatusBadRequest,
+		},
+		{
+			Desc:      "no version",
+			Method:    http.MethodPut,
+			Body:      `{"theNum":3838,"theStr":"AT"}`,
+			ExpStatus: http.StatusBadRequest,
+		},
+		{
+			Desc: "update by If-Match *",
+			SetupTest: func(desc string) {
+				s.mockRecord.On(
+					"GetRecord", mock.Anything, mockUUID.String(),
+				).Return(
+					&dao.Record{ID: mockUUID, TheNum: 1, TheStr: "XD", Version: 4}, nil,
+				).Once()
+				s.mockRecord.On(
+					"UpdateRecord", mock.Anything, &dao.Record{ID: mockUUID, TheNum: mockRecord.TheNum, TheStr: mockRecord.TheStr, Version: 4},
+				).Return(
+					nil,
+				).Once()
+			},
+			Method:    http.MethodPut,
+			Body:      `{"theNum":3838,"theStr":"AT"}`,
+			IfMatch:   "*",
+			ExpStatus: http.StatusOK,
+			ExpETag:   `"4"`,
+		},
+		{
+			Desc: "If-Match * on a missing record",
+			SetupTest: func(desc string) {
+				s.mockRecord.On(
+					"GetRecord", mock.Anything, mockUUID.String(),
+				).Return(
+					nil, &dao.Error{Kind: dao.ErrNotFound, Err: errors.New("record not found")},
+				).Once()
+			},
+			Method:    http.MethodPut,
+			Body:      `{"theNum":3838,"theStr":"AT"}`,
+			IfMatch:   "*",
+			ExpStatus: http.StatusPreconditionFailed,
+		},
+		{
+			Desc: "update by a list of If-Match",
+			SetupTest: func(desc string) {
+				s.mockRecord.On(
+					"GetRecord", mock.Anything, mockUUID.String(),
+				).Return(
+					&dao.Record{ID: mockUUID, TheNum: 1, TheStr: "XD", Version: 4}, nil,
+				).Once()
+				s.mockRecord.On(
+					"UpdateRecord", mock.Anything, &dao.Record{ID: mockUUID, TheNum: mockRecord.TheNum, TheStr: mockRecord.TheStr, Version: 4},
+				).Return(
+					nil,
+				).Once()
+			},
+			Method:    http.MethodPut,
+			Body:      `{"theNum":3838,"theStr":"AT"}`,
+			IfMatch:   `"3", "4"`,
+			ExpStatus: http.StatusOK,
+			ExpETag:   `"4"`,
+		},
+		{
+			Desc: "the current version isn't in the list of If-Match",
+			SetupTest: func(desc string) {
+				s.mockRecord.On(
+					"GetRecord", mock.Anything, mockUUID.String(),
+				).Return(
+					&dao.Record{ID: mockUUID, TheNum: 1, TheStr: "XD", Version: 4}, nil,
+				).Once()
+			},
+			Method:    http.MethodPut,
+			Body:      `{"theNum":3838,"theStr":"AT"}`,
+			IfMatch:   `"1", "2"`,
+			ExpStatus: http.StatusPreconditionFailed,
+		},
+		{
+			Desc:      "invalid If-Match",
+			Method:    http.MethodPut,
+			Body:      `{"theNum":3838,"theStr":"AT"}`,
+			IfMatch:   "3",
+			ExpStatus: http.StatusBadRequest,
+		},
+		{
+			Desc:      "weak If-Match",
+			Method:    http.MethodPut,
+			Body:      `{"theNum":3838,"theStr":"AT"}`,
+			IfMatch:   `W/"3"`,
+			ExpStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, t := range tests {
+		s.SetupTest()
+		if t.SetupTest != nil {
+			t.SetupTest(t.Desc)
+		}
+
+		req := httptest.NewRequest(t.Method, "/api/records/"+mockUUID.String(), strings.NewReader(t.Body))
+		if t.IfMatch != "" {
+			req.Header.Set("If-Match", t.IfMatch)
+		}
+
+		w := s.serveHTTP(req)
+		s.Require().Equal(t.ExpStatus, w.Code, t.Desc)
+		s.Require().Equal(t.ExpETag, w.Header().Get("ETag"), t.Desc)
+
+		s.TearDownTest()
+	}
+}
+
+func (s *rpcSuite) TestDeleteRecord() {
+	tests := []struct {
+		Desc       string
+		SetupTest  func(string)
+		Req        *pb.DeleteRecordReq
+		ExpError   error
+		ExpAtError *ExpAtError
+		ExpResp    *pb.DeleteRecordRes
+	}{
+		{
+			Desc:       "invalid id",
+			Req:        &pb.DeleteRecordReq{ID: "abc"},
+			ExpAtError: &ExpAtError{ExpStatus: http.StatusBadRequest, ExpCode: codes.ErrInvalidArgument},
+		},
+		{
+			Desc: "delete failed",
+			SetupTest: func(desc string) {
+				s.mockRecord.On(
+					"DeleteRecord", mock.Anything, mockUUID.String(),
+				).Return(
+					errors.New("XD"),
+				).Once()
+			},
+			Req:      &pb.DeleteRecordReq{ID: mockUUID.String()},
+			ExpError: errors.New("XD"),
+		},
+		{
+			Desc: "not found",
+			SetupTest: func(desc string) {
+				s.mockRecord.On(
+					"DeleteRecord", mock.Anything, mockUUID.String(),
+				).Return(
+					&dao.Error{Kind: dao.ErrNotFound, Err: errors.New("record not found")},
+				).Once()
+			},
+			Req:        &pb.DeleteRecordReq{ID: mockUUID.String()},
+			ExpAtError: &ExpAtError{ExpStatus: http.StatusNotFound, ExpCode: codes.ErrNotFound},
+		},
+		{
+			Desc: "normal case",
+			SetupTest: func(desc string) {
+				s.mockRecord.On(
+					"DeleteRecord", mock.Anything, mockUUID.String(),
+				).Return(
+					nil,
+				).Once()
+			},
+			Req:      &pb.DeleteRecordReq{ID: mockUUID.String()},
+			ExpError: nil,
+			ExpResp:  &pb.DeleteRecordRes{ID: mockUUID.String()},
+		},
+	}
+
+	for _, t := range tests {
+		if t.SetupTest != nil {
+			t.SetupTest(t.Desc)
+		}
+
+		resp, err := s.serv.DeleteRecord(mockCTX, t.Req)
+		s.requireError(t.ExpError, t.ExpAtError, err, t.Desc)
+
+		if err == nil {
+			s.Require().Equal(t.ExpResp, resp, t.Desc)
+		}
+
+		s.TearDownTest()
+	}
+}
+
+func (s *rpcSuite) TestRestoreRecord() {
+	tests := []struct {
+		Desc       string
+		SetupTest  func(string)
+		Req        *pb.RestoreRecordReq
+		ExpError   error
+		ExpAtError *ExpAtError
+		ExpResp    *pb.RestoreRecordRes
+	}{
+		{
+			Desc:       "invalid id",
+			Req:        &pb.RestoreRecordReq{ID: "abc"},
+			ExpAtError: &ExpAtError{ExpStatus: http.StatusBadRequest, ExpCode: codes.ErrInvalidArgument},
+		},
+		{
+			Desc: "restore failed",
+			SetupTest: func(desc string) {
+				s.mockRecord.On(
+					"RestoreRecord", mock.Anything, mockUUID.String(),
+				).Return(
+					nil, errors.New("XD"),
+				).Once()
+			},
+			Req:      &pb.RestoreRecordReq{ID: mockUUID.String()},
+			ExpError: errors.New("XD"),
+		},
+		{
+			Desc: "not found",
+			SetupTest: func(desc string) {
+				s.mockRecord.On(
+					"RestoreRecord", mock.Anything, mockUUID.String(),
+				).Return(
+					nil, &dao.Error{Kind: dao.ErrNotFound, Err: errors.New("record not found")},
+				).Once()
+			},
+			Req:        &pb.RestoreRecordReq{ID: mockUUID.String()},
+			ExpAtError: &ExpAtError{ExpStatus: http.StatusNotFound, ExpCode: codes.ErrNotFound},
+		},
+		{
+			Desc: "normal case",
+			SetupTest: func(desc string) {
+				s.mockRecord.On(
+					"RestoreRecord", mock.Anything, mockUUID.String(),
+				).Return(
+					mockRecord, nil,
+				).Once()
+			},
+			Req:      &pb.RestoreRecordReq{ID: mockUUID.String()},
+			ExpError: nil,
+			ExpResp:  &pb.RestoreRecordRes{Record: mockRecord.FormatPb()},
+		},
+	}
+
+	for _, t := range tests {
+		if t.SetupTest != nil {
+			t.SetupTest(t.Desc)
+		}
+
+		resp, err := s.serv.RestoreRecord(mockCTX, t.Req)
+		s.requireError(t.ExpError, t.ExpAtError, err, t.Desc)
+
+		if err == nil {
+			s.Require().Equal(t.ExpResp, resp, t.Desc)
+		}
+
+		s.TearDownTest()
+	}
+}
+
+func (s *rpcSuite) TestListRecordRevisions() {
+	otherUUID := uuid.New()
+	revisions := []dao.RecordRevision{
+		{ID: 3, RecordID: mockUUID.String(), Action: dao.RevisionDelete, Actor: "alice", OldValue: (*dao.RecordValue)(&mockRecords[1]), CreatedAt: &mockTimeNow},
+		{ID: 2, RecordID: mockUUID.String(), Action: dao.RevisionUpdate, Actor: "alice", OldValue: (*dao.RecordValue)(&mockRecords[0]), NewValue: (*dao.RecordValue)(&mockRecords[1]), CreatedAt: &mockTimeNow},
+		{ID: 1, RecordID: mockUUID.String(), Action: dao.RevisionCreate, Actor: "bob", NewValue: (*dao.RecordValue)(&mockRecords[0]), CreatedAt: &mockTimeNow},
+	}
+	nextPageToken, _ := encodeRevisionPageToken([]byte(mockSecret), mockUUID.String(), 2)
+	otherPageToken, _ := encodeRevisionPageToken([]byte(mockSecret), otherUUID.String(), 2)
+
+	tests := []struct {
+		Desc       string
+		SetupTest  func(string)
+		Req        *pb.ListRecordRevisionsReq
+		ExpError   error
+		ExpAtError *ExpAtError
+		ExpResp    *pb.ListRecordRevisionsRes
+	}{
+		{
+			Desc:       "invalid id",
+			Req:        &pb.ListRecordRevisionsReq{ID: "abc"},
+			ExpAtError: &ExpAtError{ExpStatus: http.StatusBadRequest, ExpCode: codes.ErrInvalidArgument},
+		},
+		{
+			Desc:       "page token of another record",
+			Req:        &pb.ListRecordRevisionsReq{ID: mockUUID.String(), PageToken: otherPageToken},
+			ExpAtError: &ExpAtError{ExpStatus: http.StatusBadRequest, ExpCode: codes.ErrInvalidArgument},
+		},
+		{
+			Desc: "list failed",
+			SetupTest: func(desc string) {
+				s.mockRecord.On(
+					"ListRecordRevisions", mock.Anything, mockUUID.String(), dao.ListRevisionsOpt{Size: defaultPageSize + 1},
+				).Return(
+					nil, errors.New("XD"),
+				).Once()
+			},
+			Req:      &pb.ListRecordRevisionsReq{ID: mockUUID.String()},
+			ExpError: errors.New("XD"),
+		},
+		{
+			Desc: "first page",
+			SetupTest: func(desc string) {
+				s.mockRecord.On(
+					"ListRecordRevisions", mock.Anything, mockUUID.String(), dao.ListRevisionsOpt{Size: 3},
+				).Return(
+					revisions, nil,
+				).Once()
+			},
+			Req: &pb.ListRecordRevisionsReq{ID: mockUUID.String(), PageSize: "2"},
+			ExpResp: &pb.ListRecordRevisionsRes{
+				Revisions:     []*pb.RecordRevision{revisions[0].FormatPb(), revisions[1].FormatPb()},
+				NextPageToken: nextPageToken,
+			},
+		},
+		{
+			Desc: "last page",
+			SetupTest: func(desc string) {
+				s.mockRecord.On(
+					"ListRecordRevisions", mock.Anything, mockUUID.String(), dao.ListRevisionsOpt{Size: 3, Before: 2},
+				).Return(
+					revisions[2:], nil,
+				).Once()
+			},
+			Req: &pb.ListRecordRevisionsReq{ID: mockUUID.String(), PageSize: "2", PageToken: nextPageToken},
+			ExpResp: &pb.ListRecordRevisionsRes{
+				Revisions: []*pb.RecordRevision{revisions[2].FormatPb()},
+			},
+		},
+	}
+
+	for _, t := range tests {
+		if t.SetupTest != nil {
+			t.SetupTest(t.Desc)
+		}
+
+		resp, err := s.serv.ListRecordRevisions(mockCTX, t.Req)
+		s.requireError(t.ExpError, t.ExpAtError, err, t.Desc)
+
+		if err == nil {
+			s.Require().Equal(t.ExpResp, resp, t.Desc)
+		}
+
+		s.TearDownTest()
+	}
+}
+
+func (s *rpcSuite) TestWithAudit() {
+	tests := []struct {
+		Desc     string
+		Identity *auth.Identity
+		MD       metadata.MD
+		ExpAudit dao.Audit
+	}{
+		{
+			Desc:     "anonymous",
+			ExpAudit: dao.Audit{},
+		},
+		{
+			Desc:     "caller and request id",
+			Identity: &auth.Identity{Subject: "alice", Method: auth.MethodJWT},
+			MD:       metadata.Pairs(interceptor.MDRequestID, "req-1"),
+			ExpAudit: dao.Audit{Actor: "alice", RequestID: "req-1"},
+		},
+	}
+
+	for _, t := range tests {
+		ctx := mockCTX
+		if t.Identity != nil {
+			ctx = auth.WithIdentity(ctx, *t.Identity)
+		}
+		if t.MD != nil {
+			ctx = metadata.NewIncomingContext(ctx, t.MD)
+		}
+
+		_, err := interceptor.UnaryRequestIDInterceptor()(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, _ interface{}) (interface{}, error) {
+			audit := dao.AuditFromContext(withAudit(ctx))
+
+			if t.ExpAudit.RequestID == "" {
+				// a new one is made
+				s.Require().NotEmpty(audit.RequestID, t.Desc)
+				audit.RequestID = ""
+			}
+			s.Require().Equal(t.ExpAudit, audit, t.Desc)
+
+			return nil, nil
+		})
+		s.Require().NoError(err, t.Desc)
+	}
+}
+
+func (s *rpcSuite) TestAuthorize() {
+	policy := config.AuthzConfig{
+		Enable: true,
+		Roles: map[string][]string{
+			"reader": {"GetRecord", "ListRecord"},
+			"admin":  {"*"},
+		},
+	}
+
+	tests := []struct {
+		Desc       string
+		Config     config.AuthzConfig
+		Ctx        context.Context
+		Method     string
+		ExpAtError *ExpAtError
+	}{
+		{
+			Desc:   "disabled",
+			Config: config.AuthzConfig{},
+			Ctx:    mockCTX,
+			Method: "CreateRecord",
+		},
+		{
+			Desc:   "allowed",
+			Config: policy,
+			Ctx:    auth.WithIdentity(mockCTX, auth.Identity{Subject: "user", Roles: []string{"reader"}}),
+			Method: "GetRecord",
+		},
+		{
+			Desc:   "wildcard",
+			Config: policy,
+			Ctx:    auth.WithIdentity(mockCTX, auth.Identity{Subject: "user", Roles: []string{"reader", "admin"}}),
+			Method: "CreateRecord",
+		},
+		{
+			Desc:       "denied",
+			Config:     policy,
+			Ctx:        auth.WithIdentity(mockCTX, auth.Identity{Subject: "user", Roles: []string{"reader"}}),
+			Method:     "CreateRecord",
+			ExpAtError: &ExpAtError{ExpStatus: http.StatusForbidden, ExpCode: codes.ErrPermissionDenied},
+		},
+		{
+			Desc:       "no roles",
+			Config:     policy,
+			Ctx:        auth.WithIdentity(mockCTX, auth.Identity{Subject: "svc"}),
+			Method:     "GetRecord",
+			ExpAtError: &ExpAtError{ExpStatus: http.StatusForbidden, ExpCode: codes.ErrPermissionDenied},
+		},
+		{
+			Desc:       "no identity",
+			Config:     policy,
+			Ctx:        mockCTX,
+			Method:     "GetRecord",
+			ExpAtError: &ExpAtError{ExpStatus: http.StatusForbidden, ExpCode: codes.ErrPermissionDenied},
+		},
+	}
+
+	for _, t := range tests {
+		cfg := t.Config
+		s.serv.authzConfig = func() config.AuthzConfig { return cfg }
+
+		err := s.serv.authorize(t.Ctx, t.Method)
+		s.requireError(nil, t.ExpAtError, err, t.Desc)
+	}
+}
+
+func (s *rpcSuite) TestCreateRecordDenied() {
+	s.serv.authzConfig = func() config.AuthzConfig {
+		return config.AuthzConfig{Enable: true, Roles: map[string][]string{"reader": {"GetRecord"}}}
+	}
+
+	ctx := auth.WithIdentity(mockCTX, auth.Identity{Subject: "user", Roles: []string{"reader"}})
+	_, err := s.serv.CreateRecord(ctx, &pb.CreateRecordReq{TheNum: mockRecord.TheNum, TheStr: mockRecord.TheStr})
+	s.requireError(nil, &ExpAtError{ExpStatus: http.StatusForbidden, ExpCode: codes.ErrPermissionDenied}, err, "denied before the dao is called")
+}
+
+func (s *rpcSuite) TestLimit() {
+	limits := config.RateLimitConfig{
+		Enable:  true,
+		Methods: map[string]config.RateLimit{"CreateRecord": {Rate: 1, Burst: 1}},
+	}
+
+	tests := []struct {
+		Desc       string
+		Config     config.RateLimitConfig
+		Limiter    ratelimit.Limiter
+		Ctx        context.Context
+		Method     string
+		ExpAtError *ExpAtError
+	}{
+		{
+			Desc:    "disabled",
+			Config:  config.RateLimitConfig{},
+			Limiter: &fakeLimiter{res: ratelimit.Result{Allowed: false}},
+			Ctx:     mockCTX,
+			Method:  "CreateRecord",
+		},
+		{
+			Desc:    "no limit of the method",
+			Config:  limits,
+			Limiter: &fakeLimiter{res: ratelimit.Result{Allowed: false}},
+			Ctx:     mockCTX,
+			Method:  "GetRecord",
+		},
+		{
+			Desc:    "allowed",
+			Config:  limits,
+			Limiter: &fakeLimiter{res: ratelimit.Result{Allowed: true}},
+			Ctx:     auth.WithIdentity(mockCTX, auth.Identity{Subject: "user", Method: auth.MethodJWT}),
+			Method:  "CreateRecord",
+		},
+		{
+			Desc:       "limited",
+			Config:     limits,
+			Limiter:    &fakeLimiter{res: ratelimit.Result{Allowed: false, RetryAfter: time.Second}},
+			Ctx:        auth.WithIdentity(mockCTX, auth.Identity{Subject: "user", Method: auth.MethodJWT}),
+			Method:     "CreateRecord",
+			ExpAtError: &ExpAtError{ExpStatus: http.StatusTooManyRequests, ExpCode: codes.ErrTooManyRequests},
+		},
+		{
+			Desc:    "limiter failed",
+			Config:  limits,
+			Limiter: &fakeLimiter{err: errors.New("XD")},
+			Ctx:     mockCTX,
+			Method:  "CreateRecord",
+		},
+	}
+
+	for _, t := range tests {
+		cfg := t.Config
+		s.serv.rateLimitConfig = func() config.RateLimitConfig { return cfg }
+		s.serv.rateLimiter = t.Limiter
+
+		err := s.serv.limit(t.Ctx, t.Method)
+		s.requireError(nil, t.ExpAtError, err, t.Desc)
+	}
+
+	// keyed by the method and the caller
+	limiter := &fakeLimiter{res: ratelimit.Result{Allowed: true}}
+	s.serv.rateLimitConfig = func() config.RateLimitConfig { return limits }
+	s.serv.rateLimiter = limiter
+
+	s.Require().NoError(s.serv.limit(auth.WithIdentity(mockCTX, auth.Identity{Subject: "svc", Method: auth.MethodAPIKey}), "CreateRecord"))
+	s.Require().Equal("CreateRecord:apikey:svc", limiter.key)
+	s.Require().Equal(ratelimit.Limit{Rate: 1, Burst: 1}, limiter.limit)
+}
+
+func (s *rpcSuite) TestValid() {
+	tests := []struct {
+		Desc          string
+		Req           interface{}
+		ExpViolations []FieldViolation
+	}{
+		{
+			Desc: "valid",
+			Req:  &pb.CreateRecordReq{TheNum: mockRecord.TheNum, TheStr: mockRecord.TheStr},
+		},
+		{
+			Desc: "create record",
+			Req:  &pb.CreateRecordReq{TheNum: -1 << 32, IdempotencyKey: strings.Repeat("k", 256)},
+			ExpViolations: []FieldViolation{
+				{Field: "theNum", Rule: "gte", Param: "-2147483648"},
+				{Field: "theStr", Rule: "required"},
+				{Field: "idempotencyKey", Rule: "max", Param: "255"},
+			},
+		},
+		{
+			Desc: "get record",
+			Req:  &pb.GetRecordReq{ID: "abc"},
+			ExpViolations: []FieldViolation{
+				{Field: "id", Rule: "uuid"},
+			},
+		},
+		{
+			Desc: "list record",
+			Req:  &pb.ListRecordReq{PageSize: "XD", TheNumMin: "1.5e", IncludeDeleted: "yes"},
+			ExpViolations: []FieldViolation{
+				{Field: "size", Rule: "numeric"},
+				{Field: "theNumMin", Rule: "numeric"},
+				{Field: "includeDeleted", Rule: "oneof", Param: "true false"},
+			},
+		},
+	}
+
+	validators := []struct {
+		Desc      string
+		Validator validatorkit.Validator
+	}{
+		{Desc: "validatorkit", Validator: validatorkit.NewGoPlaygroundValidator()},
+		{Desc: "wrapped", Validator: wrappingValidator{validatorkit.NewGoPlaygroundValidator()}},
+	}
+
+	for _, v := range validators {
+		serv := NewGoAmazingServer(GoAmazingServerOpt{Validator: v.Validator})
+
+		for _, t := range tests {
+			s.requireViolations(t.ExpViolations, serv.valid(mockCTX, t.Req), v.Desc+": "+t.Desc)
+		}
+	}
+
+	// the http callers get the violations in the error body
+	w := s.serveHTTP(httptest.NewRequest(http.MethodPost, "/api/record", strings.NewReader(`{"theNum":3838}`)))
+	s.Require().Equal(http.StatusBadRequest, w.Code)
+
+	body := struct {
+		Details []FieldViolation `json:"details"`
+	}{}
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &body))
+	s.Require().Equal([]FieldViolation{{Field: "theStr", Rule: "required"}}, body.Details)
+}
+
+// requireViolations checks the invalid argument error carries the violations for both http and grpc.
+func (s *rpcSuite) requireViolations(expViolations []FieldViolation, err error, desc string) {
+	if expViolations == nil {
+		s.Require().NoError(err, desc)
+		return
+	}
+
+	atErr := errorkit.FormatError(err)
+	s.Require().Equal(codes.ErrInvalidArgument, atErr.ATErrorCode(), desc)
+	s.Require().Equal(http.StatusBadRequest, atErr.HttpStatus(), desc)
+	s.Require().Equal(expViolations, atErr.GinHashMap()["details"], desc)
+
+	// the grpc callers get the violations by the status
+	st, ok := status.FromError(err)
+	s.Require().True(ok, desc)
+	s.Require().Equal(grpcCodes.InvalidArgument, st.Code(), desc)
+	s.Require().Len(st.Details(), 1, desc)
+	br, ok := st.Details()[0].(*errdetails.BadRequest)
+	s.Require().True(ok, desc)
+	s.Require().Len(br.FieldViolations, len(expViolations), desc)
+	for i, v := range expViolations {
+		s.Require().Equal(v.Field, br.FieldViolations[i].Field, desc)
+	}
+}
+
+func (s *rpcSuite) TestPageToken() {
+	cursor := dao.Cursor{ID: mockUUID.String(), TheNum: 3838, TheStr: "AT", CreatedAt: mockTimeNow, UpdatedAt: mockTimeNow}
+
+	token, err := encodePageToken([]byte(mockSecret), cursor, 2, 38, mockQuery)
+	s.Require().NoError(err)
+
+	got, page, total, err := decodePageToken([]byte(mockSecret), token, mockQuery)
+	s.Require().NoError(err)
+	s.Require().Equal(&cursor, got)
+	s.Require().Equal(2, page)
+	s.Require().Equal(int64(38), total)
+
+	_, _, _, err = decodePageToken([]byte("other secret"), token, mockQuery)
+	s.Require().Equal(errInvalidPageToken, err, "signed by another secret")
+
+	_, _, _, err = decodePageToken([]byte(mockSecret), token, "another query")
+	s.Require().Equal(errInvalidPageToken, err, "issued for another query")
+
+	_, _, _, err = decodePageToken([]byte(mockSecret), "XD", mockQuery)
+	s.Require().Equal(errInvalidPageToken, err, "malformed")
+}
+
+func (s *rpcSuite) TestRevisionPageToken() {
+	token, err := encodeRevisionPageToken([]byte(mockSecret), mockUUID.String(), 38)
+	s.Require().NoError(err)
+
+	before, err := decodeRevisionPageToken([]byte(mockSecret), token, mockUUID.String())
+	s.Require().NoError(err)
+	s.Require().Equal(int64(38), before)
+
+	_, err = decodeRevisionPageToken([]byte("other secret"), token, mockUUID.String())
+	s.Require().Equal(errInvalidPageToken, err, "signed by another secret")
+
+	_, err = decodeRevisionPageToken([]byte(mockSecret), token, uuid.New().String())
+	s.Require().Equal(errInvalidPageToken, err, "issued for another record")
+
+	_, err = decodeRevisionPageToken([]byte(mockSecret), mockPageToken, mockUUID.String())
+	s.Require().Equal(errInvalidPageToken, err, "a page token of ListRecord")
 }
@@ -0,0 +1,56 @@
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+)
+
+type httpTransportKey struct{}
+
+// httpTransport is the http request being served and the headers of its response, the http
+// adapter is generated, so the handlers reach the headers by it.
+type httpTransport struct {
+	req       *http.Request
+	setHeader func(key, value string)
+}
+
+// GinMiddleware puts the http transport into the context for the handlers. It has to be used
+// before the routes are registered.
+func GinMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		c := context.WithValue(ctx.Request.Context(), httpTransportKey{}, httpTransport{
+			req:       ctx.Request,
+			setHeader: ctx.Header,
+		})
+
+		ctx.Request = ctx.Request.WithContext(c)
+		ctx.Next()
+	}
+}
+
+// requestURL returns the url of the http request, it's nil outside the middleware.
+func requestURL(ctx context.Context) *url.URL {
+	if t, ok := ctx.Value(httpTransportKey{}).(httpTransport); ok {
+		return t.req.URL
+	}
+	return nil
+}
+
+// requestHeader returns the header of the http request, it's empty outside the middleware.
+func requestHeader(ctx context.Context, key string) string {
+	if t, ok := ctx.Value(httpTransportKey{}).(httpTransport); ok {
+		return t.req.Header.Get(key)
+	}
+	return ""
+}
+
+// setHeader sets the header of the http response, it has to be called before the response is
+// written. It does nothing outside the middleware.
+func setHeader(ctx context.Context, key, value string) {
+	if t, ok := ctx.Value(httpTransportKey{}).(httpTransport); ok {
+		t.setHeader(key, value)
+	}
+}
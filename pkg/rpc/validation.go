@@ -0,0 +1,113 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	grpcCodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/AmazingTalker/go-rpc-kit/errorkit"
+)
+
+// FieldViolation tells which field of the request failed which rule.
+type FieldViolation struct {
+	// Field is the json name of the field.
+	Field string `json:"field"`
+	// Rule is the validate tag failed, ex: required, max, uuid.
+	Rule string `json:"rule"`
+	// Param is the param of the rule, ex: 255 of max=255.
+	Param string `json:"param,omitempty"`
+}
+
+// validationError adds the field violations into the error body of the invalid argument error.
+type validationError struct {
+	errorkit.ATError
+	violations []FieldViolation
+}
+
+func (e *validationError) GinHashMap() gin.H {
+	h := gin.H{}
+	for k, v := range e.ATError.GinHashMap() {
+		h[k] = v
+	}
+	h["details"] = e.violations
+
+	return h
+}
+
+// GRPCStatus gives the field violations to the grpc callers as the BadRequest details, the
+// description of a violation is the failed rule. ex: max=255
+func (e *validationError) GRPCStatus() *status.Status {
+	br := &errdetails.BadRequest{}
+	for _, v := range e.violations {
+		desc := v.Rule
+		if v.Param != "" {
+			desc += "=" + v.Param
+		}
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{Field: v.Field, Description: desc})
+	}
+
+	st := status.New(grpcCodes.InvalidArgument, e.Error())
+	if withDetails, err := st.WithDetails(br); err == nil {
+		return withDetails
+	}
+	return st
+}
+
+// valid validates req by the validate tags of rpc.proto. The violations are unwrapped from the
+// error of the validator, which may wrap them into an AT error.
+func (serv GoAmazingServer) valid(ctx context.Context, req interface{}) error {
+	err := serv.validator.Valid(ctx, req)
+	if err == nil {
+		return nil
+	}
+
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		return newInvalidArgumentError(err)
+	}
+
+	violations := make([]FieldViolation, len(errs))
+	for i, e := range errs {
+		violations[i] = FieldViolation{
+			Field: jsonFieldName(req, e.StructField()),
+			Rule:  e.Tag(),
+			Param: e.Param(),
+		}
+	}
+
+	return &validationError{
+		ATError:    errorkit.FormatError(newInvalidArgumentError(err)),
+		violations: violations,
+	}
+}
+
+// jsonFieldName returns the json name of the field of req, or the field name when it has no json tag.
+func jsonFieldName(req interface{}, field string) string {
+	t := reflect.TypeOf(req)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return field
+	}
+
+	f, ok := t.FieldByName(field)
+	if !ok {
+		return field
+	}
+
+	name := strings.Split(f.Tag.Get("json"), ",")[0]
+	if name == "" || name == "-" {
+		return field
+	}
+
+	return name
+}